@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	dto "github.com/gunawanpras/be-product-service/internal/adapter/http/dto/product"
+	importer "github.com/gunawanpras/be-product-service/internal/adapter/importer/product"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+const cmdImport = "import"
+
+// runImport imports a catalog file from the command line, the same way as
+// POST /products/import:
+//
+//	main import [-dry-run] [-report product-import-report.csv] products.xlsx
+//
+// The rows that could not be imported are written to the report file.
+func runImport(ctx context.Context, productImporter importer.Importer, args []string) error {
+	flags := flag.NewFlagSet(cmdImport, flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "check the rows without creating any product")
+	reportPath := flags.String("report", constant.ProductImportReportFilename, "file to write the rows that could not be imported to")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: %s %s [flags] <file.csv|file.xlsx>\n", os.Args[0], cmdImport)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("missing file to import")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	rows, err := importer.NewRowReader(file.Name(), file, info.Size())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	result, err := productImporter.ImportProducts(ctx, rows, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	var res dto.ImportProductsResponse
+	res.ToResponse(result)
+
+	fmt.Printf("rows: %d, created: %d, valid: %d, failed: %d\n", res.Total, res.Created, res.Valid, res.Failed)
+
+	if res.Failed == 0 {
+		return nil
+	}
+
+	report, err := os.Create(*reportPath)
+	if err != nil {
+		return err
+	}
+	defer report.Close()
+
+	if err = res.WriteCSV(report); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%d rows could not be imported, see %s", res.Failed, *reportPath)
+}
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"context"
+	"log"
+	"os"
+
 	"github.com/gunawanpras/be-product-service/config"
 	"github.com/gunawanpras/be-product-service/delivery/server"
 	"github.com/gunawanpras/be-product-service/internal/setup"
@@ -15,9 +19,31 @@ func main() {
 	externalService := setup.InitExternalServices(conf)
 	defer externalService.Postgres.Close()
 
+	if externalService.Nats != nil {
+		defer externalService.Nats.Close()
+	}
+
 	// init core services
 	coreService := setup.InitCoreServices(conf, externalService)
 
+	// import a catalog file instead of serving requests
+	if len(os.Args) > 1 && os.Args[1] == cmdImport {
+		if err := runImport(context.Background(), coreService.Importer.ProductImporter, os.Args[2:]); err != nil {
+			externalService.Postgres.Close()
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	// relay the product events of the outbox and deliver the webhooks while serving
+	// requests
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go coreService.Outbox.RunRelay(ctx)
+	go coreService.Webhook.RunDispatcher(ctx)
+
 	// init server
 	server.Up(coreService.Handler, conf.Server)
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -37,6 +38,8 @@ func Init(opts ...Option) error {
 	viper.SetConfigName(opt.configFile)
 	// Config File Type
 	viper.SetConfigType(opt.configType)
+	// Environment variables override the config file, e.g. SERVER_ADMINTOKEN for server.adminToken
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
 
 	// Application config --> config/config.yaml
@@ -2,15 +2,26 @@ package config
 
 type (
 	Config struct {
-		Server  ServerConfig `yaml:"server"`
-		Postgre PostgreList  `yaml:"postgre"`
-		Redis   RedisList    `yaml:"redis"`
+		Server  ServerConfig  `yaml:"server"`
+		Postgre PostgreList   `yaml:"postgre"`
+		Redis   RedisList     `yaml:"redis"`
+		Outbox  OutboxConfig  `yaml:"outbox"`
+		Nats    NatsConfig    `yaml:"nats"`
+		Webhook WebhookConfig `yaml:"webhook"`
 	}
 
 	ServerConfig struct {
-		Name string `yaml:"name"`
-		Host string `yaml:"host"`
-		Port int    `yaml:"port"`
+		Name              string    `yaml:"name"`
+		Host              string    `yaml:"host"`
+		Port              int       `yaml:"port"`
+		AdminToken        string    `yaml:"adminToken"`
+		IdempotencyKeyTtl int       `yaml:"idempotencyKeyTtl"`
+		MaxPageSize       int       `yaml:"maxPageSize"`
+		MaxBatchSize      int       `yaml:"maxBatchSize"`
+		MaxBulkSize       int       `yaml:"maxBulkSize"`
+		ImportChunkSize   int       `yaml:"importChunkSize"`
+		ImportBodyLimit   int       `yaml:"importBodyLimit"`
+		PriceBuckets      []float64 `yaml:"priceBuckets"`
 	}
 
 	PostgreList struct {
@@ -39,4 +50,32 @@ type (
 		ReadTimeout  int    `yaml:"read_timeout"`
 		WriteTimeout int    `yaml:"write_timeout"`
 	}
+
+	// OutboxConfig configures the relay of the product events, the publisher is either
+	// nats or memory.
+	OutboxConfig struct {
+		Publisher                   string `yaml:"publisher"`
+		PollIntervalInMillisecond   int    `yaml:"pollIntervalInMillisecond"`
+		BatchSize                   int    `yaml:"batchSize"`
+		RetryBaseDelayInMillisecond int    `yaml:"retryBaseDelayInMillisecond"`
+		RetryMaxDelayInMillisecond  int    `yaml:"retryMaxDelayInMillisecond"`
+	}
+
+	NatsConfig struct {
+		Url                    string `yaml:"url"`
+		Stream                 string `yaml:"stream"`
+		Subject                string `yaml:"subject"`
+		PublishTimeoutInSecond int    `yaml:"publishTimeoutInSecond"`
+	}
+
+	// WebhookConfig configures the dispatch of the webhook deliveries. A delivery is
+	// dead-lettered once it failed MaxAttempts times.
+	WebhookConfig struct {
+		PollIntervalInMillisecond int `yaml:"pollIntervalInMillisecond"`
+		BatchSize                 int `yaml:"batchSize"`
+		TimeoutInSecond           int `yaml:"timeoutInSecond"`
+		MaxAttempts               int `yaml:"maxAttempts"`
+		RetryBaseDelayInSecond    int `yaml:"retryBaseDelayInSecond"`
+		RetryMaxDelayInSecond     int `yaml:"retryMaxDelayInSecond"`
+	}
 )
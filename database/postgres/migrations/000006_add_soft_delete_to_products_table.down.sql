@@ -0,0 +1,7 @@
+-- Migration 0006 Down: Drop soft delete columns from products table
+DROP INDEX IF EXISTS idx_products_category_name;
+DROP INDEX IF EXISTS idx_products_deleted_at;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS deleted_by;
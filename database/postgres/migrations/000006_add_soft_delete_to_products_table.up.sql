@@ -0,0 +1,24 @@
+-- Migration 0006 Up: Add soft delete columns to products table
+ALTER TABLE products
+    ADD COLUMN deleted_at TIMESTAMP DEFAULT NULL,
+    ADD COLUMN deleted_by VARCHAR(36) DEFAULT NULL;
+
+CREATE INDEX idx_products_deleted_at ON products(deleted_at);
+
+-- Products already sharing their name within a category would break the unique index
+-- below: the oldest one keeps the name and the others are soft deleted.
+UPDATE products p
+SET
+    deleted_at = CURRENT_TIMESTAMP,
+    deleted_by = 'SYSTEM'
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY category_id, name ORDER BY created_at, id) AS position
+    FROM products
+) duplicates
+WHERE
+    p.id = duplicates.id AND
+    duplicates.position > 1;
+
+-- Only active products are covered, a soft-deleted product keeps its name without
+-- blocking a new product from taking it.
+CREATE UNIQUE INDEX idx_products_category_name ON products(category_id, name) WHERE deleted_at IS NULL;
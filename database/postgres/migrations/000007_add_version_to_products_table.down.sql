@@ -0,0 +1,3 @@
+-- Migration 0007 Down: Drop row version column from products table
+ALTER TABLE products
+    DROP COLUMN IF EXISTS version;
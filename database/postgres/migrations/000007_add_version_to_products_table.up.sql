@@ -0,0 +1,3 @@
+-- Migration 0007 Up: Add row version column to products table
+ALTER TABLE products
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
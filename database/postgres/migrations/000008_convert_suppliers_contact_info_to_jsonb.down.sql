@@ -0,0 +1,4 @@
+-- Migration 0008 Down: Store supplier contact info as free-form text again
+ALTER TABLE suppliers
+    ALTER COLUMN contact_info TYPE VARCHAR(255)
+    USING LEFT(contact_info->>'address', 255);
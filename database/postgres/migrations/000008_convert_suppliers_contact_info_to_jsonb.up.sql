@@ -0,0 +1,8 @@
+-- Migration 0008 Up: Store supplier contact info as structured JSON
+-- Existing free-form values were addresses, keep them as such.
+ALTER TABLE suppliers
+    ALTER COLUMN contact_info TYPE JSONB
+    USING CASE
+        WHEN contact_info IS NULL OR contact_info = '' THEN NULL
+        ELSE jsonb_build_object('address', contact_info)
+    END;
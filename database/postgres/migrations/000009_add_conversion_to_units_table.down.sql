@@ -0,0 +1,10 @@
+-- Migration 0009 Down: Drop dimension and conversion factor from units table
+DROP INDEX IF EXISTS idx_units_base_unit;
+
+ALTER TABLE units
+    DROP CONSTRAINT IF EXISTS chk_units_dimension,
+    DROP CONSTRAINT IF EXISTS chk_units_factor,
+    DROP CONSTRAINT IF EXISTS fk_units_base_unit,
+    DROP COLUMN IF EXISTS factor,
+    DROP COLUMN IF EXISTS base_unit_id,
+    DROP COLUMN IF EXISTS dimension;
@@ -0,0 +1,13 @@
+-- Migration 0009 Up: Add dimension and conversion factor to units table
+-- A unit without base unit is the base of its dimension and has a factor of 1,
+-- any other unit equals factor times its base unit.
+ALTER TABLE units
+    ADD COLUMN dimension    VARCHAR(20) NOT NULL DEFAULT 'count',
+    ADD COLUMN base_unit_id UUID DEFAULT NULL,
+    ADD COLUMN factor       NUMERIC(18, 6) NOT NULL DEFAULT 1,
+    ADD CONSTRAINT fk_units_base_unit FOREIGN KEY (base_unit_id)
+        REFERENCES units(id),
+    ADD CONSTRAINT chk_units_factor CHECK (factor > 0),
+    ADD CONSTRAINT chk_units_dimension CHECK (dimension IN ('mass', 'volume', 'length', 'count'));
+
+CREATE INDEX idx_units_base_unit ON units(base_unit_id);
@@ -0,0 +1,5 @@
+-- Migration 0010 Down: Drop full-text search vector from products table
+DROP INDEX IF EXISTS idx_products_search_vector;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,10 @@
+-- Migration 0010 Up: Add full-text search vector to products table
+-- The simple configuration does not stem words, as product names are mostly Indonesian.
+-- Matches on the name rank above matches on the description.
+ALTER TABLE products
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX idx_products_search_vector ON products USING GIN (search_vector);
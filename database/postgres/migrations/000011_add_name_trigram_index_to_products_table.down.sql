@@ -0,0 +1,3 @@
+-- Migration 0011 Down: Drop trigram index on product names
+-- The pg_trgm extension is kept, other objects of the database may rely on it.
+DROP INDEX IF EXISTS idx_products_name_trgm;
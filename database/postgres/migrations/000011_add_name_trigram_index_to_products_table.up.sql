@@ -0,0 +1,6 @@
+-- Migration 0011 Up: Add trigram index on product names
+-- Backs the similarity matching of product suggestions and fuzzy searches, as well as
+-- case-insensitive prefix matching.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_products_name_trgm ON products USING GIN (name gin_trgm_ops);
@@ -0,0 +1,2 @@
+-- Migration 0012 Down: Drop product_events table
+DROP TABLE IF EXISTS product_events;
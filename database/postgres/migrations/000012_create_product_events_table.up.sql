@@ -0,0 +1,18 @@
+-- Migration 0012 Up: Create product_events table
+-- Outbox of product changes. Each change records its event in the transaction that
+-- writes the product, the relay then publishes the events in the order of their id.
+CREATE TABLE product_events (
+    id              BIGSERIAL PRIMARY KEY,
+    event_id        UUID NOT NULL,
+    product_id      UUID NOT NULL,
+    event_type      VARCHAR(50) NOT NULL,
+    payload         JSONB NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at    TIMESTAMP DEFAULT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP DEFAULT NULL,
+    last_error      TEXT DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX idx_product_events_event_id ON product_events(event_id);
+CREATE INDEX idx_product_events_pending ON product_events(product_id, next_attempt_at) WHERE published_at IS NULL;
@@ -0,0 +1,36 @@
+-- Migration 0013 Up: Create webhooks and webhook_deliveries tables
+-- A webhook subscribes a URL to event types. Each event of such a type is queued as a
+-- delivery of the webhook, which the dispatcher sends until the URL accepts it or the
+-- delivery runs out of attempts and is dead-lettered.
+CREATE TABLE webhooks (
+    id            UUID PRIMARY KEY,
+    url           VARCHAR(2048) NOT NULL,
+    secret        VARCHAR(255) NOT NULL,
+    event_types   VARCHAR(50)[] NOT NULL,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by    VARCHAR(36)
+);
+
+CREATE INDEX idx_webhooks_event_types ON webhooks USING GIN (event_types);
+
+CREATE TABLE webhook_deliveries (
+    id               UUID PRIMARY KEY,
+    webhook_id       UUID NOT NULL,
+    event_id         UUID NOT NULL,
+    event_type       VARCHAR(50) NOT NULL,
+    payload          JSONB NOT NULL,
+    status           VARCHAR(20) NOT NULL,
+    attempts         INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMP NOT NULL,
+    last_attempt_at  TIMESTAMP DEFAULT NULL,
+    last_status_code INTEGER DEFAULT NULL,
+    last_error       TEXT DEFAULT NULL,
+    delivered_at     TIMESTAMP DEFAULT NULL,
+    created_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_webhook_deliveries_webhook FOREIGN KEY (webhook_id)
+         REFERENCES webhooks(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_webhook_deliveries_event ON webhook_deliveries(webhook_id, event_id);
+CREATE INDEX idx_webhook_deliveries_created_at ON webhook_deliveries(webhook_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,6 @@
+-- Migration 0014 Down: Drop stock_movements table
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS chk_products_stock,
+    ALTER COLUMN stock DROP NOT NULL;
+
+DROP TABLE IF EXISTS stock_movements;
@@ -0,0 +1,34 @@
+-- Migration 0014 Up: Create stock_movements table
+-- Ledger of the stock of the products. Every change of products.stock records a movement
+-- in the same transaction, so the stock of a product is the stock_after of its latest
+-- movement. The stock of the existing products is opened with an adjustment.
+CREATE TABLE stock_movements (
+    id            UUID PRIMARY KEY,
+    product_id    UUID NOT NULL,
+    movement_type VARCHAR(20) NOT NULL,
+    quantity      INTEGER NOT NULL,
+    stock_after   INTEGER NOT NULL,
+    reason        VARCHAR(255) DEFAULT NULL,
+    reference_id  VARCHAR(100) DEFAULT NULL,
+    actor         VARCHAR(100) NOT NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_stock_movements_product FOREIGN KEY (product_id)
+         REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT chk_stock_movements_type CHECK (movement_type IN ('receipt', 'sale', 'adjustment', 'return', 'transfer')),
+    CONSTRAINT chk_stock_movements_quantity CHECK (quantity <> 0)
+);
+
+CREATE INDEX idx_stock_movements_product ON stock_movements(product_id, created_at DESC);
+CREATE UNIQUE INDEX idx_stock_movements_reference ON stock_movements(product_id, movement_type, reference_id) WHERE reference_id IS NOT NULL;
+
+-- the stock of a product is now always known, a missing stock being none
+UPDATE products SET stock = 0 WHERE stock IS NULL;
+
+ALTER TABLE products
+    ALTER COLUMN stock SET NOT NULL,
+    ADD CONSTRAINT chk_products_stock CHECK (stock >= 0);
+
+INSERT INTO stock_movements (id, product_id, movement_type, quantity, stock_after, reason, actor, created_at)
+SELECT gen_random_uuid(), id, 'adjustment', stock, stock, 'opening balance', 'SYSTEM', CURRENT_TIMESTAMP
+FROM products
+WHERE stock <> 0;
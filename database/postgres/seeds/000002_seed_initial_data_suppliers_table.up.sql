@@ -1,7 +1,7 @@
 INSERT INTO suppliers
     (id, name, contact_info, created_at, created_by, updated_at, updated_by)
 VALUES
-    ('00000000-0000-0000-0000-000000000011', 'Toko Sayuran Segar', 'Jl. Sayuran No.1, Jakarta', CURRENT_TIMESTAMP, 'SYSTEM', NULL, ''),
-    ('00000000-0000-0000-0000-000000000012', 'Toko Protein Utama', 'Jl. Daging No.2, Bandung', CURRENT_TIMESTAMP, 'SYSTEM', NULL, ''),
-    ('00000000-0000-0000-0000-000000000013', 'Pusat Buah Asli', 'Jl. Buah No.3, Surabaya', CURRENT_TIMESTAMP, 'SYSTEM', NULL, ''),
-    ('00000000-0000-0000-0000-000000000014', 'Camilan Nusantara', 'Jl. Camilan No.4, Medan', CURRENT_TIMESTAMP, 'SYSTEM', NULL, '');
+    ('00000000-0000-0000-0000-000000000011', 'Toko Sayuran Segar', '{"address": "Jl. Sayuran No.1, Jakarta"}', CURRENT_TIMESTAMP, 'SYSTEM', NULL, ''),
+    ('00000000-0000-0000-0000-000000000012', 'Toko Protein Utama', '{"address": "Jl. Daging No.2, Bandung"}', CURRENT_TIMESTAMP, 'SYSTEM', NULL, ''),
+    ('00000000-0000-0000-0000-000000000013', 'Pusat Buah Asli', '{"address": "Jl. Buah No.3, Surabaya"}', CURRENT_TIMESTAMP, 'SYSTEM', NULL, ''),
+    ('00000000-0000-0000-0000-000000000014', 'Camilan Nusantara', '{"address": "Jl. Camilan No.4, Medan"}', CURRENT_TIMESTAMP, 'SYSTEM', NULL, '');
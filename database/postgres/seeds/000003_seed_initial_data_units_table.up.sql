@@ -1,7 +1,10 @@
 INSERT INTO units
-    (id, unit_name)
+    (id, unit_name, dimension, base_unit_id, factor)
 VALUES
-    ('00000000-0000-0000-0000-000000000021', 'kg'),
-    ('00000000-0000-0000-0000-000000000022', 'pack'),
-    ('00000000-0000-0000-0000-000000000023', 'pcs'),
-    ('00000000-0000-0000-0000-000000000024', 'bottle');
+    ('00000000-0000-0000-0000-000000000021', 'kg', 'mass', NULL, 1),
+    ('00000000-0000-0000-0000-000000000022', 'pack', 'count', NULL, 1),
+    ('00000000-0000-0000-0000-000000000023', 'pcs', 'count', NULL, 1),
+    ('00000000-0000-0000-0000-000000000024', 'bottle', 'count', NULL, 1),
+    ('00000000-0000-0000-0000-000000000025', 'g', 'mass', '00000000-0000-0000-0000-000000000021', 0.001),
+    ('00000000-0000-0000-0000-000000000026', 'ons', 'mass', '00000000-0000-0000-0000-000000000021', 0.1),
+    ('00000000-0000-0000-0000-000000000027', 'lusin', 'count', '00000000-0000-0000-0000-000000000023', 12);
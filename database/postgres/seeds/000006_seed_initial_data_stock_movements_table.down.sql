@@ -0,0 +1 @@
+DELETE FROM stock_movements;
\ No newline at end of file
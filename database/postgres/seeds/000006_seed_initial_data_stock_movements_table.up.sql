@@ -0,0 +1,5 @@
+INSERT INTO stock_movements
+    (id, product_id, movement_type, quantity, stock_after, reason, reference_id, actor, created_at)
+SELECT gen_random_uuid(), id, 'adjustment', stock, stock, 'initial stock', NULL, created_by, created_at
+FROM products
+WHERE stock <> 0;
\ No newline at end of file
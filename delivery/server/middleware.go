@@ -0,0 +1,68 @@
+package server
+
+import (
+	"crypto/subtle"
+	"io"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/response"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+// adminOnly only lets requests through when they carry the configured admin token.
+// An empty token disables the admin routes altogether.
+func adminOnly(adminToken string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token := c.Get(constant.HeaderAdminToken)
+		if adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			return response.Error(c, constant.AdminAccessRequired, apperror.Forbidden(constant.CodeInvalidAdminToken, constant.InvalidAdminToken))
+		}
+
+		return c.Next()
+	}
+}
+
+// bodyLimit rejects the requests whose body is larger than limit bytes, or than the limit
+// given for their route in routeLimits, keyed by method and path. As request bodies are
+// streamed, fasthttp hands the larger ones over instead of rejecting them: the body is read
+// here up to the limit, except for the routes of routeLimits which read it as it arrives.
+func bodyLimit(limit int, routeLimits map[string]int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := c.Request()
+		if routeLimit, ok := routeLimits[c.Method()+" "+strings.TrimSuffix(c.Path(), "/")]; ok {
+			if req.Header.ContentLength() > routeLimit {
+				return bodyTooLarge(c)
+			}
+
+			return c.Next()
+		}
+
+		if req.Header.ContentLength() > limit {
+			return bodyTooLarge(c)
+		}
+
+		if req.IsBodyStream() {
+			body, err := io.ReadAll(io.LimitReader(req.BodyStream(), int64(limit)+1))
+			if err != nil {
+				return response.Error(c, constant.BindingParameterFailed, fiber.NewError(fiber.StatusBadRequest, err.Error()))
+			}
+
+			if len(body) > limit {
+				return bodyTooLarge(c)
+			}
+
+			req.SetBody(body)
+		}
+
+		return c.Next()
+	}
+}
+
+// bodyTooLarge rejects a request whose body is over its limit. The connection is closed as
+// the rest of the body is left unread.
+func bodyTooLarge(c *fiber.Ctx) error {
+	c.Context().SetConnectionClose()
+	return response.Error(c, constant.RequestBodyTooLarge, fiber.ErrRequestEntityTooLarge)
+}
@@ -2,15 +2,65 @@ package server
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/gunawanpras/be-product-service/config"
 	"github.com/gunawanpras/be-product-service/internal/setup"
 )
 
-func NewRouter(app *fiber.App, handler setup.Handler) {
-	// app.Use(recover.New())
+func NewRouter(app *fiber.App, handler setup.Handler, config config.ServerConfig) {
+	app.Use(bodyLimit(fiber.DefaultBodyLimit, map[string]int{
+		fiber.MethodPost + " /products/import": config.ImportBodyLimit,
+	}))
 	app.Get("/favicon.ico", func(c *fiber.Ctx) error { return nil })
 
 	products := app.Group("/products")
-	products.Post("/", handler.ProductHandler.CreateProduct)
+	products.Post("/", handler.Middleware.Idempotency, handler.ProductHandler.CreateProduct)
 	products.Get("/", handler.ProductHandler.GetListProduct)
+	products.Post("/bulk", handler.Middleware.Idempotency, handler.ProductHandler.CreateProducts)
+	products.Post("/batch-get", handler.ProductHandler.BatchGetProduct)
+	products.Post("/import", handler.ProductHandler.ImportProducts)
+	products.Get("/suggest", handler.ProductHandler.SuggestProduct)
+	products.Get("/facets", handler.ProductHandler.GetProductFacets)
+	products.Get("/export", handler.ProductHandler.ExportProduct)
 	products.Get("/:id", handler.ProductHandler.GetProductByID)
+	products.Put("/:id", handler.ProductHandler.UpdateProduct)
+	products.Patch("/:id", handler.ProductHandler.PatchProduct)
+	products.Delete("/:id", handler.ProductHandler.DeleteProduct)
+	products.Post("/:id/restore", handler.ProductHandler.RestoreProduct)
+	products.Post("/:id/discount", handler.ProductHandler.CreateProductDiscount)
+	products.Put("/:id/discount", handler.ProductHandler.UpdateProductDiscount)
+	products.Delete("/:id/discount", handler.ProductHandler.DeleteProductDiscount)
+	products.Post("/:id/stock/movements", handler.Middleware.Idempotency, handler.ProductHandler.CreateStockMovement)
+	products.Get("/:id/stock/history", handler.ProductHandler.GetStockHistory)
+
+	categories := app.Group("/categories")
+	categories.Post("/", handler.CategoryHandler.CreateCategory)
+	categories.Get("/", handler.CategoryHandler.GetListCategory)
+	categories.Get("/:id", handler.CategoryHandler.GetCategoryByID)
+	categories.Put("/:id", handler.CategoryHandler.UpdateCategory)
+	categories.Delete("/:id", handler.CategoryHandler.DeleteCategory)
+
+	suppliers := app.Group("/suppliers")
+	suppliers.Post("/", handler.SupplierHandler.CreateSupplier)
+	suppliers.Get("/", handler.SupplierHandler.GetListSupplier)
+	suppliers.Get("/:id", handler.SupplierHandler.GetSupplierByID)
+	suppliers.Get("/:id/products", handler.SupplierHandler.GetListSupplierProduct)
+	suppliers.Put("/:id", handler.SupplierHandler.UpdateSupplier)
+	suppliers.Delete("/:id", handler.SupplierHandler.DeleteSupplier)
+
+	units := app.Group("/units")
+	units.Post("/", handler.UnitHandler.CreateUnit)
+	units.Get("/", handler.UnitHandler.GetListUnit)
+	units.Get("/:id", handler.UnitHandler.GetUnitByID)
+	units.Put("/:id", handler.UnitHandler.UpdateUnit)
+	units.Delete("/:id", handler.UnitHandler.DeleteUnit)
+
+	webhooks := app.Group("/webhooks", adminOnly(config.AdminToken))
+	webhooks.Post("/", handler.WebhookHandler.CreateWebhook)
+	webhooks.Get("/", handler.WebhookHandler.GetListWebhook)
+	webhooks.Get("/:id", handler.WebhookHandler.GetWebhookByID)
+	webhooks.Delete("/:id", handler.WebhookHandler.DeleteWebhook)
+	webhooks.Get("/:id/deliveries", handler.WebhookHandler.GetListDelivery)
+
+	admin := app.Group("/admin", adminOnly(config.AdminToken))
+	admin.Delete("/products/:id", handler.ProductHandler.PurgeProduct)
 }
@@ -6,24 +6,31 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gunawanpras/be-product-service/config"
 	"github.com/gunawanpras/be-product-service/internal/setup"
 	"github.com/gunawanpras/be-product-service/pkg/response"
-	"github.com/gunawanpras/be-product-service/pkg/util/constant"
 )
 
 func Up(handler setup.Handler, config config.ServerConfig) {
 	app := fiber.New(
 		fiber.Config{
 			ErrorHandler: func(c *fiber.Ctx, err error) error {
-				return response.Error(c, err.Error(), err, constant.GenericHttpStatusMappings)
+				return response.Error(c, err.Error(), err)
 			},
+			// bodies are streamed so that import files are read as they arrive, the body
+			// limits are enforced by the bodyLimit middleware
+			StreamRequestBody:            true,
+			DisablePreParseMultipartForm: true,
 		},
 	)
 
 	app.Use(cors.New())
+	// a panicking handler answers 500 instead of dropping the connection, once the
+	// idempotency middleware released its key
+	app.Use(recover.New())
 
-	NewRouter(app, handler)
+	NewRouter(app, handler, config)
 
 	err := app.Listen(fmt.Sprintf(":%d", config.Port))
 	if err != nil {
@@ -2,9 +2,11 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	rCache "github.com/go-redis/cache/v8"
+	"github.com/go-redis/redis/v8"
 )
 
 func (r *RedisClient) SetValue(ctx context.Context, key string, value interface{}, ttl time.Duration) (err error) {
@@ -22,6 +24,35 @@ func (r *RedisClient) SetValue(ctx context.Context, key string, value interface{
 	return
 }
 
+// SetValueNX sets the value only when the key does not exist yet, atomically, and reports
+// whether it did. It writes to Redis directly so that every instance sees the key.
+func (r *RedisClient) SetValueNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (ok bool, err error) {
+	b, err := r.cache.Client.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	return r.cache.Redis.SetNX(ctx, key, b, ttl).Result()
+}
+
+// SetValues sets several keys in a single round trip, pipelining their SET commands.
+func (r *RedisClient) SetValues(ctx context.Context, values map[string]interface{}, ttl time.Duration) (err error) {
+	_, err = r.cache.Redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range values {
+			b, err := r.cache.Client.Marshal(value)
+			if err != nil {
+				return err
+			}
+
+			pipe.Set(ctx, key, b, ttl)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
 func (r *RedisClient) GetValue(ctx context.Context, key string) (value string, err error) {
 	if err = r.cache.Client.Get(ctx, key, &value); err != nil {
 		return
@@ -30,6 +61,40 @@ func (r *RedisClient) GetValue(ctx context.Context, key string) (value string, e
 	return
 }
 
+// GetValueDirect reads the value from Redis directly, skipping the local cache of the
+// instance, so that it sees the keys written by SetValueNX on every instance. A missing
+// key returns cache.ErrCacheMiss, as GetValue does.
+func (r *RedisClient) GetValueDirect(ctx context.Context, key string) (value string, err error) {
+	value, err = r.cache.Redis.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", rCache.ErrCacheMiss
+	}
+
+	return value, err
+}
+
+// GetValues reads several keys with a single MGET, in the order of keys. The value of a
+// missing key is nil.
+func (r *RedisClient) GetValues(ctx context.Context, keys []string) (values []*string, err error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	results, err := r.cache.Redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	values = make([]*string, len(results))
+	for i, result := range results {
+		if value, ok := result.(string); ok {
+			values[i] = &value
+		}
+	}
+
+	return values, nil
+}
+
 func (r *RedisClient) DeleteValue(ctx context.Context, key string) (err error) {
 	if err = r.cache.Client.Delete(ctx, key); err != nil {
 		return
@@ -37,3 +102,9 @@ func (r *RedisClient) DeleteValue(ctx context.Context, key string) (err error) {
 
 	return
 }
+
+// Increment atomically increments the integer stored at key, starting from zero, and
+// returns its new value. Like SetValueNX, it goes to Redis directly.
+func (r *RedisClient) Increment(ctx context.Context, key string) (value int64, err error) {
+	return r.cache.Redis.Incr(ctx, key).Result()
+}
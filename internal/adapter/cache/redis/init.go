@@ -26,5 +26,5 @@ func (init InitAttribute) validate() error {
 }
 
 func (client Client) validate() bool {
-	return client.Client != nil
+	return client.Client != nil && client.Redis != nil
 }
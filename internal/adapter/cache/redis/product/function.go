@@ -2,17 +2,73 @@ package product
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"fmt"
-	"strings"
+	"errors"
 	"time"
 
+	rCache "github.com/go-redis/cache/v8"
+	"github.com/google/uuid"
 	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
 )
 
-func (r *ProductCache) SetListProductCache(ctx context.Context, productName, categoryType, sort, direction string, products domain.Products) (err error) {
-	cacheKey := fmt.Sprintf("products:product_name:%s:category_type:%s:sort:%s:direction:%s", productName, categoryType, sort, direction)
-	cacheValue, err := json.Marshal(products)
+// listGenerationCacheKey holds the generation of the product list and facets keys, which
+// every product write bumps.
+const listGenerationCacheKey = "products:generation"
+
+func (r *ProductCache) SetListProductCache(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort, page domain.ListPage, productPage domain.ProductPage) (err error) {
+	generation, err := r.listGeneration(ctx)
+	if err != nil {
+		return err
+	}
+
+	cacheKey := listProductCacheKey(generation, filter, sort, page)
+	cacheValue, err := json.Marshal(productPage)
+	if err != nil {
+		return err
+	}
+	cacheTtl := time.Duration(r.config.Redis.Primary.Ttl) * time.Minute
+
+	err = r.redis.RedisClient.SetValue(ctx, cacheKey, cacheValue, cacheTtl)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ProductCache) GetListProductCache(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort, page domain.ListPage) (res domain.ProductPage, err error) {
+	generation, err := r.listGeneration(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	cacheKey := listProductCacheKey(generation, filter, sort, page)
+	cacheValue, err := r.redis.RedisClient.GetValue(ctx, cacheKey)
+	if err != nil {
+		cacheValue = "{}"
+		if !errors.Is(err, rCache.ErrCacheMiss) {
+			return res, err
+		}
+	}
+
+	err = json.Unmarshal([]byte(cacheValue), &res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (r *ProductCache) SetProductFacetsCache(ctx context.Context, filter domain.ProductFilter, priceBounds []float64, facets domain.ProductFacets) (err error) {
+	generation, err := r.listGeneration(ctx)
+	if err != nil {
+		return err
+	}
+
+	cacheKey := productFacetsCacheKey(generation, filter, priceBounds)
+	cacheValue, err := json.Marshal(facets)
 	if err != nil {
 		return err
 	}
@@ -26,12 +82,17 @@ func (r *ProductCache) SetListProductCache(ctx context.Context, productName, cat
 	return nil
 }
 
-func (r *ProductCache) GetListProductCache(ctx context.Context, productName, categoryType, sort, direction string) (res domain.Products, err error) {
-	cacheKey := fmt.Sprintf("products:product_name:%s:category_type:%s:sort:%s:direction:%s", productName, categoryType, sort, direction)
+func (r *ProductCache) GetProductFacetsCache(ctx context.Context, filter domain.ProductFilter, priceBounds []float64) (res domain.ProductFacets, err error) {
+	generation, err := r.listGeneration(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	cacheKey := productFacetsCacheKey(generation, filter, priceBounds)
 	cacheValue, err := r.redis.RedisClient.GetValue(ctx, cacheKey)
 	if err != nil {
 		cacheValue = "{}"
-		if !strings.Contains(err.Error(), "key is missing") {
+		if !errors.Is(err, rCache.ErrCacheMiss) {
 			return res, err
 		}
 	}
@@ -43,3 +104,116 @@ func (r *ProductCache) GetListProductCache(ctx context.Context, productName, cat
 
 	return res, nil
 }
+
+// SetProductCache caches every product on its own key, so that they can be read back
+// whatever batch they are asked in. The keys are written in a single round trip.
+func (r *ProductCache) SetProductCache(ctx context.Context, products domain.Products) (err error) {
+	if len(products) == 0 {
+		return nil
+	}
+
+	cacheTtl := time.Duration(r.config.Redis.Primary.Ttl) * time.Minute
+
+	cacheValues := make(map[string]any, len(products))
+	for _, product := range products {
+		cacheValue, err := json.Marshal(product)
+		if err != nil {
+			return err
+		}
+
+		cacheValues[productCacheKey(product.ID)] = cacheValue
+	}
+
+	return r.redis.RedisClient.SetValues(ctx, cacheValues, cacheTtl)
+}
+
+// GetProductCache reads the cached products among the given IDs with a single MGET, the
+// products that are not cached are left out.
+func (r *ProductCache) GetProductCache(ctx context.Context, productIDs []uuid.UUID) (res domain.Products, err error) {
+	cacheKeys := make([]string, len(productIDs))
+	for i, productID := range productIDs {
+		cacheKeys[i] = productCacheKey(productID)
+	}
+
+	cacheValues, err := r.redis.RedisClient.GetValues(ctx, cacheKeys)
+	if err != nil {
+		return res, err
+	}
+
+	for _, cacheValue := range cacheValues {
+		if cacheValue == nil {
+			continue
+		}
+
+		var product domain.Product
+		err = json.Unmarshal([]byte(*cacheValue), &product)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, product)
+	}
+
+	return res, nil
+}
+
+// DeleteProductCache drops the cached copy of a product.
+func (r *ProductCache) DeleteProductCache(ctx context.Context, productID uuid.UUID) (err error) {
+	return r.redis.RedisClient.DeleteValue(ctx, productCacheKey(productID))
+}
+
+// InvalidateListProductCache drops every cached page of the product list and every cached
+// facets at once, by moving their keys to a new generation. The entries of the previous
+// generation are never read again and expire with their TTL.
+func (r *ProductCache) InvalidateListProductCache(ctx context.Context) (err error) {
+	_, err = r.redis.RedisClient.Increment(ctx, listGenerationCacheKey)
+	return err
+}
+
+// listGeneration reads the current generation of the list and facets keys, straight from
+// Redis so that every instance switches generation as soon as it is bumped.
+func (r *ProductCache) listGeneration(ctx context.Context) (string, error) {
+	values, err := r.redis.RedisClient.GetValues(ctx, []string{listGenerationCacheKey})
+	if err != nil {
+		return "", err
+	}
+
+	if len(values) == 0 || values[0] == nil {
+		return "0", nil
+	}
+
+	return *values[0], nil
+}
+
+// productCacheKey builds the key of a single product.
+func productCacheKey(productID uuid.UUID) string {
+	return "products:item:" + productID.String()
+}
+
+// listProductCacheKey builds the key of a page of the product list. The key is a hash
+// of every criterion of the query, so pages of different filters, sorts, sizes, numbers
+// or cursors never share a key, under the current generation of the list.
+func listProductCacheKey(generation string, filter domain.ProductFilter, sort domain.ProductSort, page domain.ListPage) string {
+	return hashCacheKey("products:list:"+generation+":", struct {
+		Filter domain.ProductFilter `json:"filter"`
+		Sort   domain.ProductSort   `json:"sort"`
+		Page   domain.ListPage      `json:"page"`
+	}{filter, sort, page})
+}
+
+// productFacetsCacheKey builds the key of the facets of a product filter, the same way
+// as listProductCacheKey.
+func productFacetsCacheKey(generation string, filter domain.ProductFilter, priceBounds []float64) string {
+	return hashCacheKey("products:facets:"+generation+":", struct {
+		Filter      domain.ProductFilter `json:"filter"`
+		PriceBounds []float64            `json:"price_bounds"`
+	}{filter, priceBounds})
+}
+
+// hashCacheKey appends the hash of the JSON encoding of criteria to prefix.
+func hashCacheKey(prefix string, criteria any) string {
+	encoded, _ := json.Marshal(criteria)
+	sum := sha256.Sum256(encoded)
+
+	return prefix + hex.EncodeToString(sum[:])
+}
@@ -5,13 +5,19 @@ import (
 	"time"
 
 	"github.com/go-redis/cache/v8"
+	"github.com/go-redis/redis/v8"
 )
 
 type (
 	Cache interface {
 		SetValue(ctx context.Context, key string, value any, ttl time.Duration) (err error)
+		SetValueNX(ctx context.Context, key string, value any, ttl time.Duration) (ok bool, err error)
+		SetValues(ctx context.Context, values map[string]any, ttl time.Duration) (err error)
 		GetValue(ctx context.Context, key string) (string, error)
+		GetValueDirect(ctx context.Context, key string) (string, error)
+		GetValues(ctx context.Context, keys []string) ([]*string, error)
 		DeleteValue(ctx context.Context, key string) error
+		Increment(ctx context.Context, key string) (int64, error)
 	}
 
 	RedisClient struct {
@@ -24,5 +30,6 @@ type (
 
 	Client struct {
 		Client *cache.Cache
+		Redis  *redis.Client
 	}
 )
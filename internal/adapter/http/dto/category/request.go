@@ -0,0 +1,22 @@
+package dto
+
+import "github.com/google/uuid"
+
+type CreateCategoryRequest struct {
+	Name        string  `json:"name" validate:"required,min=3,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=255"`
+}
+
+type UpdateCategoryRequest struct {
+	ID          uuid.UUID `json:"-" uri:"id" validate:"required,uuid"`
+	Name        string    `json:"name" validate:"required,min=3,max=100"`
+	Description *string   `json:"description" validate:"omitempty,max=255"`
+}
+
+type GetListCategoryRequest struct {
+	CategoryName string `query:"category_name" validate:"omitempty,max=100"`
+}
+
+type GetCategoryByIDRequest struct {
+	ID uuid.UUID `uri:"id" validate:"required,uuid"`
+}
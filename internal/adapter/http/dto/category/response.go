@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/category/domain"
+)
+
+type (
+	CreateCategoryResponse struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	GetCategoryResponse struct {
+		ID          uuid.UUID `json:"id"`
+		Name        string    `json:"name"`
+		Description *string   `json:"description"`
+		CreatedAt   string    `json:"created_at"`
+		CreatedBy   string    `json:"created_by"`
+		UpdatedAt   *string   `json:"updated_at,omitempty"`
+		UpdatedBy   *string   `json:"updated_by,omitempty"`
+	}
+
+	GetListCategoryResponse []GetCategoryResponse
+)
+
+func (r *GetCategoryResponse) ToResponse(category domain.Category) {
+	*r = GetCategoryResponse{
+		ID:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+		CreatedAt:   category.CreatedAt.Format(time.RFC3339),
+		CreatedBy:   category.CreatedBy,
+		UpdatedAt:   formatTime(category.UpdatedAt),
+		UpdatedBy:   category.UpdatedBy,
+	}
+}
+
+func (r *GetListCategoryResponse) ToResponse(categories domain.Categories) {
+	for _, category := range categories {
+		var res GetCategoryResponse
+		res.ToResponse(category)
+		*r = append(*r, res)
+	}
+}
+
+// formatTime formats an optional timestamp as RFC3339, keeping nil values nil.
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.Format(time.RFC3339)
+
+	return &formatted
+}
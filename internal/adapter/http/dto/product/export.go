@@ -0,0 +1,128 @@
+package dto
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/parquet-go/parquet-go"
+)
+
+// ProductExportWriter writes the products of a catalog export one at a time. Close writes
+// out whatever the format still holds, such as the footer of a Parquet file, but leaves
+// the underlying writer open.
+type ProductExportWriter interface {
+	Write(product ExportProductResponse) error
+	Close() error
+}
+
+// NewProductExportWriter writes a catalog export in the given format, CSV unless the
+// format is NDJSON or Parquet. Parquet files hold up to constant.ProductExportRowGroupSize
+// products in memory before writing them out as a row group.
+func NewProductExportWriter(format string, w io.Writer) ProductExportWriter {
+	switch format {
+	case constant.ProductExportFormatNDJSON:
+		return &ndjsonExportWriter{encoder: json.NewEncoder(w)}
+	case constant.ProductExportFormatParquet:
+		return &parquetExportWriter{
+			writer: parquet.NewGenericWriter[ExportProductResponse](w, parquet.MaxRowsPerRowGroup(constant.ProductExportRowGroupSize)),
+		}
+	default:
+		return &csvExportWriter{writer: csv.NewWriter(w)}
+	}
+}
+
+type csvExportWriter struct {
+	writer      *csv.Writer
+	wroteHeader bool
+}
+
+// exportColumns are the columns of a CSV export, named after the fields of
+// ExportProductResponse.
+var exportColumns = []string{
+	"id", "category_id", "category_name", "supplier_id", "supplier_name", "unit_id",
+	"unit_name", "name", "description", "base_price", "stock", "created_at", "created_by",
+	"updated_at", "updated_by", "deleted_at", "version",
+}
+
+func (w *csvExportWriter) Write(product ExportProductResponse) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	return w.writer.Write([]string{
+		product.ID,
+		product.CategoryID,
+		product.CategoryName,
+		product.SupplierID,
+		stringValue(product.SupplierName),
+		product.UnitID,
+		product.UnitName,
+		product.Name,
+		stringValue(product.Description),
+		strconv.FormatFloat(product.BasePrice, 'f', -1, 64),
+		strconv.Itoa(product.Stock),
+		product.CreatedAt,
+		product.CreatedBy,
+		stringValue(product.UpdatedAt),
+		stringValue(product.UpdatedBy),
+		stringValue(product.DeletedAt),
+		strconv.Itoa(product.Version),
+	})
+}
+
+// writeHeader names the columns once, even when no product is exported.
+func (w *csvExportWriter) writeHeader() error {
+	if w.wroteHeader {
+		return nil
+	}
+
+	w.wroteHeader = true
+
+	return w.writer.Write(exportColumns)
+}
+
+func (w *csvExportWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonExportWriter) Write(product ExportProductResponse) error {
+	return w.encoder.Encode(product)
+}
+
+func (w *ndjsonExportWriter) Close() error {
+	return nil
+}
+
+type parquetExportWriter struct {
+	writer *parquet.GenericWriter[ExportProductResponse]
+}
+
+func (w *parquetExportWriter) Write(product ExportProductResponse) error {
+	_, err := w.writer.Write([]ExportProductResponse{product})
+	return err
+}
+
+func (w *parquetExportWriter) Close() error {
+	return w.writer.Close()
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,89 @@
+package dto_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	dto "github.com/gunawanpras/be-product-service/internal/adapter/http/dto/product"
+	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProductExportWriter(t *testing.T) {
+	description := "Kangkung segar"
+	product := domain.Product{
+		ID:          uuid.MustParse("a1b2c3d4-0000-0000-0000-000000000001"),
+		CategoryID:  uuid.MustParse("a1b2c3d4-0000-0000-0000-000000000002"),
+		SupplierID:  uuid.MustParse("a1b2c3d4-0000-0000-0000-000000000003"),
+		UnitID:      uuid.MustParse("a1b2c3d4-0000-0000-0000-000000000004"),
+		Name:        "Kangkung, Potong",
+		Description: &description,
+		BasePrice:   4500.5,
+		Stock:       12,
+		CreatedAt:   time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC),
+		CreatedBy:   "SYSTEM",
+		Version:     2,
+		Category:    &domain.ProductRelation{Name: "Sayuran"},
+		Unit:        &domain.ProductRelation{Name: "ikat"},
+	}
+
+	var row dto.ExportProductResponse
+	row.ToResponse(product)
+
+	tests := []struct {
+		name     string
+		format   string
+		products []dto.ExportProductResponse
+		want     string
+	}{
+		{
+			name:     "csv",
+			format:   constant.ProductExportFormatCSV,
+			products: []dto.ExportProductResponse{row},
+			want: "id,category_id,category_name,supplier_id,supplier_name,unit_id,unit_name,name,description,base_price,stock,created_at,created_by,updated_at,updated_by,deleted_at,version\n" +
+				"a1b2c3d4-0000-0000-0000-000000000001,a1b2c3d4-0000-0000-0000-000000000002,Sayuran,a1b2c3d4-0000-0000-0000-000000000003,,a1b2c3d4-0000-0000-0000-000000000004,ikat,\"Kangkung, Potong\",Kangkung segar,4500.5,12,2025-03-01T08:00:00Z,SYSTEM,,,,2\n",
+		},
+		{
+			name:   "csv without products still names the columns",
+			format: constant.ProductExportFormatCSV,
+			want:   "id,category_id,category_name,supplier_id,supplier_name,unit_id,unit_name,name,description,base_price,stock,created_at,created_by,updated_at,updated_by,deleted_at,version\n",
+		},
+		{
+			name:     "ndjson",
+			format:   constant.ProductExportFormatNDJSON,
+			products: []dto.ExportProductResponse{row, row},
+			want: `{"id":"a1b2c3d4-0000-0000-0000-000000000001","category_id":"a1b2c3d4-0000-0000-0000-000000000002","category_name":"Sayuran","supplier_id":"a1b2c3d4-0000-0000-0000-000000000003","supplier_name":null,"unit_id":"a1b2c3d4-0000-0000-0000-000000000004","unit_name":"ikat","name":"Kangkung, Potong","description":"Kangkung segar","base_price":4500.5,"stock":12,"created_at":"2025-03-01T08:00:00Z","created_by":"SYSTEM","updated_at":null,"updated_by":null,"deleted_at":null,"version":2}` + "\n" +
+				`{"id":"a1b2c3d4-0000-0000-0000-000000000001","category_id":"a1b2c3d4-0000-0000-0000-000000000002","category_name":"Sayuran","supplier_id":"a1b2c3d4-0000-0000-0000-000000000003","supplier_name":null,"unit_id":"a1b2c3d4-0000-0000-0000-000000000004","unit_name":"ikat","name":"Kangkung, Potong","description":"Kangkung segar","base_price":4500.5,"stock":12,"created_at":"2025-03-01T08:00:00Z","created_by":"SYSTEM","updated_at":null,"updated_by":null,"deleted_at":null,"version":2}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			writer := dto.NewProductExportWriter(tt.format, &buf)
+			for _, product := range tt.products {
+				assert.NoError(t, writer.Write(product))
+			}
+			assert.NoError(t, writer.Close())
+
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	t.Run("parquet", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		writer := dto.NewProductExportWriter(constant.ProductExportFormatParquet, &buf)
+		assert.NoError(t, writer.Write(row))
+		assert.NoError(t, writer.Close())
+
+		got, err := parquet.Read[dto.ExportProductResponse](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.ExportProductResponse{row}, got)
+	})
+}
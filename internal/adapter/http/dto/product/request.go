@@ -1,6 +1,18 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/pageutil"
+	"github.com/gunawanpras/be-product-service/pkg/validator"
+)
 
 type CreateProductRequest struct {
 	CategoryID  uuid.UUID `json:"category_id" validate:"required,uuid"`
@@ -12,20 +24,456 @@ type CreateProductRequest struct {
 	Stock       int       `json:"stock" validate:"required,gte=0"`
 }
 
+// CreateProductsRequest holds the products of a bulk creation. Each product is validated
+// on its own, so that an invalid product does not reject the others unless Atomic is set.
+type CreateProductsRequest struct {
+	Products []CreateProductRequest `json:"products" validate:"required,min=1"`
+	Atomic   bool                   `json:"-" query:"atomic"`
+}
+
+// ToItems validates every product of the bulk and converts it into an item to create.
+// The items failing validation carry domain.ErrProductBulkItemInvalid, and their failed
+// validations are returned at the same index. A bulk may not hold more than maxBulkSize
+// products.
+func (r CreateProductsRequest) ToItems(maxBulkSize int) (res []domain.ProductBulkItem, fieldErrors [][]*validator.ErrorResponse, err error) {
+	if maxBulkSize <= 0 {
+		maxBulkSize = constant.ProductBulkMaxSize
+	}
+
+	if len(r.Products) > maxBulkSize {
+		return nil, nil, domain.ErrProductBulkTooLarge
+	}
+
+	res = make([]domain.ProductBulkItem, len(r.Products))
+	fieldErrors = make([][]*validator.ErrorResponse, len(r.Products))
+
+	for i, product := range r.Products {
+		res[i].Product = domain.Product{
+			CategoryID:  product.CategoryID,
+			SupplierID:  product.SupplierID,
+			UnitID:      product.UnitID,
+			Name:        product.Name,
+			Description: product.Description,
+			BasePrice:   product.BasePrice,
+			Stock:       product.Stock,
+		}
+
+		if fieldErrors[i] = validator.Validate(product); fieldErrors[i] != nil {
+			res[i].Err = domain.ErrProductBulkItemInvalid
+		}
+	}
+
+	return res, fieldErrors, nil
+}
+
+// ImportProductsRequest holds the options of the import of a catalog file, the file
+// itself being read from the "file" field of the multipart form.
+type ImportProductsRequest struct {
+	DryRun bool `query:"dry_run"`
+}
+
+type UpdateProductRequest struct {
+	ID          uuid.UUID `json:"-" uri:"id" validate:"required,uuid"`
+	CategoryID  uuid.UUID `json:"category_id" validate:"required,uuid"`
+	SupplierID  uuid.UUID `json:"supplier_id" validate:"required,uuid"`
+	UnitID      uuid.UUID `json:"unit_id" validate:"required,uuid"`
+	Name        string    `json:"name" validate:"required,min=3,max=150"`
+	Description *string   `json:"description" validate:"omitempty,max=255"`
+	BasePrice   float64   `json:"base_price" validate:"required,gte=0"`
+	Stock       int       `json:"stock" validate:"gte=0"`
+}
+
+type PatchProductRequest struct {
+	ID                uuid.UUID  `json:"-" uri:"id" validate:"required,uuid"`
+	CategoryID        *uuid.UUID `json:"category_id" validate:"omitempty,uuid"`
+	SupplierID        *uuid.UUID `json:"supplier_id" validate:"omitempty,uuid"`
+	UnitID            *uuid.UUID `json:"unit_id" validate:"omitempty,uuid"`
+	Name              *string    `json:"name" validate:"omitempty,min=3,max=150"`
+	Description       *string    `json:"description" validate:"omitempty,max=255"`
+	BasePrice         *float64   `json:"base_price" validate:"omitempty,gte=0"`
+	Stock             *int       `json:"stock" validate:"omitempty,gte=0"`
+	RemoveDescription bool       `json:"-"`
+}
+
+// UnmarshalJSON decodes a JSON merge-patch document. An explicit null removes the
+// description, and is rejected for every other field since they are mandatory.
+func (r *PatchProductRequest) UnmarshalJSON(data []byte) error {
+	type patchProductRequest PatchProductRequest
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, (*patchProductRequest)(r)); err != nil {
+		return err
+	}
+
+	for field, value := range raw {
+		if string(value) != "null" {
+			continue
+		}
+
+		if field != "description" {
+			return fmt.Errorf("%s cannot be null", field)
+		}
+
+		r.RemoveDescription = true
+	}
+
+	return nil
+}
+
+// ProductFilterRequest holds the filters shared by the product list and its facets.
+type ProductFilterRequest struct {
+	Query          string   `query:"q" validate:"omitempty,max=200"`
+	Fuzzy          bool     `query:"fuzzy"`
+	ProductName    string   `query:"product_name" validate:"omitempty,min=3,max=150"`
+	CategoryType   string   `query:"category_type" validate:"omitempty,min=3,max=15"`
+	CategoryIDs    []string `query:"category_id" validate:"omitempty,max=50,dive,uuid"`
+	SupplierIDs    []string `query:"supplier_id" validate:"omitempty,max=50,dive,uuid"`
+	UnitIDs        []string `query:"unit_id" validate:"omitempty,max=50,dive,uuid"`
+	MinPrice       *float64 `query:"min_price" validate:"omitempty,gte=0"`
+	MaxPrice       *float64 `query:"max_price" validate:"omitempty,gte=0"`
+	MinStock       *int     `query:"min_stock" validate:"omitempty,gte=0"`
+	MaxStock       *int     `query:"max_stock" validate:"omitempty,gte=0"`
+	InStock        bool     `query:"in_stock"`
+	CreatedFrom    string   `query:"created_from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	CreatedTo      string   `query:"created_to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	IncludeDeleted bool     `query:"include_deleted"`
+}
+
 type GetListProductRequest struct {
-	ProductName string `query:"product_name" validate:"omitempty,min=3,max=150"`
+	ProductFilterRequest
+	Limit   int    `query:"limit" validate:"omitempty,min=1,excluded_with=PerPage"`
+	Cursor  string `query:"cursor" validate:"omitempty,max=1024,excluded_with=Page"`
+	Page    int    `query:"page" validate:"omitempty,min=1"`
+	PerPage int    `query:"per_page" validate:"omitempty,min=1"`
+	FilterSort
+	ProductFieldsetRequest
+}
+
+type GetProductFacetsRequest struct {
+	ProductFilterRequest
+}
+
+// ExportProductRequest takes the filters and sort of the product list, the whole list
+// being exported in the given format, csv by default.
+type ExportProductRequest struct {
+	ProductFilterRequest
 	FilterSort
+	Format string `query:"format" validate:"omitempty,oneof=csv ndjson parquet"`
+}
+
+// ToFilter converts the filter parameters into the criteria of the products. The IDs and
+// timestamps are expected to be validated already.
+func (r ProductFilterRequest) ToFilter() (res domain.ProductFilter, err error) {
+	res = domain.ProductFilter{
+		Query:          strings.TrimSpace(r.Query),
+		Fuzzy:          r.Fuzzy,
+		ProductName:    r.ProductName,
+		CategoryType:   r.CategoryType,
+		MinPrice:       r.MinPrice,
+		MaxPrice:       r.MaxPrice,
+		MinStock:       r.MinStock,
+		MaxStock:       r.MaxStock,
+		InStock:        r.InStock,
+		IncludeDeleted: r.IncludeDeleted,
+	}
+
+	if res.CategoryIDs, err = parseUUIDs(r.CategoryIDs); err != nil {
+		return res, err
+	}
+
+	if res.SupplierIDs, err = parseUUIDs(r.SupplierIDs); err != nil {
+		return res, err
+	}
+
+	if res.UnitIDs, err = parseUUIDs(r.UnitIDs); err != nil {
+		return res, err
+	}
+
+	if res.CreatedFrom, err = parseTimestamp(r.CreatedFrom); err != nil {
+		return res, err
+	}
+
+	if res.CreatedTo, err = parseTimestamp(r.CreatedTo); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// ToSort converts the sort parameters into the keys to sort the list by, created_at
+// ascending when no sort is given. A field prefixed with "-" is sorted descending, the
+// others following direction.
+func (r FilterSort) ToSort() domain.ProductSort {
+	return domain.ProductSort{
+		Keys: pageutil.ParseSort(r.Sort, r.Direction, r.Nulls, constant.ProductSortCreatedAt),
+	}
+}
+
+// ToFormat returns the format of the export, csv when none is given.
+func (r ExportProductRequest) ToFormat() string {
+	if r.Format == "" {
+		return constant.ProductExportFormatCSV
+	}
+
+	return r.Format
+}
+
+func parseUUIDs(values []string) (res []uuid.UUID, err error) {
+	for _, value := range values {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, id)
+	}
+
+	return res, nil
+}
+
+func parseTimestamp(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// ToListPage converts the pagination parameters into the page to fetch. Giving page or
+// per_page asks for a numbered page, otherwise the page following the cursor is fetched.
+// The page size defaults to constant.ProductListDefaultLimit and may not exceed
+// maxPageSize.
+func (r GetListProductRequest) ToListPage(maxPageSize int) (res domain.ListPage, err error) {
+	if maxPageSize <= 0 {
+		maxPageSize = constant.ProductListMaxLimit
+	}
+
+	res.Limit = r.Limit
+	if r.Page > 0 || r.PerPage > 0 {
+		res.Limit = r.PerPage
+		res.Number = max(r.Page, 1)
+	}
+
+	if res.Limit == 0 {
+		res.Limit = min(constant.ProductListDefaultLimit, maxPageSize)
+	}
+
+	if res.Limit > maxPageSize {
+		return res, domain.ErrProductPageSizeTooLarge
+	}
+
+	if r.Cursor != "" {
+		var after domain.ProductCursor
+		if err = pageutil.DecodeCursor(r.Cursor, &after); err != nil {
+			return res, domain.ErrProductInvalidCursor
+		}
+
+		if err = after.Validate(); err != nil {
+			return res, err
+		}
+
+		res.After = &after
+	}
+
+	return res, nil
+}
+
+type SuggestProductRequest struct {
+	Prefix       string   `query:"prefix" validate:"required,min=1,max=150"`
+	Limit        int      `query:"limit" validate:"omitempty,min=1,max=20"`
+	CategoryType string   `query:"category_type" validate:"omitempty,min=3,max=15"`
+	CategoryIDs  []string `query:"category_id" validate:"omitempty,max=50,dive,uuid"`
+}
+
+// ToFilter converts the category scoping of the suggestions into a product filter. The
+// IDs are expected to be validated already.
+func (r SuggestProductRequest) ToFilter() (res domain.ProductFilter, err error) {
+	res.CategoryType = r.CategoryType
+
+	if res.CategoryIDs, err = parseUUIDs(r.CategoryIDs); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// ToLimit returns the number of suggestions to fetch, constant.ProductSuggestDefaultLimit
+// when none is given.
+func (r SuggestProductRequest) ToLimit() int {
+	if r.Limit == 0 {
+		return constant.ProductSuggestDefaultLimit
+	}
+
+	return r.Limit
+}
+
+type ProductDiscountRequest struct {
+	ID              uuid.UUID `json:"-" uri:"id" validate:"required,uuid"`
+	DiscountPercent float64   `json:"discount_percent" validate:"required,gt=0,lte=100"`
+	StartDate       string    `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate         string    `json:"end_date" validate:"required,datetime=2006-01-02"`
+	MaxPurchaseQty  *int      `json:"max_purchase_qty" validate:"omitempty,gt=0"`
+}
+
+// ToDomain converts the request into the discount window of the product. The dates are
+// expected to be validated already.
+func (r ProductDiscountRequest) ToDomain() (res domain.ProductDiscount, err error) {
+	startDate, err := time.Parse(discountDateLayout, r.StartDate)
+	if err != nil {
+		return res, err
+	}
+
+	endDate, err := time.Parse(discountDateLayout, r.EndDate)
+	if err != nil {
+		return res, err
+	}
+
+	return domain.ProductDiscount{
+		ProductID:       r.ID,
+		DiscountPercent: r.DiscountPercent,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		MaxPurchaseQty:  r.MaxPurchaseQty,
+	}, nil
+}
+
+// CreateStockMovementRequest records a movement of the stock of a product. Quantity is
+// the number of items moved for a receipt, sale or return, and the signed change of stock
+// for an adjustment or a transfer. The movement is recorded on behalf of the system when
+// no actor is given.
+type CreateStockMovementRequest struct {
+	ID          uuid.UUID `json:"-" uri:"id" validate:"required,uuid"`
+	Type        string    `json:"type" validate:"required,oneof=receipt sale adjustment return transfer"`
+	Quantity    int       `json:"quantity" validate:"required"`
+	Reason      *string   `json:"reason" validate:"omitempty,max=255"`
+	ReferenceID *string   `json:"reference_id" validate:"omitempty,min=1,max=100"`
+	Actor       string    `json:"actor" validate:"omitempty,max=100"`
+}
+
+func (r CreateStockMovementRequest) ToDomain() domain.StockMovement {
+	return domain.StockMovement{
+		ProductID:   r.ID,
+		Type:        r.Type,
+		Quantity:    r.Quantity,
+		Reason:      r.Reason,
+		ReferenceID: r.ReferenceID,
+		Actor:       r.Actor,
+	}
+}
+
+type GetStockHistoryRequest struct {
+	ID      uuid.UUID `uri:"id" validate:"required,uuid"`
+	Type    string    `query:"type" validate:"omitempty,oneof=receipt sale adjustment return transfer"`
+	Page    int       `query:"page" validate:"omitempty,min=1"`
+	PerPage int       `query:"per_page" validate:"omitempty,min=1"`
+}
+
+// ToListPage converts the pagination parameters into a numbered page of the stock
+// history, the first page of constant.StockHistoryDefaultLimit movements unless asked
+// otherwise. A page may not hold more than maxPageSize movements.
+func (r GetStockHistoryRequest) ToListPage(maxPageSize int) (res domain.ListPage, err error) {
+	if maxPageSize <= 0 {
+		maxPageSize = constant.ProductListMaxLimit
+	}
+
+	res = domain.ListPage{
+		Limit:  r.PerPage,
+		Number: max(r.Page, 1),
+	}
+
+	if res.Limit == 0 {
+		res.Limit = min(constant.StockHistoryDefaultLimit, maxPageSize)
+	}
+
+	if res.Limit > maxPageSize {
+		return res, domain.ErrProductPageSizeTooLarge
+	}
+
+	return res, nil
+}
+
+func (r GetStockHistoryRequest) ToFilter() domain.StockMovementFilter {
+	return domain.StockMovementFilter{
+		Type: r.Type,
+	}
+}
+
+type BatchGetProductRequest struct {
+	IDs []string `json:"ids" validate:"required,min=1,dive,uuid"`
+}
+
+// ToIDs converts the requested IDs, which are expected to be validated already. A batch
+// may not ask for more than maxBatchSize products.
+func (r BatchGetProductRequest) ToIDs(maxBatchSize int) (res []uuid.UUID, err error) {
+	if maxBatchSize <= 0 {
+		maxBatchSize = constant.ProductBatchMaxSize
+	}
+
+	if len(r.IDs) > maxBatchSize {
+		return nil, domain.ErrProductBatchTooLarge
+	}
+
+	return parseUUIDs(r.IDs)
 }
 
 type GetProductByIDRequest struct {
-	ID uuid.UUID `uri:"id" validate:"required,uuid"`
+	ID   uuid.UUID `uri:"id" validate:"required,uuid"`
+	Unit string    `query:"unit" validate:"omitempty,max=50"`
+	ProductFieldsetRequest
+}
+
+// ProductFieldsetRequest selects the parts of the products to return: fields lists the
+// response fields to keep and include the related records to embed, both comma separated.
+type ProductFieldsetRequest struct {
+	Fields  string `query:"fields" validate:"omitempty,max=500"`
+	Include string `query:"include" validate:"omitempty,max=100"`
+}
+
+// ToFieldset converts the fieldset parameters into the fields and relations to return,
+// rejecting the names a product response does not have.
+func (r ProductFieldsetRequest) ToFieldset() (res ProductFieldset, err error) {
+	res.Fields = splitList(r.Fields)
+	for _, field := range res.Fields {
+		if !slices.Contains(productResponseFields, field) {
+			return res, domain.ErrProductInvalidFields
+		}
+	}
+
+	res.Include = splitList(r.Include)
+	for _, relation := range res.Include {
+		if !slices.Contains(constant.ValidProductInclude, relation) {
+			return res, domain.ErrProductInvalidInclude
+		}
+	}
+
+	return res, nil
+}
+
+// splitList splits a comma separated parameter, ignoring blank items.
+func splitList(value string) (res []string) {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+
+	return res
 }
 
 type GetProductByNameRequest struct {
 }
 
 type FilterSort struct {
-	CategoryType string `query:"category_type" validate:"omitempty,min=3,max=15"`
-	Sort         string `query:"sort" validate:"omitempty,min=3,max=150"`
-	Direction    string `query:"direction" validate:"omitempty,min=3,max=4"`
+	Sort      string `query:"sort" validate:"omitempty,min=3,max=150"`
+	Direction string `query:"direction" validate:"omitempty,min=3,max=4"`
+	Nulls     string `query:"nulls" validate:"omitempty,oneof=first last"`
 }
@@ -0,0 +1,343 @@
+package dto_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	dto "github.com/gunawanpras/be-product-service/internal/adapter/http/dto/product"
+	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/pageutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetListProductRequest_ToListPage(t *testing.T) {
+	name := "Kangkung Potong 1"
+	cursor := &domain.ProductCursor{
+		Sort:   []pageutil.SortKey{{Field: constant.ProductSortProductName, Direction: constant.SortDirectionAsc}},
+		Values: []*string{&name},
+		ID:     uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971427"),
+	}
+
+	encoded, err := dto.EncodeCursor(cursor)
+	assert.NoError(t, err)
+
+	nullCursor, err := dto.EncodeCursor(&domain.ProductCursor{
+		Sort:   []pageutil.SortKey{{Field: constant.ProductSortCreatedAt, Direction: constant.SortDirectionAsc}},
+		Values: []*string{nil},
+	})
+	assert.NoError(t, err)
+
+	stock := "twelve"
+	unparsableCursor, err := dto.EncodeCursor(&domain.ProductCursor{
+		Sort:   []pageutil.SortKey{{Field: constant.ProductSortStock, Direction: constant.SortDirectionDesc}},
+		Values: []*string{&stock},
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		req         dto.GetListProductRequest
+		maxPageSize int
+		want        domain.ListPage
+		wantErr     error
+	}{
+		{
+			name: "default limit without cursor",
+			req:  dto.GetListProductRequest{},
+			want: domain.ListPage{Limit: constant.ProductListDefaultLimit},
+		},
+		{
+			name: "cursor issued for a previous page",
+			req:  dto.GetListProductRequest{Limit: 5, Cursor: *encoded},
+			want: domain.ListPage{Limit: 5, After: cursor},
+		},
+		{
+			name:    "malformed cursor",
+			req:     dto.GetListProductRequest{Cursor: "not a cursor"},
+			wantErr: domain.ErrProductInvalidCursor,
+		},
+		{
+			name:    "cursor without a value for a field that is not nullable",
+			req:     dto.GetListProductRequest{Cursor: *nullCursor},
+			wantErr: domain.ErrProductInvalidCursor,
+		},
+		{
+			name:    "cursor with a value that does not parse for its field",
+			req:     dto.GetListProductRequest{Cursor: *unparsableCursor},
+			wantErr: domain.ErrProductInvalidCursor,
+		},
+		{
+			name: "numbered page",
+			req:  dto.GetListProductRequest{Page: 3, PerPage: 10},
+			want: domain.ListPage{Limit: 10, Number: 3},
+		},
+		{
+			name: "first numbered page when only per_page is given",
+			req:  dto.GetListProductRequest{PerPage: 10},
+			want: domain.ListPage{Limit: 10, Number: 1},
+		},
+		{
+			name:        "default page size capped by the max page size",
+			req:         dto.GetListProductRequest{Page: 2},
+			maxPageSize: 5,
+			want:        domain.ListPage{Limit: 5, Number: 2},
+		},
+		{
+			name:        "page size above the max page size",
+			req:         dto.GetListProductRequest{PerPage: 50},
+			maxPageSize: 20,
+			wantErr:     domain.ErrProductPageSizeTooLarge,
+		},
+		{
+			name:    "limit above the default max page size",
+			req:     dto.GetListProductRequest{Limit: constant.ProductListMaxLimit + 1},
+			wantErr: domain.ErrProductPageSizeTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.req.ToListPage(tt.maxPageSize)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetStockHistoryRequest_ToListPage(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         dto.GetStockHistoryRequest
+		maxPageSize int
+		want        domain.ListPage
+		wantErr     error
+	}{
+		{
+			name: "first page by default",
+			req:  dto.GetStockHistoryRequest{},
+			want: domain.ListPage{Limit: constant.StockHistoryDefaultLimit, Number: 1},
+		},
+		{
+			name: "numbered page",
+			req:  dto.GetStockHistoryRequest{Page: 3, PerPage: 10},
+			want: domain.ListPage{Limit: 10, Number: 3},
+		},
+		{
+			name:        "default page size capped by the max page size",
+			req:         dto.GetStockHistoryRequest{Page: 2},
+			maxPageSize: 5,
+			want:        domain.ListPage{Limit: 5, Number: 2},
+		},
+		{
+			name:    "page size above the default max page size",
+			req:     dto.GetStockHistoryRequest{PerPage: constant.ProductListMaxLimit + 1},
+			wantErr: domain.ErrProductPageSizeTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.req.ToListPage(tt.maxPageSize)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetListProductRequest_ToFilter(t *testing.T) {
+	supplierID := uuid.MustParse("c13c2fc2-9a01-4e8e-8eb6-5f8a3a1d0a11")
+	minPrice := float64(1000)
+	createdFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		req     dto.GetListProductRequest
+		want    domain.ProductFilter
+		wantErr bool
+	}{
+		{
+			name: "empty filter",
+			req:  dto.GetListProductRequest{},
+			want: domain.ProductFilter{},
+		},
+		{
+			name: "every filter",
+			req: dto.GetListProductRequest{ProductFilterRequest: dto.ProductFilterRequest{
+				ProductName:  "kangkung",
+				CategoryType: "Sayuran",
+				SupplierIDs:  []string{supplierID.String()},
+				MinPrice:     &minPrice,
+				InStock:      true,
+				CreatedFrom:  "2024-01-01T00:00:00Z",
+			}},
+			want: domain.ProductFilter{
+				ProductName:  "kangkung",
+				CategoryType: "Sayuran",
+				SupplierIDs:  []uuid.UUID{supplierID},
+				MinPrice:     &minPrice,
+				InStock:      true,
+				CreatedFrom:  &createdFrom,
+			},
+		},
+		{
+			name:    "malformed id",
+			req:     dto.GetListProductRequest{ProductFilterRequest: dto.ProductFilterRequest{UnitIDs: []string{"not an id"}}},
+			wantErr: true,
+		},
+		{
+			name:    "malformed timestamp",
+			req:     dto.GetListProductRequest{ProductFilterRequest: dto.ProductFilterRequest{CreatedTo: "2024-01-01"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.req.ToFilter()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProductFieldsetRequest_ToFieldset(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     dto.ProductFieldsetRequest
+		want    dto.ProductFieldset
+		wantErr error
+	}{
+		{
+			name: "no fieldset",
+			req:  dto.ProductFieldsetRequest{},
+			want: dto.ProductFieldset{},
+		},
+		{
+			name: "fields and relations",
+			req:  dto.ProductFieldsetRequest{Fields: "name, base_price,", Include: "category,unit"},
+			want: dto.ProductFieldset{Fields: []string{"name", "base_price"}, Include: []string{"category", "unit"}},
+		},
+		{
+			name:    "unknown field",
+			req:     dto.ProductFieldsetRequest{Fields: "name,price"},
+			wantErr: domain.ErrProductInvalidFields,
+		},
+		{
+			name:    "unknown relation",
+			req:     dto.ProductFieldsetRequest{Include: "category,warehouse"},
+			wantErr: domain.ErrProductInvalidInclude,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.req.ToFieldset()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBatchGetProductRequest_ToIDs(t *testing.T) {
+	productID := uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971427")
+	otherProductID := uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971428")
+
+	tests := []struct {
+		name         string
+		req          dto.BatchGetProductRequest
+		maxBatchSize int
+		want         []uuid.UUID
+		wantErr      error
+	}{
+		{
+			name:         "ids in request order",
+			req:          dto.BatchGetProductRequest{IDs: []string{otherProductID.String(), productID.String()}},
+			maxBatchSize: 2,
+			want:         []uuid.UUID{otherProductID, productID},
+		},
+		{
+			name:         "batch larger than the max batch size",
+			req:          dto.BatchGetProductRequest{IDs: []string{productID.String(), otherProductID.String()}},
+			maxBatchSize: 1,
+			wantErr:      domain.ErrProductBatchTooLarge,
+		},
+		{
+			name:    "default max batch size",
+			req:     dto.BatchGetProductRequest{IDs: make([]string, constant.ProductBatchMaxSize+1)},
+			wantErr: domain.ErrProductBatchTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.req.ToIDs(tt.maxBatchSize)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateProductsRequest_ToItems(t *testing.T) {
+	valid := dto.CreateProductRequest{
+		CategoryID: uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971429"),
+		SupplierID: uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971431"),
+		UnitID:     uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971432"),
+		Name:       "Kangkung Potong 1",
+		BasePrice:  3000,
+		Stock:      100,
+	}
+
+	invalid := valid
+	invalid.Name = "Ka"
+
+	t.Run("invalid products marked at their index", func(t *testing.T) {
+		req := dto.CreateProductsRequest{Products: []dto.CreateProductRequest{valid, invalid}}
+
+		items, fieldErrors, err := req.ToItems(0)
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+
+		assert.NoError(t, items[0].Err)
+		assert.Equal(t, valid.Name, items[0].Product.Name)
+		assert.Empty(t, fieldErrors[0])
+
+		assert.ErrorIs(t, items[1].Err, domain.ErrProductBulkItemInvalid)
+		assert.Len(t, fieldErrors[1], 1)
+		assert.Equal(t, "name", fieldErrors[1][0].Field)
+	})
+
+	t.Run("bulk larger than the max bulk size", func(t *testing.T) {
+		req := dto.CreateProductsRequest{Products: []dto.CreateProductRequest{valid, valid}}
+
+		_, _, err := req.ToItems(1)
+		assert.ErrorIs(t, err, domain.ErrProductBulkTooLarge)
+	})
+}
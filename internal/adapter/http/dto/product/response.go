@@ -1,10 +1,23 @@
 package dto
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	unitDomain "github.com/gunawanpras/be-product-service/internal/core/unit/domain"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/pageutil"
+	"github.com/gunawanpras/be-product-service/pkg/util/timeutil"
+	"github.com/gunawanpras/be-product-service/pkg/validator"
 )
 
 type (
@@ -13,49 +26,585 @@ type (
 	}
 
 	GetProductResponse struct {
+		ID                uuid.UUID                  `json:"id"`
+		CategoryID        uuid.UUID                  `json:"category_id"`
+		SupplierID        uuid.UUID                  `json:"supplier_id"`
+		UnitID            uuid.UUID                  `json:"unit_id"`
+		Name              string                     `json:"name"`
+		Description       *string                    `json:"description"`
+		BasePrice         float64                    `json:"base_price"`
+		Stock             float64                    `json:"stock"`
+		DiscountPercent   *float64                   `json:"discount_percent"`
+		DiscountStartDate *string                    `json:"discount_start_date,omitempty"`
+		DiscountEndDate   *string                    `json:"discount_end_date,omitempty"`
+		DiscountActive    bool                       `json:"discount_active"`
+		EffectivePrice    float64                    `json:"effective_price"`
+		MaxPurchaseQty    *int                       `json:"max_purchase_qty"`
+		CreatedAt         string                     `json:"created_at"`
+		CreatedBy         string                     `json:"created_by"`
+		DeletedAt         *string                    `json:"deleted_at,omitempty"`
+		Version           int                        `json:"version"`
+		Highlights        *ProductHighlightsResponse `json:"highlights,omitempty"`
+		Category          *ProductRelationResponse   `json:"category,omitempty"`
+		Supplier          *ProductRelationResponse   `json:"supplier,omitempty"`
+		Unit              *ProductRelationResponse   `json:"unit,omitempty"`
+		Discount          *ProductDiscountResponse   `json:"discount,omitempty"`
+	}
+
+	// CreateProductsResponse reports the outcome of every product of a bulk creation, in
+	// the order of the request.
+	CreateProductsResponse struct {
+		Created int                          `json:"created"`
+		Failed  int                          `json:"failed"`
+		Items   []CreateProductsItemResponse `json:"items"`
+	}
+
+	CreateProductsItemResponse struct {
+		Index   int                  `json:"index"`
+		Status  string               `json:"status"`
+		ID      *uuid.UUID           `json:"id,omitempty"`
+		Code    string               `json:"code,omitempty"`
+		Message string               `json:"message,omitempty"`
+		Errors  []FieldErrorResponse `json:"errors,omitempty"`
+	}
+
+	// ImportProductsResponse sums up the import of a catalog file, listing the rows that
+	// were not created, or would not be on a dry run, by their line in the file.
+	ImportProductsResponse struct {
+		DryRun  bool                        `json:"dry_run"`
+		Total   int                         `json:"total"`
+		Created int                         `json:"created"`
+		Valid   int                         `json:"valid"`
+		Failed  int                         `json:"failed"`
+		Rows    []ImportProductsRowResponse `json:"rows"`
+	}
+
+	ImportProductsRowResponse struct {
+		Line    int                  `json:"line"`
+		Name    string               `json:"name"`
+		Status  string               `json:"status"`
+		Code    string               `json:"code,omitempty"`
+		Message string               `json:"message,omitempty"`
+		Errors  []FieldErrorResponse `json:"errors,omitempty"`
+	}
+
+	// ExportProductResponse is a product of a catalog export, flattened for the rows of
+	// CSV and Parquet files.
+	ExportProductResponse struct {
+		ID           string  `json:"id" parquet:"id"`
+		CategoryID   string  `json:"category_id" parquet:"category_id"`
+		CategoryName string  `json:"category_name" parquet:"category_name"`
+		SupplierID   string  `json:"supplier_id" parquet:"supplier_id"`
+		SupplierName *string `json:"supplier_name" parquet:"supplier_name,optional"`
+		UnitID       string  `json:"unit_id" parquet:"unit_id"`
+		UnitName     string  `json:"unit_name" parquet:"unit_name"`
+		Name         string  `json:"name" parquet:"name"`
+		Description  *string `json:"description" parquet:"description,optional"`
+		BasePrice    float64 `json:"base_price" parquet:"base_price"`
+		Stock        int     `json:"stock" parquet:"stock"`
+		CreatedAt    string  `json:"created_at" parquet:"created_at"`
+		CreatedBy    string  `json:"created_by" parquet:"created_by"`
+		UpdatedAt    *string `json:"updated_at" parquet:"updated_at,optional"`
+		UpdatedBy    *string `json:"updated_by" parquet:"updated_by,optional"`
+		DeletedAt    *string `json:"deleted_at" parquet:"deleted_at,optional"`
+		Version      int     `json:"version" parquet:"version"`
+	}
+
+	// FieldErrorResponse is a failed validation of a field of a product.
+	FieldErrorResponse struct {
+		Field string `json:"field"`
+		Code  string `json:"code"`
+		Param string `json:"param,omitempty"`
+	}
+
+	// ProductRelationResponse is a record embedded in a product response on request.
+	ProductRelationResponse struct {
+		ID   uuid.UUID `json:"id"`
+		Name string    `json:"name"`
+	}
+
+	ProductDiscountResponse struct {
+		Percent        float64 `json:"percent"`
+		StartDate      string  `json:"start_date"`
+		EndDate        string  `json:"end_date"`
+		MaxPurchaseQty *int    `json:"max_purchase_qty"`
+		Active         bool    `json:"active"`
+	}
+
+	// ProductFieldset selects the parts of the products to return. Fields lists the
+	// response fields to keep, all of them when empty, and Include the relations to embed.
+	ProductFieldset struct {
+		Fields  []string
+		Include []string
+	}
+
+	// ProductHighlightsResponse holds the name and description of a product found by a
+	// full-text search, with the matching words wrapped in <mark> tags.
+	ProductHighlightsResponse struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+	}
+
+	GetListProductResponse []GetProductResponse
+
+	// BatchGetProductResponse holds the products found, in the order they were asked
+	// for, and the IDs of the missing ones.
+	BatchGetProductResponse struct {
+		Products GetListProductResponse `json:"products"`
+		Missing  []uuid.UUID            `json:"missing"`
+	}
+
+	SuggestProductResponse struct {
+		ID   uuid.UUID `json:"id"`
+		Name string    `json:"name"`
+	}
+
+	SuggestProductResponses []SuggestProductResponse
+
+	FacetCountResponse struct {
+		ID    uuid.UUID `json:"id"`
+		Name  string    `json:"name"`
+		Count int       `json:"count"`
+	}
+
+	// PriceBucketResponse counts the products priced from Min, included, to Max, excluded.
+	// Min is left out for the bucket below the lowest bound and Max for the highest one.
+	PriceBucketResponse struct {
+		Min   *float64 `json:"min,omitempty"`
+		Max   *float64 `json:"max,omitempty"`
+		Count int      `json:"count"`
+	}
+
+	GetProductFacetsResponse struct {
+		Categories   []FacetCountResponse  `json:"categories"`
+		Suppliers    []FacetCountResponse  `json:"suppliers"`
+		Units        []FacetCountResponse  `json:"units"`
+		PriceBuckets []PriceBucketResponse `json:"price_buckets"`
+	}
+
+	// StockMovementResponse is an entry of the stock history of a product. Quantity is
+	// the change of stock made by the movement, negative when the stock went down.
+	StockMovementResponse struct {
 		ID          uuid.UUID `json:"id"`
-		CategoryID  uuid.UUID `json:"category_id"`
-		SupplierID  uuid.UUID `json:"supplier_id"`
-		UnitID      uuid.UUID `json:"unit_id"`
-		Name        string    `json:"name"`
-		Description *string   `json:"description"`
-		BasePrice   float64   `json:"base_price"`
-		Stock       int       `json:"stock"`
+		ProductID   uuid.UUID `json:"product_id"`
+		Type        string    `json:"type"`
+		Quantity    int       `json:"quantity"`
+		StockAfter  int       `json:"stock_after"`
+		Reason      *string   `json:"reason"`
+		ReferenceID *string   `json:"reference_id"`
+		Actor       string    `json:"actor"`
 		CreatedAt   string    `json:"created_at"`
-		CreatedBy   string    `json:"created_by"`
 	}
 
-	GetListProductResponse []GetProductResponse
+	GetStockHistoryResponse []StockMovementResponse
 )
 
+// discountDateLayout is the layout of the discount window dates.
+const discountDateLayout = "2006-01-02"
+
+// productResponseFields are the names a fieldset may select, the JSON names of the
+// fields of GetProductResponse.
+var productResponseFields = jsonFieldNames(reflect.TypeOf(GetProductResponse{}))
+
 func (p *GetProductResponse) ToResponse(product domain.Product) {
+	effectivePrice, discountActive := product.EffectivePrice(timeutil.TimeHelper.Now())
+
 	*p = GetProductResponse{
-		ID:          product.ID,
-		CategoryID:  product.CategoryID,
-		SupplierID:  product.SupplierID,
-		UnitID:      product.UnitID,
+		ID:             product.ID,
+		CategoryID:     product.CategoryID,
+		SupplierID:     product.SupplierID,
+		UnitID:         product.UnitID,
+		Name:           product.Name,
+		Description:    product.Description,
+		BasePrice:      product.BasePrice,
+		Stock:          float64(product.Stock),
+		DiscountActive: discountActive,
+		EffectivePrice: effectivePrice,
+		CreatedAt:      product.CreatedAt.Format(time.RFC3339),
+		CreatedBy:      product.CreatedBy,
+		DeletedAt:      formatTime(product.DeletedAt),
+		Version:        product.Version,
+	}
+
+	if discount := product.Discount; discount != nil {
+		startDate := discount.StartDate.Format(discountDateLayout)
+		endDate := discount.EndDate.Format(discountDateLayout)
+
+		p.DiscountPercent = &discount.DiscountPercent
+		p.DiscountStartDate = &startDate
+		p.DiscountEndDate = &endDate
+		p.MaxPurchaseQty = discount.MaxPurchaseQty
+	}
+
+	if match := product.Match; match != nil && match.NameHighlight != "" {
+		p.Highlights = &ProductHighlightsResponse{
+			Name:        match.NameHighlight,
+			Description: match.DescriptionHighlight,
+		}
+	}
+}
+
+// Include embeds the given relations of the product, the ones it has no record for are
+// left out.
+func (p *GetProductResponse) Include(product domain.Product, include []string) {
+	for _, relation := range include {
+		switch relation {
+		case constant.ProductIncludeCategory:
+			p.Category = toRelationResponse(product.Category)
+		case constant.ProductIncludeSupplier:
+			p.Supplier = toRelationResponse(product.Supplier)
+		case constant.ProductIncludeUnit:
+			p.Unit = toRelationResponse(product.Unit)
+		case constant.ProductIncludeDiscount:
+			if p.DiscountPercent != nil {
+				p.Discount = &ProductDiscountResponse{
+					Percent:        *p.DiscountPercent,
+					StartDate:      *p.DiscountStartDate,
+					EndDate:        *p.DiscountEndDate,
+					MaxPurchaseQty: p.MaxPurchaseQty,
+					Active:         p.DiscountActive,
+				}
+			}
+		}
+	}
+}
+
+// Select keeps the fields of the fieldset, along with the ID and the embedded relations
+// of the product. The response is returned as is when the fieldset has no fields.
+func (p GetProductResponse) Select(fieldset ProductFieldset) (any, error) {
+	if len(fieldset.Fields) == 0 {
+		return p, nil
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var all map[string]json.RawMessage
+	if err = json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	keep := slices.Concat([]string{"id"}, fieldset.Fields, fieldset.Include)
+
+	res := make(map[string]json.RawMessage, len(keep))
+	for _, field := range keep {
+		if value, ok := all[field]; ok {
+			res[field] = value
+		}
+	}
+
+	return res, nil
+}
+
+// ConvertUnit expresses the stock and the prices of the product in the target unit
+// of the conversion. MaxPurchaseQty stays expressed in the unit of the product.
+func (p *GetProductResponse) ConvertUnit(conversion unitDomain.Conversion) {
+	p.UnitID = conversion.To.ID
+	if p.Unit != nil {
+		p.Unit = &ProductRelationResponse{ID: conversion.To.ID, Name: conversion.To.Name}
+	}
+
+	p.Stock = conversion.Quantity(p.Stock)
+	p.BasePrice = conversion.Price(p.BasePrice)
+	p.EffectivePrice = conversion.Price(p.EffectivePrice)
+}
+
+func (p *GetListProductResponse) ToResponse(products domain.Products) {
+	for _, product := range products {
+		var res GetProductResponse
+		res.ToResponse(product)
+		*p = append(*p, res)
+	}
+}
+
+// Include embeds the given relations in every product of the list, products being the
+// products the list was made from.
+func (p GetListProductResponse) Include(products domain.Products, include []string) {
+	for i := range p {
+		p[i].Include(products[i], include)
+	}
+}
+
+func (p *BatchGetProductResponse) ToResponse(batch domain.ProductBatch) {
+	*p = BatchGetProductResponse{
+		Products: make(GetListProductResponse, 0, len(batch.Products)),
+		Missing:  make([]uuid.UUID, 0, len(batch.Missing)),
+	}
+
+	p.Products.ToResponse(batch.Products)
+	p.Missing = append(p.Missing, batch.Missing...)
+}
+
+// Select keeps the fields of the fieldset in every product of the list, see
+// GetProductResponse.Select.
+func (p GetListProductResponse) Select(fieldset ProductFieldset) (any, error) {
+	if len(fieldset.Fields) == 0 {
+		return p, nil
+	}
+
+	res := make([]any, 0, len(p))
+	for _, product := range p {
+		selected, err := product.Select(fieldset)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, selected)
+	}
+
+	return res, nil
+}
+
+// ToResponse reports the outcome of the items of a bulk creation, fieldErrors holding the
+// failed validations of the items at the same index.
+func (p *CreateProductsResponse) ToResponse(items []domain.ProductBulkItem, fieldErrors [][]*validator.ErrorResponse) {
+	*p = CreateProductsResponse{
+		Items: make([]CreateProductsItemResponse, 0, len(items)),
+	}
+
+	for i, item := range items {
+		res := CreateProductsItemResponse{
+			Index:  i,
+			Status: item.Status,
+		}
+
+		if item.Status == constant.ProductBulkCreated {
+			p.Created++
+			res.ID = &item.Product.ID
+		} else {
+			p.Failed++
+		}
+
+		if item.Err != nil {
+			res.Code = apperror.CodeOf(item.Err)
+			res.Message = item.Err.Error()
+		}
+
+		if i < len(fieldErrors) {
+			for _, fieldError := range fieldErrors[i] {
+				res.Errors = append(res.Errors, FieldErrorResponse{
+					Field: fieldError.Field,
+					Code:  fieldError.Tag,
+					Param: fieldError.Value,
+				})
+			}
+		}
+
+		p.Items = append(p.Items, res)
+	}
+}
+
+func (p *ExportProductResponse) ToResponse(product domain.Product) {
+	*p = ExportProductResponse{
+		ID:          product.ID.String(),
+		CategoryID:  product.CategoryID.String(),
+		SupplierID:  product.SupplierID.String(),
+		UnitID:      product.UnitID.String(),
 		Name:        product.Name,
 		Description: product.Description,
 		BasePrice:   product.BasePrice,
 		Stock:       product.Stock,
 		CreatedAt:   product.CreatedAt.Format(time.RFC3339),
 		CreatedBy:   product.CreatedBy,
+		UpdatedAt:   formatTime(product.UpdatedAt),
+		UpdatedBy:   product.UpdatedBy,
+		DeletedAt:   formatTime(product.DeletedAt),
+		Version:     product.Version,
+	}
+
+	if product.Category != nil {
+		p.CategoryName = product.Category.Name
+	}
+
+	if product.Supplier != nil {
+		p.SupplierName = &product.Supplier.Name
+	}
+
+	if product.Unit != nil {
+		p.UnitName = product.Unit.Name
 	}
 }
 
-func (p *GetListProductResponse) ToResponse(products domain.Products) {
-	for _, product := range products {
-		*p = append(*p, GetProductResponse{
-			ID:          product.ID,
-			CategoryID:  product.CategoryID,
-			SupplierID:  product.SupplierID,
-			UnitID:      product.UnitID,
-			Name:        product.Name,
-			Description: product.Description,
-			BasePrice:   product.BasePrice,
-			Stock:       product.Stock,
-			CreatedAt:   product.CreatedAt.Format(time.RFC3339),
-			CreatedBy:   product.CreatedBy,
+func (p *ImportProductsResponse) ToResponse(result domain.ProductImport) {
+	*p = ImportProductsResponse{
+		DryRun:  result.DryRun,
+		Total:   result.Total,
+		Created: result.Created,
+		Valid:   result.Valid,
+		Failed:  result.Failed,
+		Rows:    make([]ImportProductsRowResponse, 0, len(result.Rows)),
+	}
+
+	for _, row := range result.Rows {
+		res := ImportProductsRowResponse{
+			Line:   row.Line,
+			Name:   row.Name,
+			Status: row.Status,
+		}
+
+		if row.Err != nil {
+			res.Code = apperror.CodeOf(row.Err)
+			res.Message = row.Err.Error()
+		}
+
+		for _, fieldError := range row.FieldErrors {
+			res.Errors = append(res.Errors, FieldErrorResponse{
+				Field: fieldError.Field,
+				Code:  fieldError.Code,
+				Param: fieldError.Param,
+			})
+		}
+
+		p.Rows = append(p.Rows, res)
+	}
+}
+
+// WriteCSV writes the rows of the import as an error report, one line per failed
+// validation of a row, so that it can be fixed in a spreadsheet and imported again.
+func (p ImportProductsResponse) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"line", "name", "status", "code", "message", "field", "field_code", "param"})
+	if err != nil {
+		return err
+	}
+
+	for _, row := range p.Rows {
+		record := []string{strconv.Itoa(row.Line), row.Name, row.Status, row.Code, row.Message}
+		if len(row.Errors) == 0 {
+			if err = writer.Write(append(record, "", "", "")); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		for _, fieldError := range row.Errors {
+			if err = writer.Write(append(slices.Clip(record), fieldError.Field, fieldError.Code, fieldError.Param)); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func (p *SuggestProductResponses) ToResponse(suggestions domain.ProductSuggestions) {
+	*p = make(SuggestProductResponses, 0, len(suggestions))
+
+	for _, suggestion := range suggestions {
+		*p = append(*p, SuggestProductResponse{
+			ID:   suggestion.ID,
+			Name: suggestion.Name,
 		})
 	}
 }
+
+func (p *GetProductFacetsResponse) ToResponse(facets domain.ProductFacets) {
+	*p = GetProductFacetsResponse{
+		Categories:   toFacetCountResponses(facets.Categories),
+		Suppliers:    toFacetCountResponses(facets.Suppliers),
+		Units:        toFacetCountResponses(facets.Units),
+		PriceBuckets: make([]PriceBucketResponse, 0, len(facets.PriceBuckets)),
+	}
+
+	for _, bucket := range facets.PriceBuckets {
+		p.PriceBuckets = append(p.PriceBuckets, PriceBucketResponse{
+			Min:   bucket.Min,
+			Max:   bucket.Max,
+			Count: bucket.Count,
+		})
+	}
+}
+
+func (p *StockMovementResponse) ToResponse(movement domain.StockMovement) {
+	*p = StockMovementResponse{
+		ID:          movement.ID,
+		ProductID:   movement.ProductID,
+		Type:        movement.Type,
+		Quantity:    movement.Quantity,
+		StockAfter:  movement.StockAfter,
+		Reason:      movement.Reason,
+		ReferenceID: movement.ReferenceID,
+		Actor:       movement.Actor,
+		CreatedAt:   movement.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (p *GetStockHistoryResponse) ToResponse(movements domain.StockMovements) {
+	*p = make(GetStockHistoryResponse, 0, len(movements))
+
+	for _, movement := range movements {
+		var res StockMovementResponse
+		res.ToResponse(movement)
+
+		*p = append(*p, res)
+	}
+}
+
+func toFacetCountResponses(counts []domain.FacetCount) []FacetCountResponse {
+	res := make([]FacetCountResponse, 0, len(counts))
+
+	for _, count := range counts {
+		res = append(res, FacetCountResponse{
+			ID:    count.ID,
+			Name:  count.Name,
+			Count: count.Count,
+		})
+	}
+
+	return res
+}
+
+// EncodeCursor turns the cursor of the next page into the opaque string given to
+// clients, keeping nil values nil.
+func EncodeCursor(cursor *domain.ProductCursor) (res *string, err error) {
+	if cursor == nil {
+		return nil, nil
+	}
+
+	encoded, err := pageutil.EncodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encoded, nil
+}
+
+func toRelationResponse(relation *domain.ProductRelation) *ProductRelationResponse {
+	if relation == nil {
+		return nil
+	}
+
+	return &ProductRelationResponse{
+		ID:   relation.ID,
+		Name: relation.Name,
+	}
+}
+
+// jsonFieldNames returns the JSON names of the fields of a struct type.
+func jsonFieldNames(t reflect.Type) (res []string) {
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			res = append(res, name)
+		}
+	}
+
+	return res
+}
+
+// formatTime formats an optional timestamp as RFC3339, keeping nil values nil.
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.Format(time.RFC3339)
+
+	return &formatted
+}
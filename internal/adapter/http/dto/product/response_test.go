@@ -0,0 +1,186 @@
+package dto_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	dto "github.com/gunawanpras/be-product-service/internal/adapter/http/dto/product"
+	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/timeutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTimeHelper struct {
+	now time.Time
+}
+
+func (m mockTimeHelper) Now() time.Time {
+	return m.now
+}
+
+func TestGetProductResponse_ToResponse(t *testing.T) {
+	maxPurchaseQty := 5
+	discount := &domain.ProductDiscount{
+		DiscountPercent: 15,
+		StartDate:       time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:         time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC),
+		MaxPurchaseQty:  &maxPurchaseQty,
+	}
+
+	tests := []struct {
+		name               string
+		now                time.Time
+		discount           *domain.ProductDiscount
+		wantEffectivePrice float64
+		wantDiscountActive bool
+	}{
+		{
+			name:               "product without discount",
+			now:                time.Date(2025, 3, 3, 10, 0, 0, 0, time.UTC),
+			discount:           nil,
+			wantEffectivePrice: 3000,
+			wantDiscountActive: false,
+		},
+		{
+			name:               "discount not started yet",
+			now:                time.Date(2025, 2, 28, 23, 59, 0, 0, time.UTC),
+			discount:           discount,
+			wantEffectivePrice: 3000,
+			wantDiscountActive: false,
+		},
+		{
+			name:               "discount active on its first day",
+			now:                time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			discount:           discount,
+			wantEffectivePrice: 2550,
+			wantDiscountActive: true,
+		},
+		{
+			name:               "discount active on its last day",
+			now:                time.Date(2025, 3, 7, 23, 59, 0, 0, time.UTC),
+			discount:           discount,
+			wantEffectivePrice: 2550,
+			wantDiscountActive: true,
+		},
+		{
+			name:               "discount expired",
+			now:                time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC),
+			discount:           discount,
+			wantEffectivePrice: 3000,
+			wantDiscountActive: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeutil.TimeHelper = mockTimeHelper{now: tt.now}
+
+			var res dto.GetProductResponse
+			res.ToResponse(domain.Product{
+				BasePrice: 3000,
+				Discount:  tt.discount,
+			})
+
+			assert.Equal(t, tt.wantEffectivePrice, res.EffectivePrice)
+			assert.Equal(t, tt.wantDiscountActive, res.DiscountActive)
+
+			if tt.discount != nil {
+				assert.Equal(t, &tt.discount.DiscountPercent, res.DiscountPercent)
+				assert.Equal(t, tt.discount.MaxPurchaseQty, res.MaxPurchaseQty)
+			} else {
+				assert.Nil(t, res.DiscountPercent)
+			}
+		})
+	}
+}
+
+func TestGetProductResponse_Select(t *testing.T) {
+	timeutil.TimeHelper = mockTimeHelper{now: time.Date(2025, 3, 3, 10, 0, 0, 0, time.UTC)}
+
+	categoryID := uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971429")
+	product := domain.Product{
+		ID:         uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971427"),
+		CategoryID: categoryID,
+		Name:       "Kangkung Potong 1",
+		BasePrice:  3000,
+		Category:   &domain.ProductRelation{ID: categoryID, Name: "Sayuran"},
+	}
+
+	tests := []struct {
+		name     string
+		fieldset dto.ProductFieldset
+		want     string
+	}{
+		{
+			name:     "fields kept with the id",
+			fieldset: dto.ProductFieldset{Fields: []string{"name", "base_price"}},
+			want:     `{"base_price":3000,"id":"e5ec5a4e-509a-4260-9d16-845032971427","name":"Kangkung Potong 1"}`,
+		},
+		{
+			name:     "included relations kept along with the fields",
+			fieldset: dto.ProductFieldset{Fields: []string{"name"}, Include: []string{"category"}},
+			want:     `{"category":{"id":"e5ec5a4e-509a-4260-9d16-845032971429","name":"Sayuran"},"id":"e5ec5a4e-509a-4260-9d16-845032971427","name":"Kangkung Potong 1"}`,
+		},
+		{
+			name:     "relation without record left out",
+			fieldset: dto.ProductFieldset{Fields: []string{"name"}, Include: []string{"supplier", "discount"}},
+			want:     `{"id":"e5ec5a4e-509a-4260-9d16-845032971427","name":"Kangkung Potong 1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var res dto.GetProductResponse
+			res.ToResponse(product)
+			res.Include(product, tt.fieldset.Include)
+
+			got, err := res.Select(tt.fieldset)
+			assert.NoError(t, err)
+
+			data, err := json.Marshal(got)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestImportProductsResponse_WriteCSV(t *testing.T) {
+	var res dto.ImportProductsResponse
+	res.ToResponse(domain.ProductImport{
+		Total:   4,
+		Created: 2,
+		Failed:  2,
+		Rows: []domain.ProductImportRow{
+			{
+				Line:   3,
+				Name:   "Bayam, Hijau",
+				Status: constant.ProductBulkDuplicate,
+				Err:    domain.ErrProductAlreadyExist,
+			},
+			{
+				Line:   5,
+				Name:   "Ka",
+				Status: constant.ProductBulkInvalid,
+				Err:    domain.ErrProductBulkItemInvalid,
+				FieldErrors: []domain.ProductFieldError{
+					{Field: "name", Code: "min", Param: "3"},
+					{Field: "unit", Code: constant.CodeUnitNotFound},
+				},
+			},
+		},
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, res.WriteCSV(&buf))
+
+	want := "line,name,status,code,message,field,field_code,param\n" +
+		"3,\"Bayam, Hijau\",duplicate,product_already_exist," + constant.ProductAlreadyExist + ",,,\n" +
+		"5,Ka,invalid,product_bulk_item_invalid,product failed validation,name,min,3\n" +
+		"5,Ka,invalid,product_bulk_item_invalid,product failed validation,unit,unit_not_found,\n"
+
+	assert.Equal(t, want, buf.String())
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/supplier/domain"
+)
+
+type ContactInfoRequest struct {
+	Email   string `json:"email" validate:"omitempty,email,max=100"`
+	Phone   string `json:"phone" validate:"omitempty,phone"`
+	Address string `json:"address" validate:"omitempty,max=255"`
+}
+
+type CreateSupplierRequest struct {
+	Name        string              `json:"name" validate:"required,min=3,max=100"`
+	ContactInfo *ContactInfoRequest `json:"contact_info" validate:"omitempty"`
+}
+
+type UpdateSupplierRequest struct {
+	ID          uuid.UUID           `json:"-" uri:"id" validate:"required,uuid"`
+	Name        string              `json:"name" validate:"required,min=3,max=100"`
+	ContactInfo *ContactInfoRequest `json:"contact_info" validate:"omitempty"`
+}
+
+type GetListSupplierRequest struct {
+	SupplierName string `query:"supplier_name" validate:"omitempty,max=100"`
+}
+
+type GetSupplierByIDRequest struct {
+	ID uuid.UUID `uri:"id" validate:"required,uuid"`
+}
+
+// ToDomain converts the contact details of a request, keeping a missing block nil.
+func (r *ContactInfoRequest) ToDomain() *domain.ContactInfo {
+	if r == nil {
+		return nil
+	}
+
+	return &domain.ContactInfo{
+		Email:   r.Email,
+		Phone:   r.Phone,
+		Address: r.Address,
+	}
+}
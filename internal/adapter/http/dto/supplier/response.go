@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/supplier/domain"
+)
+
+type (
+	CreateSupplierResponse struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	ContactInfoResponse struct {
+		Email   string `json:"email,omitempty"`
+		Phone   string `json:"phone,omitempty"`
+		Address string `json:"address,omitempty"`
+	}
+
+	GetSupplierResponse struct {
+		ID          uuid.UUID            `json:"id"`
+		Name        string               `json:"name"`
+		ContactInfo *ContactInfoResponse `json:"contact_info"`
+		CreatedAt   string               `json:"created_at"`
+		CreatedBy   string               `json:"created_by"`
+		UpdatedAt   *string              `json:"updated_at,omitempty"`
+		UpdatedBy   *string              `json:"updated_by,omitempty"`
+	}
+
+	GetListSupplierResponse []GetSupplierResponse
+)
+
+func (r *GetSupplierResponse) ToResponse(supplier domain.Supplier) {
+	*r = GetSupplierResponse{
+		ID:          supplier.ID,
+		Name:        supplier.Name,
+		ContactInfo: toContactInfoResponse(supplier.ContactInfo),
+		CreatedAt:   supplier.CreatedAt.Format(time.RFC3339),
+		CreatedBy:   supplier.CreatedBy,
+		UpdatedAt:   formatTime(supplier.UpdatedAt),
+		UpdatedBy:   supplier.UpdatedBy,
+	}
+}
+
+func (r *GetListSupplierResponse) ToResponse(suppliers domain.Suppliers) {
+	for _, supplier := range suppliers {
+		var res GetSupplierResponse
+		res.ToResponse(supplier)
+		*r = append(*r, res)
+	}
+}
+
+// toContactInfoResponse converts optional contact details, keeping nil values nil.
+func toContactInfoResponse(contactInfo *domain.ContactInfo) *ContactInfoResponse {
+	if contactInfo == nil {
+		return nil
+	}
+
+	return &ContactInfoResponse{
+		Email:   contactInfo.Email,
+		Phone:   contactInfo.Phone,
+		Address: contactInfo.Address,
+	}
+}
+
+// formatTime formats an optional timestamp as RFC3339, keeping nil values nil.
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.Format(time.RFC3339)
+
+	return &formatted
+}
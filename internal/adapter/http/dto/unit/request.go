@@ -0,0 +1,26 @@
+package dto
+
+import "github.com/google/uuid"
+
+type CreateUnitRequest struct {
+	Name       string     `json:"name" validate:"required,min=1,max=50"`
+	Dimension  string     `json:"dimension" validate:"required,oneof=mass volume length count"`
+	BaseUnitID *uuid.UUID `json:"base_unit_id" validate:"omitempty,uuid"`
+	Factor     float64    `json:"factor" validate:"required_with=BaseUnitID,omitempty,gt=0"`
+}
+
+type UpdateUnitRequest struct {
+	ID         uuid.UUID  `json:"-" uri:"id" validate:"required,uuid"`
+	Name       string     `json:"name" validate:"required,min=1,max=50"`
+	Dimension  string     `json:"dimension" validate:"required,oneof=mass volume length count"`
+	BaseUnitID *uuid.UUID `json:"base_unit_id" validate:"omitempty,uuid"`
+	Factor     float64    `json:"factor" validate:"required_with=BaseUnitID,omitempty,gt=0"`
+}
+
+type GetListUnitRequest struct {
+	Dimension string `query:"dimension" validate:"omitempty,oneof=mass volume length count"`
+}
+
+type GetUnitByIDRequest struct {
+	ID uuid.UUID `uri:"id" validate:"required,uuid"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/unit/domain"
+)
+
+type (
+	CreateUnitResponse struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	GetUnitResponse struct {
+		ID         uuid.UUID  `json:"id"`
+		Name       string     `json:"name"`
+		Dimension  string     `json:"dimension"`
+		BaseUnitID *uuid.UUID `json:"base_unit_id"`
+		Factor     float64    `json:"factor"`
+	}
+
+	GetListUnitResponse []GetUnitResponse
+)
+
+func (r *GetUnitResponse) ToResponse(unit domain.Unit) {
+	*r = GetUnitResponse{
+		ID:         unit.ID,
+		Name:       unit.Name,
+		Dimension:  unit.Dimension,
+		BaseUnitID: unit.BaseUnitID,
+		Factor:     unit.Factor,
+	}
+}
+
+func (r *GetListUnitResponse) ToResponse(units domain.Units) {
+	for _, unit := range units {
+		var res GetUnitResponse
+		res.ToResponse(unit)
+		*r = append(*r, res)
+	}
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,http_url,max=2048"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=255"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,max=10,dive,oneof=product.created product.updated product.deleted product.restored product.purged stock.changed"`
+}
+
+type GetWebhookByIDRequest struct {
+	ID uuid.UUID `uri:"id" validate:"required,uuid"`
+}
+
+type GetListDeliveryRequest struct {
+	ID     uuid.UUID `uri:"id" validate:"required,uuid"`
+	Status string    `query:"status" validate:"omitempty,oneof=pending delivered dead"`
+	Page   int       `query:"page" validate:"omitempty,min=1"`
+	Limit  int       `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+func (r CreateWebhookRequest) ToDomain() domain.Webhook {
+	return domain.Webhook{
+		URL:        r.URL,
+		Secret:     r.Secret,
+		EventTypes: r.EventTypes,
+	}
+}
+
+// ToFilter selects the requested page of deliveries, the first page of
+// constant.WebhookDeliveryDefaultLimit deliveries unless asked otherwise.
+func (r GetListDeliveryRequest) ToFilter() domain.DeliveryFilter {
+	filter := domain.DeliveryFilter{
+		Status: r.Status,
+		Page:   r.Page,
+		Limit:  r.Limit,
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+
+	if filter.Limit < 1 {
+		filter.Limit = constant.WebhookDeliveryDefaultLimit
+	}
+
+	return filter
+}
@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+type (
+	// CreateWebhookResponse is the only response holding the secret of a webhook.
+	CreateWebhookResponse struct {
+		GetWebhookResponse
+		Secret string `json:"secret"`
+	}
+
+	GetWebhookResponse struct {
+		ID         uuid.UUID `json:"id"`
+		URL        string    `json:"url"`
+		EventTypes []string  `json:"event_types"`
+		CreatedAt  string    `json:"created_at"`
+		CreatedBy  string    `json:"created_by"`
+	}
+
+	GetListWebhookResponse []GetWebhookResponse
+
+	GetDeliveryResponse struct {
+		ID             uuid.UUID       `json:"id"`
+		EventID        uuid.UUID       `json:"event_id"`
+		EventType      string          `json:"event_type"`
+		Status         string          `json:"status"`
+		Attempts       int             `json:"attempts"`
+		NextAttemptAt  *string         `json:"next_attempt_at"`
+		LastAttemptAt  *string         `json:"last_attempt_at"`
+		LastStatusCode *int            `json:"last_status_code"`
+		LastError      *string         `json:"last_error"`
+		DeliveredAt    *string         `json:"delivered_at"`
+		CreatedAt      string          `json:"created_at"`
+		Payload        json.RawMessage `json:"payload"`
+	}
+
+	GetListDeliveryResponse []GetDeliveryResponse
+)
+
+func (r *CreateWebhookResponse) ToResponse(webhook domain.Webhook) {
+	r.GetWebhookResponse.ToResponse(webhook)
+	r.Secret = webhook.Secret
+}
+
+func (r *GetWebhookResponse) ToResponse(webhook domain.Webhook) {
+	*r = GetWebhookResponse{
+		ID:         webhook.ID,
+		URL:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		CreatedAt:  webhook.CreatedAt.Format(time.RFC3339),
+		CreatedBy:  webhook.CreatedBy,
+	}
+}
+
+func (r *GetListWebhookResponse) ToResponse(webhooks domain.Webhooks) {
+	for _, webhook := range webhooks {
+		var res GetWebhookResponse
+		res.ToResponse(webhook)
+		*r = append(*r, res)
+	}
+}
+
+// ToResponse converts a delivery, its next attempt being only set while it is pending.
+func (r *GetDeliveryResponse) ToResponse(delivery domain.Delivery) {
+	*r = GetDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  formatTime(delivery.LastAttemptAt),
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    formatTime(delivery.DeliveredAt),
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+		Payload:        delivery.Payload,
+	}
+
+	if delivery.Status == constant.WebhookDeliveryPending {
+		r.NextAttemptAt = formatTime(&delivery.NextAttemptAt)
+	}
+}
+
+func (r *GetListDeliveryResponse) ToResponse(deliveries domain.Deliveries) {
+	for _, delivery := range deliveries {
+		var res GetDeliveryResponse
+		res.ToResponse(delivery)
+		*r = append(*r, res)
+	}
+}
+
+// formatTime formats an optional timestamp as RFC3339, keeping nil values nil.
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.Format(time.RFC3339)
+
+	return &formatted
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	dto "github.com/gunawanpras/be-product-service/internal/adapter/http/dto/category"
+	"github.com/gunawanpras/be-product-service/internal/core/category/domain"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/response"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/validator"
+)
+
+// CreateCategory handles the creation of a new category. It parses and validates the
+// request body, then calls the CategoryService to create the category. On success, it
+// returns a response with the ID of the newly created category.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or category
+//     creation, otherwise nil.
+func (handler *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
+	var req dto.CreateCategoryRequest
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	args := domain.Category{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	resp, err := handler.service.CategoryService.CreateCategory(ctx, args)
+	if err != nil {
+		return response.Error(c, constant.CategoryCreateFailed, err)
+	}
+
+	respData := dto.CreateCategoryResponse{
+		ID: resp.ID,
+	}
+
+	return response.OK(c, constant.CategoryCreateSuccess, respData, constant.CategoryHttpStatusMappings)
+}
+
+// GetListCategory retrieves the list of categories, optionally filtered by name.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or category
+//     retrieval, otherwise nil.
+func (handler *CategoryHandler) GetListCategory(c *fiber.Ctx) error {
+	var (
+		req dto.GetListCategoryRequest
+		res dto.GetListCategoryResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.QueryParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	resp, err := handler.service.CategoryService.GetListCategory(ctx, req.CategoryName)
+	if err != nil {
+		return response.Error(c, constant.CategoryGetFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.CategoryGetSuccess, res, constant.CategoryHttpStatusMappings)
+}
+
+// GetCategoryByID retrieves a category by its unique identifier.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or category
+//     retrieval, otherwise nil.
+func (handler *CategoryHandler) GetCategoryByID(c *fiber.Ctx) error {
+	var (
+		req dto.GetCategoryByIDRequest
+		res dto.GetCategoryResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	resp, err := handler.service.CategoryService.GetCategoryByID(ctx, req.ID)
+	if err != nil {
+		return response.Error(c, constant.CategoryGetFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.CategoryGetSuccess, res, constant.CategoryHttpStatusMappings)
+}
+
+// UpdateCategory handles the full replacement of an existing category. On success, it
+// returns the updated category information in the response.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or category
+//     update, otherwise nil.
+func (handler *CategoryHandler) UpdateCategory(c *fiber.Ctx) error {
+	var (
+		req dto.UpdateCategoryRequest
+		res dto.GetCategoryResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	args := domain.Category{
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	resp, err := handler.service.CategoryService.UpdateCategory(ctx, args)
+	if err != nil {
+		return response.Error(c, constant.CategoryUpdateFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.CategoryUpdateSuccess, res, constant.CategoryHttpStatusMappings)
+}
+
+// DeleteCategory removes a category by its unique identifier. The request is refused with
+// a conflict while products still reference the category.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or category
+//     deletion, otherwise nil.
+func (handler *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
+	var req dto.GetCategoryByIDRequest
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	err := handler.service.CategoryService.DeleteCategory(ctx, req.ID)
+	if err != nil {
+		return response.Error(c, constant.CategoryDeleteFailed, err)
+	}
+
+	return response.OK(c, constant.CategoryDeleteSuccess, nil, constant.CategoryHttpStatusMappings)
+}
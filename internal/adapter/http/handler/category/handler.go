@@ -0,0 +1,13 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	CreateCategory(c *fiber.Ctx) error
+	GetListCategory(c *fiber.Ctx) error
+	GetCategoryByID(c *fiber.Ctx) error
+	UpdateCategory(c *fiber.Ctx) error
+	DeleteCategory(c *fiber.Ctx) error
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+)
+
+func New(attr InitAttribute) *CategoryHandler {
+	if err := attr.validate(); err != nil {
+		log.Panic(err)
+	}
+	return &CategoryHandler{
+		service: attr.Service,
+	}
+}
+
+func (attr InitAttribute) validate() error {
+	if !attr.Service.validate() {
+		return fmt.Errorf("missing category service : %+v", attr.Service.CategoryService)
+	}
+
+	return nil
+}
+
+func (service ServiceAttribute) validate() bool {
+	return service.CategoryService != nil
+}
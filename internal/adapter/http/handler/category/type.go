@@ -0,0 +1,17 @@
+package handler
+
+import "github.com/gunawanpras/be-product-service/internal/core/category/port"
+
+type (
+	ServiceAttribute struct {
+		CategoryService port.Service
+	}
+
+	CategoryHandler struct {
+		service ServiceAttribute
+	}
+
+	InitAttribute struct {
+		Service ServiceAttribute
+	}
+)
@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"bufio"
+	"log"
+
 	"github.com/gofiber/fiber/v2"
 	dto "github.com/gunawanpras/be-product-service/internal/adapter/http/dto/product"
+	importer "github.com/gunawanpras/be-product-service/internal/adapter/importer/product"
 	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
 	"github.com/gunawanpras/be-product-service/pkg/response"
 	"github.com/gunawanpras/be-product-service/pkg/util/constant"
 	"github.com/gunawanpras/be-product-service/pkg/validator"
@@ -25,7 +30,7 @@ func (handler *ProductHandler) CreateProduct(c *fiber.Ctx) error {
 
 	ctx := c.UserContext()
 	if err := c.BodyParser(&req); err != nil {
-		return response.Error(c, constant.BindingParameterFailed, err, constant.GenericHttpStatusMappings)
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
 	}
 
 	errv := validator.Validate(req)
@@ -45,7 +50,7 @@ func (handler *ProductHandler) CreateProduct(c *fiber.Ctx) error {
 
 	resp, err := handler.service.ProductService.CreateProduct(ctx, args)
 	if err != nil {
-		return response.Error(c, constant.ProductCreateFailed, err, constant.ProductHttpStatusMappings)
+		return response.Error(c, constant.ProductCreateFailed, err)
 	}
 
 	respData := dto.CreateProductResponse{
@@ -55,8 +60,126 @@ func (handler *ProductHandler) CreateProduct(c *fiber.Ctx) error {
 	return response.OK(c, constant.ProductCreateSuccess, respData, constant.ProductHttpStatusMappings)
 }
 
-// GetListProduct retrieves a list of products filtered by product name, category type,
-// and sorted by a specified field and direction.
+// CreateProducts handles the creation of a bulk of products, e.g. when onboarding a
+// supplier. Every product is validated on its own and the outcome of each one is
+// reported. With atomic=true in the query, no product is created unless all of them can
+// be.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or product
+//     creation, otherwise nil.
+func (handler *ProductHandler) CreateProducts(c *fiber.Ctx) error {
+	var (
+		req dto.CreateProductsRequest
+		res dto.CreateProductsResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	if err := c.QueryParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	items, fieldErrors, err := req.ToItems(handler.config.Server.MaxBulkSize)
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, err)
+	}
+
+	resp, err := handler.service.ProductService.CreateProducts(ctx, items, domain.ProductBulkOptions{Atomic: req.Atomic})
+	if err != nil {
+		return response.Error(c, constant.ProductBulkCreateFailed, err)
+	}
+
+	res.ToResponse(resp, fieldErrors)
+
+	message := constant.ProductBulkCreateSuccess
+	switch {
+	case res.Failed > 0 && req.Atomic:
+		message = constant.ProductBulkCreateRejected
+	case res.Failed > 0:
+		message = constant.ProductBulkCreatePartial
+	}
+
+	return response.OK(c, message, res, constant.ProductHttpStatusMappings)
+}
+
+// ImportProducts handles the import of a catalog file, sent as the "file" field of a
+// multipart form that is read as it arrives, up to the import body limit. The file is a CSV or XLSX file whose rows are created by chunks, or only
+// checked on a dry run. The response sums up the import and lists the rows that were not
+// created, as JSON or, when the client accepts text/csv, as a downloadable error report.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, reading the file or
+//     product creation, otherwise nil.
+func (handler *ProductHandler) ImportProducts(c *fiber.Ctx) error {
+	var (
+		req dto.ImportProductsRequest
+		res dto.ImportProductsResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.QueryParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	body := newLimitedBody(c, handler.config.Server.ImportBodyLimit)
+	defer body.discard(c)
+
+	file, err := formFile(c, body, "file")
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, body.cause(apperror.Wrap(apperror.KindValidation, err)))
+	}
+
+	rows, err := importer.NewStreamRowReader(file.FileName(), file)
+	if err != nil {
+		return response.Error(c, constant.ProductImportFailed, body.cause(err))
+	}
+	defer rows.Close()
+
+	result, err := handler.importer.ImportProducts(ctx, rows, req.DryRun)
+	if err != nil {
+		return response.Error(c, constant.ProductImportFailed, body.cause(err))
+	}
+
+	res.ToResponse(result)
+
+	message := constant.ProductImportSuccess
+	switch {
+	case res.Failed > 0:
+		message = constant.ProductImportPartial
+	case req.DryRun:
+		message = constant.ProductImportValidated
+	}
+
+	if c.Accepts(fiber.MIMEApplicationJSON, constant.MIMETextCSV) == constant.MIMETextCSV {
+		c.Status(constant.ProductHttpStatusMappings[message])
+		c.Attachment(constant.ProductImportReportFilename)
+
+		return res.WriteCSV(c)
+	}
+
+	return response.OK(c, message, res, constant.ProductHttpStatusMappings)
+}
+
+// GetListProduct retrieves a page of the products matching the filters of the query,
+// sorted by one or more fields. The meta block of the response holds
+// the cursor of the next page, or the page numbers and total for numbered pages.
+// The products can be trimmed to the fields of the query and embed the related
+// records it includes.
 //
 // Parameters:
 //   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
@@ -72,7 +195,78 @@ func (handler *ProductHandler) GetListProduct(c *fiber.Ctx) error {
 
 	ctx := c.UserContext()
 	if err := c.QueryParser(&req); err != nil {
-		return response.Error(c, constant.BindingParameterFailed, err, constant.GenericHttpStatusMappings)
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	page, err := req.ToListPage(handler.config.Server.MaxPageSize)
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, err)
+	}
+
+	fieldset, err := req.ToFieldset()
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, err)
+	}
+
+	filter, err := req.ToFilter()
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	resp, err := handler.service.ProductService.GetListProduct(ctx, filter, req.ToSort(), page)
+	if err != nil {
+		return response.Error(c, constant.ProductGetFailed, err)
+	}
+
+	res.ToResponse(resp.Products)
+	res.Include(resp.Products, fieldset.Include)
+
+	data, err := res.Select(fieldset)
+	if err != nil {
+		return response.Error(c, constant.ProductGetFailed, err)
+	}
+
+	var meta any
+	if page.Number > 0 {
+		meta = response.NewPageMeta(resp.Total, page.Number, page.Limit)
+	} else {
+		nextCursor, err := dto.EncodeCursor(resp.Next)
+		if err != nil {
+			return response.Error(c, constant.ProductGetFailed, err)
+		}
+
+		meta = response.CursorMeta{
+			NextCursor: nextCursor,
+			HasMore:    resp.Next != nil,
+		}
+	}
+
+	return response.OKWithMeta(c, constant.ProductGetSuccess, data, meta, constant.ProductHttpStatusMappings)
+}
+
+// ExportProduct streams every product matching the filters of the query as a CSV, NDJSON
+// or Parquet file, sorted like GetListProduct but without pages. The products are written
+// to the response with chunked transfer as they are read from the database, so that the
+// catalog is never held in memory. Once the first bytes are sent the status can no longer
+// change: an error while streaming cuts the file short and is only logged.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or when
+//     querying the products, otherwise nil.
+func (handler *ProductHandler) ExportProduct(c *fiber.Ctx) error {
+	var req dto.ExportProductRequest
+
+	ctx := c.UserContext()
+	if err := c.QueryParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
 	}
 
 	errv := validator.Validate(req)
@@ -80,18 +274,168 @@ func (handler *ProductHandler) GetListProduct(c *fiber.Ctx) error {
 		return response.ErrorValidator(c, errv)
 	}
 
-	resp, err := handler.service.ProductService.GetListProduct(ctx, req.ProductName, req.CategoryType, req.Sort, req.Direction)
+	filter, err := req.ToFilter()
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	rows, err := handler.service.ProductService.ExportProduct(ctx, filter, req.ToSort())
 	if err != nil {
-		return response.Error(c, constant.ProductGetFailed, err, constant.ProductHttpStatusMappings)
+		return response.Error(c, constant.ProductExportFailed, err)
+	}
+
+	format := req.ToFormat()
+	c.Attachment(constant.ProductExportFilename + "." + format)
+	c.Set(fiber.HeaderContentType, constant.ProductExportContentTypes[format])
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer rows.Close()
+
+		if err := exportProducts(w, rows, format); err != nil {
+			log.Printf("[ExportProduct] export cut short: %v", err)
+		}
+	})
+
+	return nil
+}
+
+// GetProductFacets counts the products matching the filters of the query by category,
+// supplier, unit and price bucket, for clients to show how many products each refinement
+// of the list would leave. It takes the same filters as GetListProduct, and the price
+// buckets of the server config.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or facet
+//     retrieval, otherwise nil.
+func (handler *ProductHandler) GetProductFacets(c *fiber.Ctx) error {
+	var (
+		req dto.GetProductFacetsRequest
+		res dto.GetProductFacetsResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.QueryParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	filter, err := req.ToFilter()
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	resp, err := handler.service.ProductService.GetProductFacets(ctx, filter, handler.priceBuckets())
+	if err != nil {
+		return response.Error(c, constant.ProductFacetsFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.ProductFacetsSuccess, res, constant.ProductHttpStatusMappings)
+}
+
+// BatchGetProduct retrieves the products with the IDs of the request body at once, for
+// clients that would otherwise fetch them one by one. The products found are returned in
+// the order of the request, followed by the IDs of the missing ones.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or product
+//     retrieval, otherwise nil.
+func (handler *ProductHandler) BatchGetProduct(c *fiber.Ctx) error {
+	var (
+		req dto.BatchGetProductRequest
+		res dto.BatchGetProductResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	productIDs, err := req.ToIDs(handler.config.Server.MaxBatchSize)
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, err)
+	}
+
+	resp, err := handler.service.ProductService.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		return response.Error(c, constant.ProductBatchGetFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.ProductBatchGetSuccess, res, constant.ProductHttpStatusMappings)
+}
+
+// priceBuckets returns the lower bounds of the price buckets of the facets.
+func (handler *ProductHandler) priceBuckets() []float64 {
+	if len(handler.config.Server.PriceBuckets) > 0 {
+		return handler.config.Server.PriceBuckets
+	}
+
+	return constant.ProductFacetPriceBuckets
+}
+
+// SuggestProduct completes a product name being typed. It parses the prefix and the
+// optional category scoping from the query, validates them, and then calls the
+// ProductService to fetch the names starting with or similar to the prefix.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or
+//     suggestion retrieval, otherwise nil.
+func (handler *ProductHandler) SuggestProduct(c *fiber.Ctx) error {
+	var (
+		req dto.SuggestProductRequest
+		res dto.SuggestProductResponses
+	)
+
+	ctx := c.UserContext()
+	if err := c.QueryParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	filter, err := req.ToFilter()
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	resp, err := handler.service.ProductService.SuggestProduct(ctx, req.Prefix, filter, req.ToLimit())
+	if err != nil {
+		return response.Error(c, constant.ProductSuggestFailed, err)
 	}
 
 	res.ToResponse(resp)
 
-	return response.OK(c, constant.ProductGetSuccess, res, constant.ProductHttpStatusMappings)
+	return response.OK(c, constant.ProductSuggestSuccess, res, constant.ProductHttpStatusMappings)
 }
 
 // GetProductByID retrieves a product by its unique identifier. It extracts the product ID
 // from the URI, validates it, and then calls the ProductService to fetch the product details.
+// When a unit is given in the query, the stock and base price are converted to that unit.
+// The fields and include parameters trim the product and embed its related records.
 // On success, it returns the product information in the response.
 //
 // Parameters:
@@ -108,7 +452,11 @@ func (handler *ProductHandler) GetProductByID(c *fiber.Ctx) error {
 
 	ctx := c.UserContext()
 	if err := c.ParamsParser(&req); err != nil {
-		return response.Error(c, constant.BindingParameterFailed, err, constant.GenericHttpStatusMappings)
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	if err := c.QueryParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
 	}
 
 	errv := validator.Validate(req)
@@ -116,12 +464,461 @@ func (handler *ProductHandler) GetProductByID(c *fiber.Ctx) error {
 		return response.ErrorValidator(c, errv)
 	}
 
+	fieldset, err := req.ToFieldset()
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, err)
+	}
+
 	resp, err := handler.service.ProductService.GetProductByID(ctx, req.ID)
 	if err != nil {
-		return response.Error(c, constant.ProductGetFailed, err, constant.ProductHttpStatusMappings)
+		return response.Error(c, constant.ProductGetFailed, err)
+	}
+
+	res.ToResponse(resp)
+	res.Include(resp, fieldset.Include)
+
+	if req.Unit != "" {
+		conversion, err := handler.service.UnitService.GetConversion(ctx, resp.UnitID, req.Unit)
+		if err != nil {
+			return response.Error(c, constant.ProductGetFailed, err)
+		}
+
+		res.ConvertUnit(conversion)
+	}
+	setETag(c, resp.Version)
+
+	data, err := res.Select(fieldset)
+	if err != nil {
+		return response.Error(c, constant.ProductGetFailed, err)
+	}
+
+	return response.OK(c, constant.ProductGetSuccess, data, constant.ProductHttpStatusMappings)
+}
+
+// UpdateProduct handles the full replacement of an existing product. It extracts the
+// product ID from the URI, parses the request body, validates the parsed data, and then
+// calls the ProductService to update the product. On success, it returns the updated
+// product information in the response.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or product
+//     update, otherwise nil.
+func (handler *ProductHandler) UpdateProduct(c *fiber.Ctx) error {
+	var (
+		req dto.UpdateProductRequest
+		res dto.GetProductResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	versions, err := parseIfMatch(c)
+	if err != nil {
+		return response.Error(c, constant.InvalidIfMatchHeader, err)
+	}
+
+	args := domain.Product{
+		ID:          req.ID,
+		CategoryID:  req.CategoryID,
+		SupplierID:  req.SupplierID,
+		UnitID:      req.UnitID,
+		Name:        req.Name,
+		Description: req.Description,
+		BasePrice:   req.BasePrice,
+		Stock:       req.Stock,
+	}
+
+	resp, err := handler.service.ProductService.UpdateProduct(ctx, args, versions)
+	if err != nil {
+		return response.Error(c, constant.ProductUpdateFailed, err)
 	}
 
 	res.ToResponse(resp)
+	setETag(c, resp.Version)
+
+	return response.OK(c, constant.ProductUpdateSuccess, res, constant.ProductHttpStatusMappings)
+}
+
+// PatchProduct handles a partial update of an existing product using JSON merge-patch
+// semantics: fields missing from the body are left untouched and a null description
+// removes it. On success, it returns the updated product information in the response.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or product
+//     update, otherwise nil.
+func (handler *ProductHandler) PatchProduct(c *fiber.Ctx) error {
+	var (
+		req dto.PatchProductRequest
+		res dto.GetProductResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	versions, err := parseIfMatch(c)
+	if err != nil {
+		return response.Error(c, constant.InvalidIfMatchHeader, err)
+	}
+
+	patch := domain.ProductPatch{
+		CategoryID:        req.CategoryID,
+		SupplierID:        req.SupplierID,
+		UnitID:            req.UnitID,
+		Name:              req.Name,
+		Description:       req.Description,
+		RemoveDescription: req.RemoveDescription,
+		BasePrice:         req.BasePrice,
+		Stock:             req.Stock,
+	}
+
+	resp, err := handler.service.ProductService.PatchProduct(ctx, req.ID, versions, patch)
+	if err != nil {
+		return response.Error(c, constant.ProductUpdateFailed, err)
+	}
+
+	res.ToResponse(resp)
+	setETag(c, resp.Version)
+
+	return response.OK(c, constant.ProductUpdateSuccess, res, constant.ProductHttpStatusMappings)
+}
+
+// DeleteProduct soft deletes a product by its unique identifier. The product is hidden from
+// every read afterwards but stays in the database so that it can be restored.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or product
+//     deletion, otherwise nil.
+func (handler *ProductHandler) DeleteProduct(c *fiber.Ctx) error {
+	var req dto.GetProductByIDRequest
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	versions, err := parseIfMatch(c)
+	if err != nil {
+		return response.Error(c, constant.InvalidIfMatchHeader, err)
+	}
+
+	err = handler.service.ProductService.DeleteProduct(ctx, req.ID, versions)
+	if err != nil {
+		return response.Error(c, constant.ProductDeleteFailed, err)
+	}
+
+	return response.OK(c, constant.ProductDeleteSuccess, nil, constant.ProductHttpStatusMappings)
+}
+
+// RestoreProduct brings a soft-deleted product back by its unique identifier. On success, it
+// returns the restored product information in the response.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or product
+//     restore, otherwise nil.
+func (handler *ProductHandler) RestoreProduct(c *fiber.Ctx) error {
+	var (
+		req dto.GetProductByIDRequest
+		res dto.GetProductResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	versions, err := parseIfMatch(c)
+	if err != nil {
+		return response.Error(c, constant.InvalidIfMatchHeader, err)
+	}
+
+	resp, err := handler.service.ProductService.RestoreProduct(ctx, req.ID, versions)
+	if err != nil {
+		return response.Error(c, constant.ProductRestoreFailed, err)
+	}
+
+	res.ToResponse(resp)
+	setETag(c, resp.Version)
+
+	return response.OK(c, constant.ProductRestoreSuccess, res, constant.ProductHttpStatusMappings)
+}
+
+// PurgeProduct permanently removes a product by its unique identifier. It is only reachable
+// through the admin routes.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or product
+//     removal, otherwise nil.
+func (handler *ProductHandler) PurgeProduct(c *fiber.Ctx) error {
+	var req dto.GetProductByIDRequest
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	versions, err := parseIfMatch(c)
+	if err != nil {
+		return response.Error(c, constant.InvalidIfMatchHeader, err)
+	}
+
+	err = handler.service.ProductService.PurgeProduct(ctx, req.ID, versions)
+	if err != nil {
+		return response.Error(c, constant.ProductPurgeFailed, err)
+	}
+
+	return response.OK(c, constant.ProductPurgeSuccess, nil, constant.ProductHttpStatusMappings)
+}
+
+// CreateProductDiscount attaches a discount window to a product. On success, it returns
+// the product information, including its effective price, in the response.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or discount
+//     creation, otherwise nil.
+func (handler *ProductHandler) CreateProductDiscount(c *fiber.Ctx) error {
+	var (
+		req dto.ProductDiscountRequest
+		res dto.GetProductResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	args, err := req.ToDomain()
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	resp, err := handler.service.ProductService.CreateProductDiscount(ctx, args)
+	if err != nil {
+		return response.Error(c, constant.ProductDiscountCreateFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.ProductDiscountCreateSuccess, res, constant.ProductHttpStatusMappings)
+}
+
+// UpdateProductDiscount replaces the discount window of a product. On success, it returns
+// the product information, including its effective price, in the response.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or discount
+//     update, otherwise nil.
+func (handler *ProductHandler) UpdateProductDiscount(c *fiber.Ctx) error {
+	var (
+		req dto.ProductDiscountRequest
+		res dto.GetProductResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	args, err := req.ToDomain()
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	resp, err := handler.service.ProductService.UpdateProductDiscount(ctx, args)
+	if err != nil {
+		return response.Error(c, constant.ProductDiscountUpdateFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.ProductDiscountUpdateSuccess, res, constant.ProductHttpStatusMappings)
+}
+
+// DeleteProductDiscount removes the discount window of a product by its unique identifier.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or discount
+//     removal, otherwise nil.
+func (handler *ProductHandler) DeleteProductDiscount(c *fiber.Ctx) error {
+	var req dto.GetProductByIDRequest
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	err := handler.service.ProductService.DeleteProductDiscount(ctx, req.ID)
+	if err != nil {
+		return response.Error(c, constant.ProductDiscountDeleteFailed, err)
+	}
+
+	return response.OK(c, constant.ProductDiscountDeleteSuccess, nil, constant.ProductHttpStatusMappings)
+}
+
+// CreateStockMovement records a movement in the stock ledger of a product, such as a
+// receipt or a sale, and applies it to the stock of the product. On success, it returns
+// the recorded movement, including the stock after it, in the response.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or when
+//     recording the movement, otherwise nil.
+func (handler *ProductHandler) CreateStockMovement(c *fiber.Ctx) error {
+	var (
+		req dto.CreateStockMovementRequest
+		res dto.StockMovementResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	resp, err := handler.service.ProductService.CreateStockMovement(ctx, req.ToDomain())
+	if err != nil {
+		return response.Error(c, constant.StockMovementCreateFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.StockMovementCreateSuccess, res, constant.ProductHttpStatusMappings)
+}
+
+// GetStockHistory retrieves a page of the stock ledger of a product, newest movement
+// first, optionally of a single type of movement. The pagination details are returned
+// along with the movements.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or when
+//     querying the movements, otherwise nil.
+func (handler *ProductHandler) GetStockHistory(c *fiber.Ctx) error {
+	var (
+		req dto.GetStockHistoryRequest
+		res dto.GetStockHistoryResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	if err := c.QueryParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	page, err := req.ToListPage(handler.config.Server.MaxPageSize)
+	if err != nil {
+		return response.Error(c, constant.BindingParameterFailed, err)
+	}
+
+	resp, err := handler.service.ProductService.GetStockHistory(ctx, req.ID, req.ToFilter(), page)
+	if err != nil {
+		return response.Error(c, constant.StockHistoryGetFailed, err)
+	}
+
+	res.ToResponse(resp.Movements)
 
-	return response.OK(c, constant.ProductGetSuccess, res, constant.ProductHttpStatusMappings)
+	return response.OKWithMeta(c, constant.StockHistoryGetSuccess, res, response.NewPageMeta(resp.Total, page.Number, page.Limit), constant.ProductHttpStatusMappings)
 }
@@ -7,5 +7,21 @@ import (
 type Handler interface {
 	CreateProduct(c *fiber.Ctx) error
 	GetListProduct(c *fiber.Ctx) error
+	SuggestProduct(c *fiber.Ctx) error
+	GetProductFacets(c *fiber.Ctx) error
+	ExportProduct(c *fiber.Ctx) error
+	BatchGetProduct(c *fiber.Ctx) error
+	CreateProducts(c *fiber.Ctx) error
+	ImportProducts(c *fiber.Ctx) error
 	GetProductByID(c *fiber.Ctx) error
+	UpdateProduct(c *fiber.Ctx) error
+	PatchProduct(c *fiber.Ctx) error
+	DeleteProduct(c *fiber.Ctx) error
+	RestoreProduct(c *fiber.Ctx) error
+	PurgeProduct(c *fiber.Ctx) error
+	CreateProductDiscount(c *fiber.Ctx) error
+	UpdateProductDiscount(c *fiber.Ctx) error
+	DeleteProductDiscount(c *fiber.Ctx) error
+	CreateStockMovement(c *fiber.Ctx) error
+	GetStockHistory(c *fiber.Ctx) error
 }
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	dto "github.com/gunawanpras/be-product-service/internal/adapter/http/dto/product"
+	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/internal/core/product/port"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+// parseIfMatch reads the expected product versions from the If-Match header, a comma
+// separated list of entity tags. A missing header or a wildcard yields no version, meaning
+// the version check is skipped. Weak tags are refused, as If-Match compares tags strongly.
+func parseIfMatch(c *fiber.Ctx) (versions domain.ProductVersions, err error) {
+	ifMatch := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+			return nil, apperror.Validation(constant.CodeInvalidIfMatchHeader, constant.InvalidIfMatchHeader)
+		}
+
+		version, err := strconv.Atoi(tag[1 : len(tag)-1])
+		if err != nil || version < constant.ProductInitialVersion {
+			return nil, apperror.Validation(constant.CodeInvalidIfMatchHeader, constant.InvalidIfMatchHeader)
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+// setETag exposes the product version as a strong entity tag.
+func setETag(c *fiber.Ctx, version int) {
+	c.Set(fiber.HeaderETag, `"`+strconv.Itoa(version)+`"`)
+}
+
+// exportProducts writes the products read by rows to w in the given format.
+func exportProducts(w *bufio.Writer, rows port.ProductRows, format string) error {
+	writer := dto.NewProductExportWriter(format, w)
+
+	for rows.Next() {
+		product, err := rows.Product()
+		if err != nil {
+			return err
+		}
+
+		var res dto.ExportProductResponse
+		res.ToResponse(product)
+
+		if err = writer.Write(res); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// limitedBody reads a request body up to limit bytes and fails past it, where
+// io.LimitReader would end the body silently.
+type limitedBody struct {
+	reader   io.Reader
+	left     int64
+	exceeded bool
+}
+
+func newLimitedBody(c *fiber.Ctx, limit int) *limitedBody {
+	var reader = c.Context().RequestBodyStream()
+	if reader == nil {
+		reader = bytes.NewReader(c.Body())
+	}
+
+	return &limitedBody{reader: reader, left: int64(limit)}
+}
+
+func (b *limitedBody) Read(p []byte) (n int, err error) {
+	if b.left <= 0 {
+		// one more byte tells a body of exactly limit bytes from a larger one
+		n, err = b.reader.Read(make([]byte, 1))
+		if n > 0 {
+			b.exceeded = true
+			return 0, fiber.ErrRequestEntityTooLarge
+		}
+
+		return 0, err
+	}
+
+	if int64(len(p)) > b.left {
+		p = p[:b.left]
+	}
+
+	n, err = b.reader.Read(p)
+	b.left -= int64(n)
+
+	return n, err
+}
+
+// discard reads what is left of the body so that the connection can serve the next
+// request, or closes the connection when the body is over its limit.
+func (b *limitedBody) discard(c *fiber.Ctx) {
+	if _, err := io.Copy(io.Discard, b); err != nil {
+		c.Context().SetConnectionClose()
+	}
+}
+
+// cause returns the error to report for err, which may only be a consequence of the
+// body being cut at its limit.
+func (b *limitedBody) cause(err error) error {
+	if b.exceeded {
+		return fiber.ErrRequestEntityTooLarge
+	}
+
+	return err
+}
+
+// formFile reads the multipart form of body up to the file sent as field. The rest of
+// the body is left unread, so that the file is read as it arrives.
+func formFile(c *fiber.Ctx, body io.Reader, field string) (*multipart.Part, error) {
+	boundary := c.Request().Header.MultipartFormBoundary()
+	if len(boundary) == 0 {
+		return nil, http.ErrNotMultipart
+	}
+
+	form := multipart.NewReader(body, string(boundary))
+	for {
+		part, err := form.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, http.ErrMissingFile
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() == field && part.FileName() != "" {
+			return part, nil
+		}
+	}
+}
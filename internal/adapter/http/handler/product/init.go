@@ -10,18 +10,35 @@ func New(attr InitAttribute) *ProductHandler {
 		log.Panic(err)
 	}
 	return &ProductHandler{
-		service: attr.Service,
+		service:  attr.Service,
+		importer: attr.Importer,
+		config:   attr.Config,
 	}
 }
 
 func (attr InitAttribute) validate() error {
 	if !attr.Service.validate() {
-		return fmt.Errorf("missing product service : %+v", attr.Service.ProductService)
+		return fmt.Errorf("missing product or unit service : %+v", attr.Service)
+	}
+
+	if attr.Importer == nil {
+		return fmt.Errorf("missing product importer : %+v", attr.Importer)
+	}
+
+	if attr.Config == nil {
+		return fmt.Errorf("missing config : %+v", attr.Config)
+	}
+
+	buckets := attr.Config.Server.PriceBuckets
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			return fmt.Errorf("price buckets must be in ascending order : %v", buckets)
+		}
 	}
 
 	return nil
 }
 
 func (service ServiceAttribute) validate() bool {
-	return service.ProductService != nil
+	return service.ProductService != nil && service.UnitService != nil
 }
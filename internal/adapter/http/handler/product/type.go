@@ -1,17 +1,27 @@
 package handler
 
-import "github.com/gunawanpras/be-product-service/internal/core/product/port"
+import (
+	"github.com/gunawanpras/be-product-service/config"
+	importer "github.com/gunawanpras/be-product-service/internal/adapter/importer/product"
+	"github.com/gunawanpras/be-product-service/internal/core/product/port"
+	unitPort "github.com/gunawanpras/be-product-service/internal/core/unit/port"
+)
 
 type (
 	ServiceAttribute struct {
 		ProductService port.Service
+		UnitService    unitPort.Service
 	}
 
 	ProductHandler struct {
-		service ServiceAttribute
+		service  ServiceAttribute
+		importer importer.Importer
+		config   *config.Config
 	}
 
 	InitAttribute struct {
-		Service ServiceAttribute
+		Service  ServiceAttribute
+		Importer importer.Importer
+		Config   *config.Config
 	}
 )
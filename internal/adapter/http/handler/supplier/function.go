@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	productDto "github.com/gunawanpras/be-product-service/internal/adapter/http/dto/product"
+	dto "github.com/gunawanpras/be-product-service/internal/adapter/http/dto/supplier"
+	"github.com/gunawanpras/be-product-service/internal/core/supplier/domain"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/response"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/validator"
+)
+
+// CreateSupplier handles the creation of a new supplier. It parses and validates the
+// request body, then calls the SupplierService to create the supplier. On success, it
+// returns a response with the ID of the newly created supplier.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or supplier
+//     creation, otherwise nil.
+func (handler *SupplierHandler) CreateSupplier(c *fiber.Ctx) error {
+	var req dto.CreateSupplierRequest
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	args := domain.Supplier{
+		Name:        req.Name,
+		ContactInfo: req.ContactInfo.ToDomain(),
+	}
+
+	resp, err := handler.service.SupplierService.CreateSupplier(ctx, args)
+	if err != nil {
+		return response.Error(c, constant.SupplierCreateFailed, err)
+	}
+
+	respData := dto.CreateSupplierResponse{
+		ID: resp.ID,
+	}
+
+	return response.OK(c, constant.SupplierCreateSuccess, respData, constant.SupplierHttpStatusMappings)
+}
+
+// GetListSupplier retrieves the list of suppliers, optionally filtered by name.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or supplier
+//     retrieval, otherwise nil.
+func (handler *SupplierHandler) GetListSupplier(c *fiber.Ctx) error {
+	var (
+		req dto.GetListSupplierRequest
+		res dto.GetListSupplierResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.QueryParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	resp, err := handler.service.SupplierService.GetListSupplier(ctx, req.SupplierName)
+	if err != nil {
+		return response.Error(c, constant.SupplierGetFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.SupplierGetSuccess, res, constant.SupplierHttpStatusMappings)
+}
+
+// GetSupplierByID retrieves a supplier by its unique identifier.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or supplier
+//     retrieval, otherwise nil.
+func (handler *SupplierHandler) GetSupplierByID(c *fiber.Ctx) error {
+	var (
+		req dto.GetSupplierByIDRequest
+		res dto.GetSupplierResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	resp, err := handler.service.SupplierService.GetSupplierByID(ctx, req.ID)
+	if err != nil {
+		return response.Error(c, constant.SupplierGetFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.SupplierGetSuccess, res, constant.SupplierHttpStatusMappings)
+}
+
+// UpdateSupplier handles the full replacement of an existing supplier. On success, it
+// returns the updated supplier information in the response.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or supplier
+//     update, otherwise nil.
+func (handler *SupplierHandler) UpdateSupplier(c *fiber.Ctx) error {
+	var (
+		req dto.UpdateSupplierRequest
+		res dto.GetSupplierResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	args := domain.Supplier{
+		ID:          req.ID,
+		Name:        req.Name,
+		ContactInfo: req.ContactInfo.ToDomain(),
+	}
+
+	resp, err := handler.service.SupplierService.UpdateSupplier(ctx, args)
+	if err != nil {
+		return response.Error(c, constant.SupplierUpdateFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.SupplierUpdateSuccess, res, constant.SupplierHttpStatusMappings)
+}
+
+// DeleteSupplier removes a supplier by its unique identifier. The request is refused with
+// a conflict while products still reference the supplier.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or supplier
+//     deletion, otherwise nil.
+func (handler *SupplierHandler) DeleteSupplier(c *fiber.Ctx) error {
+	var req dto.GetSupplierByIDRequest
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	err := handler.service.SupplierService.DeleteSupplier(ctx, req.ID)
+	if err != nil {
+		return response.Error(c, constant.SupplierDeleteFailed, err)
+	}
+
+	return response.OK(c, constant.SupplierDeleteSuccess, nil, constant.SupplierHttpStatusMappings)
+}
+
+// GetListSupplierProduct retrieves the products of a supplier. It responds with not found
+// when the supplier does not exist, and with an empty list when it has no products.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or product
+//     retrieval, otherwise nil.
+func (handler *SupplierHandler) GetListSupplierProduct(c *fiber.Ctx) error {
+	var (
+		req dto.GetSupplierByIDRequest
+		res productDto.GetListProductResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	_, err := handler.service.SupplierService.GetSupplierByID(ctx, req.ID)
+	if err != nil {
+		return response.Error(c, constant.SupplierGetFailed, err)
+	}
+
+	resp, err := handler.service.ProductService.GetListProductBySupplierID(ctx, req.ID)
+	if err != nil {
+		return response.Error(c, constant.ProductGetFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.ProductGetSuccess, res, constant.ProductHttpStatusMappings)
+}
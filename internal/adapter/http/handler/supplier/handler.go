@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	CreateSupplier(c *fiber.Ctx) error
+	GetListSupplier(c *fiber.Ctx) error
+	GetSupplierByID(c *fiber.Ctx) error
+	UpdateSupplier(c *fiber.Ctx) error
+	DeleteSupplier(c *fiber.Ctx) error
+	GetListSupplierProduct(c *fiber.Ctx) error
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	productPort "github.com/gunawanpras/be-product-service/internal/core/product/port"
+	"github.com/gunawanpras/be-product-service/internal/core/supplier/port"
+)
+
+type (
+	ServiceAttribute struct {
+		SupplierService port.Service
+		ProductService  productPort.Service
+	}
+
+	SupplierHandler struct {
+		service ServiceAttribute
+	}
+
+	InitAttribute struct {
+		Service ServiceAttribute
+	}
+)
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	dto "github.com/gunawanpras/be-product-service/internal/adapter/http/dto/unit"
+	"github.com/gunawanpras/be-product-service/internal/core/unit/domain"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/response"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/validator"
+)
+
+// CreateUnit handles the creation of a new unit. It parses and validates the
+// request body, then calls the UnitService to create the unit. On success, it
+// returns a response with the ID of the newly created unit.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or unit
+//     creation, otherwise nil.
+func (handler *UnitHandler) CreateUnit(c *fiber.Ctx) error {
+	var req dto.CreateUnitRequest
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	args := domain.Unit{
+		Name:       req.Name,
+		Dimension:  req.Dimension,
+		BaseUnitID: req.BaseUnitID,
+		Factor:     req.Factor,
+	}
+
+	resp, err := handler.service.UnitService.CreateUnit(ctx, args)
+	if err != nil {
+		return response.Error(c, constant.UnitCreateFailed, err)
+	}
+
+	respData := dto.CreateUnitResponse{
+		ID: resp.ID,
+	}
+
+	return response.OK(c, constant.UnitCreateSuccess, respData, constant.UnitHttpStatusMappings)
+}
+
+// GetListUnit retrieves the list of units, optionally filtered by dimension.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or unit
+//     retrieval, otherwise nil.
+func (handler *UnitHandler) GetListUnit(c *fiber.Ctx) error {
+	var (
+		req dto.GetListUnitRequest
+		res dto.GetListUnitResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.QueryParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	resp, err := handler.service.UnitService.GetListUnit(ctx, req.Dimension)
+	if err != nil {
+		return response.Error(c, constant.UnitGetFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.UnitGetSuccess, res, constant.UnitHttpStatusMappings)
+}
+
+// GetUnitByID retrieves a unit by its unique identifier.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or unit
+//     retrieval, otherwise nil.
+func (handler *UnitHandler) GetUnitByID(c *fiber.Ctx) error {
+	var (
+		req dto.GetUnitByIDRequest
+		res dto.GetUnitResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	resp, err := handler.service.UnitService.GetUnitByID(ctx, req.ID)
+	if err != nil {
+		return response.Error(c, constant.UnitGetFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.UnitGetSuccess, res, constant.UnitHttpStatusMappings)
+}
+
+// UpdateUnit handles the full replacement of an existing unit. On success, it
+// returns the updated unit information in the response.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or unit
+//     update, otherwise nil.
+func (handler *UnitHandler) UpdateUnit(c *fiber.Ctx) error {
+	var (
+		req dto.UpdateUnitRequest
+		res dto.GetUnitResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	args := domain.Unit{
+		ID:         req.ID,
+		Name:       req.Name,
+		Dimension:  req.Dimension,
+		BaseUnitID: req.BaseUnitID,
+		Factor:     req.Factor,
+	}
+
+	resp, err := handler.service.UnitService.UpdateUnit(ctx, args)
+	if err != nil {
+		return response.Error(c, constant.UnitUpdateFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.UnitUpdateSuccess, res, constant.UnitHttpStatusMappings)
+}
+
+// DeleteUnit removes a unit by its unique identifier. The request is refused with
+// a conflict while products still reference the unit.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or unit
+//     deletion, otherwise nil.
+func (handler *UnitHandler) DeleteUnit(c *fiber.Ctx) error {
+	var req dto.GetUnitByIDRequest
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	err := handler.service.UnitService.DeleteUnit(ctx, req.ID)
+	if err != nil {
+		return response.Error(c, constant.UnitDeleteFailed, err)
+	}
+
+	return response.OK(c, constant.UnitDeleteSuccess, nil, constant.UnitHttpStatusMappings)
+}
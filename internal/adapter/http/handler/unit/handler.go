@@ -0,0 +1,13 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	CreateUnit(c *fiber.Ctx) error
+	GetListUnit(c *fiber.Ctx) error
+	GetUnitByID(c *fiber.Ctx) error
+	UpdateUnit(c *fiber.Ctx) error
+	DeleteUnit(c *fiber.Ctx) error
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+)
+
+func New(attr InitAttribute) *UnitHandler {
+	if err := attr.validate(); err != nil {
+		log.Panic(err)
+	}
+	return &UnitHandler{
+		service: attr.Service,
+	}
+}
+
+func (attr InitAttribute) validate() error {
+	if !attr.Service.validate() {
+		return fmt.Errorf("missing unit service : %+v", attr.Service.UnitService)
+	}
+
+	return nil
+}
+
+func (service ServiceAttribute) validate() bool {
+	return service.UnitService != nil
+}
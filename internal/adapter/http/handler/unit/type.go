@@ -0,0 +1,17 @@
+package handler
+
+import "github.com/gunawanpras/be-product-service/internal/core/unit/port"
+
+type (
+	ServiceAttribute struct {
+		UnitService port.Service
+	}
+
+	UnitHandler struct {
+		service ServiceAttribute
+	}
+
+	InitAttribute struct {
+		Service ServiceAttribute
+	}
+)
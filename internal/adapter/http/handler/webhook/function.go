@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	dto "github.com/gunawanpras/be-product-service/internal/adapter/http/dto/webhook"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/response"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/validator"
+)
+
+// CreateWebhook handles the subscription of a URL to event types. It parses and validates
+// the request body, then calls the WebhookService to create the webhook. On success, it
+// returns the webhook along with its secret, which is not returned anywhere else.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during request parsing, validation, or webhook
+//     creation, otherwise nil.
+func (handler *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	var (
+		req dto.CreateWebhookRequest
+		res dto.CreateWebhookResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.BodyParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	resp, err := handler.service.WebhookService.CreateWebhook(ctx, req.ToDomain())
+	if err != nil {
+		return response.Error(c, constant.WebhookCreateFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.WebhookCreateSuccess, res, constant.WebhookHttpStatusMappings)
+}
+
+// GetListWebhook retrieves every webhook, without their secrets.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during webhook retrieval, otherwise nil.
+func (handler *WebhookHandler) GetListWebhook(c *fiber.Ctx) error {
+	var res dto.GetListWebhookResponse
+
+	ctx := c.UserContext()
+
+	resp, err := handler.service.WebhookService.GetListWebhook(ctx)
+	if err != nil {
+		return response.Error(c, constant.WebhookGetFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.WebhookGetSuccess, res, constant.WebhookHttpStatusMappings)
+}
+
+// GetWebhookByID retrieves a webhook by its unique identifier, without its secret.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or webhook
+//     retrieval, otherwise nil.
+func (handler *WebhookHandler) GetWebhookByID(c *fiber.Ctx) error {
+	var (
+		req dto.GetWebhookByIDRequest
+		res dto.GetWebhookResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	resp, err := handler.service.WebhookService.GetWebhookByID(ctx, req.ID)
+	if err != nil {
+		return response.Error(c, constant.WebhookGetFailed, err)
+	}
+
+	res.ToResponse(resp)
+
+	return response.OK(c, constant.WebhookGetSuccess, res, constant.WebhookHttpStatusMappings)
+}
+
+// DeleteWebhook removes a webhook by its unique identifier, along with its deliveries.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or webhook
+//     deletion, otherwise nil.
+func (handler *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	var req dto.GetWebhookByIDRequest
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	err := handler.service.WebhookService.DeleteWebhook(ctx, req.ID)
+	if err != nil {
+		return response.Error(c, constant.WebhookDeleteFailed, err)
+	}
+
+	return response.OK(c, constant.WebhookDeleteSuccess, nil, constant.WebhookHttpStatusMappings)
+}
+
+// GetListDelivery retrieves a page of the deliveries of a webhook, newest first, with the
+// outcome of their last attempt. The deliveries can be narrowed to a status, such as the
+// dead ones, to debug a failing webhook.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if any issue occurs during parameter parsing, validation, or delivery
+//     retrieval, otherwise nil.
+func (handler *WebhookHandler) GetListDelivery(c *fiber.Ctx) error {
+	var (
+		req dto.GetListDeliveryRequest
+		res dto.GetListDeliveryResponse
+	)
+
+	ctx := c.UserContext()
+	if err := c.ParamsParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	if err := c.QueryParser(&req); err != nil {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Wrap(apperror.KindValidation, err))
+	}
+
+	errv := validator.Validate(req)
+	if errv != nil {
+		return response.ErrorValidator(c, errv)
+	}
+
+	filter := req.ToFilter()
+
+	resp, err := handler.service.WebhookService.GetListDelivery(ctx, req.ID, filter)
+	if err != nil {
+		return response.Error(c, constant.WebhookDeliveryGetFailed, err)
+	}
+
+	res.ToResponse(resp.Deliveries)
+	meta := response.NewPageMeta(resp.Total, filter.Page, filter.Limit)
+
+	return response.OKWithMeta(c, constant.WebhookDeliveryGetSuccess, res, meta, constant.WebhookHttpStatusMappings)
+}
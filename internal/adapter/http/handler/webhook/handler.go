@@ -0,0 +1,13 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	CreateWebhook(c *fiber.Ctx) error
+	GetListWebhook(c *fiber.Ctx) error
+	GetWebhookByID(c *fiber.Ctx) error
+	DeleteWebhook(c *fiber.Ctx) error
+	GetListDelivery(c *fiber.Ctx) error
+}
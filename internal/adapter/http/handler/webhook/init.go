@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+)
+
+func New(attr InitAttribute) *WebhookHandler {
+	if err := attr.validate(); err != nil {
+		log.Panic(err)
+	}
+	return &WebhookHandler{
+		service: attr.Service,
+	}
+}
+
+func (attr InitAttribute) validate() error {
+	if !attr.Service.validate() {
+		return fmt.Errorf("missing webhook service : %+v", attr.Service)
+	}
+
+	return nil
+}
+
+func (service ServiceAttribute) validate() bool {
+	return service.WebhookService != nil
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/port"
+)
+
+type (
+	ServiceAttribute struct {
+		WebhookService port.Service
+	}
+
+	WebhookHandler struct {
+		service ServiceAttribute
+	}
+
+	InitAttribute struct {
+		Service ServiceAttribute
+	}
+)
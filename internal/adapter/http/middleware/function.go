@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	rCache "github.com/go-redis/cache/v8"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/response"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+const (
+	idempotencyKeyMaxLength = 255
+	idempotencyLockTtl      = time.Minute
+)
+
+// Idempotency makes a mutating endpoint safe to retry. The first request carrying an
+// Idempotency-Key header is processed normally and its response is stored in Redis
+// together with a hash of the request body. Repeating the key with the same body
+// replays the stored response, while repeating it with a different body is rejected.
+// Requests without the header are passed through untouched.
+//
+// Parameters:
+//   - c: *fiber.Ctx, the Fiber context that provides request and response handling.
+//
+// Returns:
+//   - error: an error if the next handler fails, otherwise nil.
+func (m *HttpMiddleware) Idempotency(c *fiber.Ctx) error {
+	key := c.Get(constant.HeaderIdempotencyKey)
+	if key == "" {
+		return c.Next()
+	}
+
+	if len(key) > idempotencyKeyMaxLength {
+		return response.Error(c, constant.BindingParameterFailed, apperror.Validation(constant.CodeIdempotencyKeyTooLong, constant.IdempotencyKeyTooLong))
+	}
+
+	ctx := c.UserContext()
+	cacheKey := fmt.Sprintf("idempotency:%s:%s:%s", c.Method(), c.Path(), key)
+	requestHash := hashRequestBody(c.Body())
+
+	// reserve the key atomically so that only one of concurrent retries is processed
+	reserved, err := m.reserveIdempotencyKey(ctx, cacheKey, idempotencyRecord{RequestHash: requestHash})
+	if err != nil {
+		return response.Error(c, constant.IdempotencyCheckFailed, err)
+	}
+
+	if !reserved {
+		record, found, err := m.getIdempotencyRecord(ctx, cacheKey)
+		if err != nil {
+			return response.Error(c, constant.IdempotencyCheckFailed, err)
+		}
+
+		if found && record.RequestHash != requestHash {
+			return response.Error(c, constant.IdempotencyCheckFailed, apperror.Unprocessable(constant.CodeIdempotencyKeyReused, constant.IdempotencyKeyReused))
+		}
+
+		// the key was released or is still held by the request that reserved it
+		if !found || record.StatusCode == 0 {
+			return response.Error(c, constant.IdempotencyCheckFailed, apperror.Conflict(constant.CodeIdempotencyRequestInProgress, constant.IdempotencyRequestInProgress))
+		}
+
+		c.Set(constant.HeaderIdempotentReplayed, "true")
+		c.Set(fiber.HeaderContentType, record.ContentType)
+
+		return c.Status(record.StatusCode).Send(record.Body)
+	}
+
+	// release the key when the handler panics, the panic being left to the recover middleware
+	defer func() {
+		if r := recover(); r != nil {
+			_ = m.cache.RedisClient.DeleteValue(ctx, cacheKey)
+			panic(r)
+		}
+	}()
+
+	if err = c.Next(); err != nil {
+		_ = m.cache.RedisClient.DeleteValue(ctx, cacheKey)
+		return err
+	}
+
+	// server errors are not final, let the client retry them
+	statusCode := c.Response().StatusCode()
+	if statusCode >= fiber.StatusInternalServerError {
+		_ = m.cache.RedisClient.DeleteValue(ctx, cacheKey)
+		return nil
+	}
+
+	record := idempotencyRecord{
+		RequestHash: requestHash,
+		StatusCode:  statusCode,
+		ContentType: string(c.Response().Header.ContentType()),
+		Body:        append([]byte(nil), c.Response().Body()...),
+	}
+
+	ttl := time.Duration(m.config.Server.IdempotencyKeyTtl) * time.Minute
+	if err = m.setIdempotencyRecord(ctx, cacheKey, record, ttl); err != nil {
+		_ = m.cache.RedisClient.DeleteValue(ctx, cacheKey)
+	}
+
+	return nil
+}
+
+func (m *HttpMiddleware) getIdempotencyRecord(ctx context.Context, cacheKey string) (record idempotencyRecord, found bool, err error) {
+	// the record is read from Redis, where the key was reserved, as the local cache of
+	// this instance may still hold an earlier record of the key
+	cacheValue, err := m.cache.RedisClient.GetValueDirect(ctx, cacheKey)
+	if err != nil {
+		if errors.Is(err, rCache.ErrCacheMiss) {
+			return record, false, nil
+		}
+
+		return record, false, err
+	}
+
+	if err = json.Unmarshal([]byte(cacheValue), &record); err != nil {
+		return record, false, err
+	}
+
+	return record, true, nil
+}
+
+func (m *HttpMiddleware) reserveIdempotencyKey(ctx context.Context, cacheKey string, record idempotencyRecord) (bool, error) {
+	cacheValue, err := json.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+
+	return m.cache.RedisClient.SetValueNX(ctx, cacheKey, cacheValue, idempotencyLockTtl)
+}
+
+func (m *HttpMiddleware) setIdempotencyRecord(ctx context.Context, cacheKey string, record idempotencyRecord, ttl time.Duration) error {
+	cacheValue, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return m.cache.RedisClient.SetValue(ctx, cacheKey, cacheValue, ttl)
+}
+
+// hashRequestBody hashes the request body. JSON bodies are normalized first so that
+// the same payload with a different key order or spacing hashes the same.
+func hashRequestBody(body []byte) string {
+	var payload any
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if normalized, err := json.Marshal(payload); err == nil {
+			body = normalized
+		}
+	}
+
+	sum := sha256.Sum256(body)
+
+	return hex.EncodeToString(sum[:])
+}
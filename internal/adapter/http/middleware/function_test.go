@@ -0,0 +1,297 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-redis/cache/v8"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/gunawanpras/be-product-service/internal/adapter/http/middleware"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockRedisClient struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (m *mockRedisClient) SetValue(ctx context.Context, key string, value any, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.values[key] = string(value.([]byte))
+	return nil
+}
+
+func (m *mockRedisClient) SetValueNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.values[key]; ok {
+		return false, nil
+	}
+
+	m.values[key] = string(value.([]byte))
+	return true, nil
+}
+
+func (m *mockRedisClient) SetValues(ctx context.Context, values map[string]any, ttl time.Duration) error {
+	for key, value := range values {
+		if err := m.SetValue(ctx, key, value, ttl); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *mockRedisClient) GetValue(ctx context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.values[key]
+	if !ok {
+		return "", cache.ErrCacheMiss
+	}
+
+	return value, nil
+}
+
+func (m *mockRedisClient) GetValueDirect(ctx context.Context, key string) (string, error) {
+	return m.GetValue(ctx, key)
+}
+
+func (m *mockRedisClient) GetValues(ctx context.Context, keys []string) ([]*string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	values := make([]*string, len(keys))
+	for i, key := range keys {
+		if value, ok := m.values[key]; ok {
+			values[i] = &value
+		}
+	}
+
+	return values, nil
+}
+
+func (m *mockRedisClient) Increment(ctx context.Context, key string) (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (m *mockRedisClient) DeleteValue(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.values, key)
+	return nil
+}
+
+func TestHttpMiddleware_Idempotency(t *testing.T) {
+	type request struct {
+		key  string
+		body string
+	}
+
+	tests := []struct {
+		name         string
+		requests     []request
+		wantStatus   int
+		wantCalls    int
+		wantReplayed bool
+	}{
+		{
+			name: "request without key is always processed",
+			requests: []request{
+				{body: `{"name":"Kangkung"}`},
+				{body: `{"name":"Kangkung"}`},
+			},
+			wantStatus: http.StatusCreated,
+			wantCalls:  2,
+		},
+		{
+			name: "repeated key with the same payload is replayed",
+			requests: []request{
+				{key: "key-1", body: `{"name":"Kangkung","stock":1}`},
+				{key: "key-1", body: `{"stock":1, "name":"Kangkung"}`},
+			},
+			wantStatus:   http.StatusCreated,
+			wantCalls:    1,
+			wantReplayed: true,
+		},
+		{
+			name: "repeated key with a different payload is rejected",
+			requests: []request{
+				{key: "key-1", body: `{"name":"Kangkung"}`},
+				{key: "key-1", body: `{"name":"Bayam"}`},
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCalls:  1,
+		},
+		{
+			name: "too long key is rejected",
+			requests: []request{
+				{key: strings.Repeat("k", 256), body: `{"name":"Kangkung"}`},
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCalls:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+
+			m := middleware.New(middleware.InitAttribute{
+				Cache: middleware.CacheAttribute{
+					RedisClient: &mockRedisClient{values: map[string]string{}},
+				},
+				Config: &config.Config{
+					Server: config.ServerConfig{IdempotencyKeyTtl: 1},
+				},
+			})
+
+			app := fiber.New()
+			app.Post("/products", m.Idempotency, func(c *fiber.Ctx) error {
+				calls++
+				return c.Status(http.StatusCreated).JSON(fiber.Map{"id": calls})
+			})
+
+			var (
+				resp  *http.Response
+				first string
+			)
+
+			for i, r := range tt.requests {
+				req := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(r.body))
+				req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+				if r.key != "" {
+					req.Header.Set(constant.HeaderIdempotencyKey, r.key)
+				}
+
+				var err error
+				resp, err = app.Test(req)
+				assert.NoError(t, err)
+
+				body, _ := io.ReadAll(resp.Body)
+				if i == 0 {
+					first = string(body)
+				} else if tt.wantReplayed {
+					assert.Equal(t, first, string(body))
+				}
+			}
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantReplayed, resp.Header.Get(constant.HeaderIdempotentReplayed) == "true")
+		})
+	}
+}
+
+func TestHttpMiddleware_Idempotency_Concurrent(t *testing.T) {
+	const requests = 10
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	m := middleware.New(middleware.InitAttribute{
+		Cache: middleware.CacheAttribute{
+			RedisClient: &mockRedisClient{values: map[string]string{}},
+		},
+		Config: &config.Config{
+			Server: config.ServerConfig{IdempotencyKeyTtl: 1},
+		},
+	})
+
+	app := fiber.New()
+	app.Post("/products", m.Idempotency, func(c *fiber.Ctx) error {
+		calls.Add(1)
+		<-release
+		return c.Status(http.StatusCreated).JSON(fiber.Map{"id": 1})
+	})
+
+	statuses := make(chan int, requests)
+
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(`{"name":"Kangkung"}`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			req.Header.Set(constant.HeaderIdempotencyKey, "key-1")
+
+			resp, err := app.Test(req, -1)
+			if !assert.NoError(t, err) {
+				statuses <- 0
+				return
+			}
+
+			statuses <- resp.StatusCode
+		}()
+	}
+
+	// every request but the one holding the key is rejected without waiting for it
+	counts := map[int]int{}
+	for range requests - 1 {
+		counts[<-statuses]++
+	}
+
+	close(release)
+	wg.Wait()
+	counts[<-statuses]++
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, map[int]int{http.StatusCreated: 1, http.StatusConflict: requests - 1}, counts)
+}
+
+func TestHttpMiddleware_Idempotency_Panic(t *testing.T) {
+	calls := 0
+
+	m := middleware.New(middleware.InitAttribute{
+		Cache: middleware.CacheAttribute{
+			RedisClient: &mockRedisClient{values: map[string]string{}},
+		},
+		Config: &config.Config{
+			Server: config.ServerConfig{IdempotencyKeyTtl: 1},
+		},
+	})
+
+	app := fiber.New()
+	app.Use(recover.New())
+	app.Post("/products", m.Idempotency, func(c *fiber.Ctx) error {
+		calls++
+		if calls == 1 {
+			panic("handler failed")
+		}
+
+		return c.SendStatus(http.StatusCreated)
+	})
+
+	var statuses []int
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(`{"name":"Kangkung"}`))
+		req.Header.Set(constant.HeaderIdempotencyKey, "key-1")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+
+		statuses = append(statuses, resp.StatusCode)
+	}
+
+	// the key reserved by the request that panicked is released for its retry
+	assert.Equal(t, []int{http.StatusInternalServerError, http.StatusCreated}, statuses)
+	assert.Equal(t, 2, calls)
+}
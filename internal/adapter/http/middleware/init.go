@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+)
+
+func New(attr InitAttribute) *HttpMiddleware {
+	if err := attr.validate(); err != nil {
+		log.Panic(err)
+	}
+
+	return &HttpMiddleware{
+		cache:  attr.Cache,
+		config: attr.Config,
+	}
+}
+
+func (attr InitAttribute) validate() error {
+	if !attr.Cache.validate() {
+		return fmt.Errorf("missing redis client : %+v", attr.Cache.RedisClient)
+	}
+
+	if attr.Config == nil {
+		return fmt.Errorf("missing config : %+v", attr.Config)
+	}
+
+	return nil
+}
+
+func (cache CacheAttribute) validate() bool {
+	return cache.RedisClient != nil
+}
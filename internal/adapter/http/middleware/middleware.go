@@ -0,0 +1,9 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type Middleware interface {
+	Idempotency(c *fiber.Ctx) error
+}
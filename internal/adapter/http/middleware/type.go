@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/gunawanpras/be-product-service/internal/adapter/cache/redis"
+)
+
+type (
+	CacheAttribute struct {
+		RedisClient redis.Cache
+	}
+
+	HttpMiddleware struct {
+		cache  CacheAttribute
+		config *config.Config
+	}
+
+	InitAttribute struct {
+		Cache  CacheAttribute
+		Config *config.Config
+	}
+
+	// idempotencyRecord is what gets stored for an Idempotency-Key. A record without
+	// a status code belongs to a request that is still being processed.
+	idempotencyRecord struct {
+		RequestHash string `json:"request_hash"`
+		StatusCode  int    `json:"status_code,omitempty"`
+		ContentType string `json:"content_type,omitempty"`
+		Body        []byte `json:"body,omitempty"`
+	}
+)
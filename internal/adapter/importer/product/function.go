@@ -0,0 +1,427 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	dto "github.com/gunawanpras/be-product-service/internal/adapter/http/dto/product"
+	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/validator"
+)
+
+var (
+	// columns an import file must have, in any order
+	requiredColumns = []string{
+		constant.ProductImportColumnCategory,
+		constant.ProductImportColumnSupplier,
+		constant.ProductImportColumnUnit,
+		constant.ProductImportColumnName,
+		constant.ProductImportColumnBasePrice,
+		constant.ProductImportColumnStock,
+	}
+
+	// relationColumns names the columns holding the name of the record a field of
+	// dto.CreateProductRequest refers to.
+	relationColumns = map[string]string{
+		"category_id": constant.ProductImportColumnCategory,
+		"supplier_id": constant.ProductImportColumnSupplier,
+		"unit_id":     constant.ProductImportColumnUnit,
+	}
+)
+
+type (
+	// columns holds the position of each known column of an import file.
+	columns map[string]int
+
+	// importRow is a row of the file waiting to be created with the rest of its chunk.
+	importRow struct {
+		line        int
+		item        domain.ProductBulkItem
+		fieldErrors []*validator.ErrorResponse
+	}
+
+	productName struct {
+		categoryID uuid.UUID
+		name       string
+	}
+
+	// resolution is the record found for a name, or the code of the error telling it
+	// was not found.
+	resolution struct {
+		id   uuid.UUID
+		code string
+	}
+
+	// resolver finds the categories, suppliers and units of the rows by their name,
+	// remembering every name it looked up for the rest of the import.
+	resolver struct {
+		lookups  map[string]func(ctx context.Context, name string) (uuid.UUID, error)
+		resolved map[string]map[string]resolution
+	}
+)
+
+// ImportProducts creates the products listed in a catalog file. The first row of the
+// file names the columns, which are the fields of dto.CreateProductRequest except that
+// the category, supplier and unit are given by name. The following rows are read and
+// created by chunks, so that a file of any size is never held in memory, each chunk
+// going through the same checks as a bulk creation. A row failing validation or
+// clashing with an existing product, or an earlier row of the file, does not stop the
+// import, nor does a chunk that cannot be created, its rows being reported as failed.
+// On a dry run, the rows are checked the same way but nothing is created.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the import.
+// - rows: RowReader reading the rows of the file.
+// - dryRun: Whether to only check the rows.
+//
+// Returns:
+// - res: domain.ProductImport summing up the import and holding the rows that were not created.
+// - err: error if the file cannot be read, a name cannot be looked up or ctx is done. The chunks created before it are kept.
+func (importer *ProductImporter) ImportProducts(ctx context.Context, rows RowReader, dryRun bool) (res domain.ProductImport, err error) {
+	res.DryRun = dryRun
+
+	header, _, err := rows.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return res, domain.ErrProductImportInvalidHeader
+		}
+
+		return res, err
+	}
+
+	columns, err := parseHeader(header)
+	if err != nil {
+		return res, err
+	}
+
+	chunkSize := importer.config.Server.ImportChunkSize
+	if chunkSize <= 0 {
+		chunkSize = constant.ProductImportChunkSize
+	}
+
+	var (
+		names = importer.newResolver()
+		seen  = map[productName]bool{}
+		chunk = make([]importRow, 0, chunkSize)
+	)
+
+	for {
+		cells, line, err := rows.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return res, err
+		}
+
+		if isBlank(cells) {
+			continue
+		}
+
+		row, err := parseRow(ctx, names, columns, cells, line)
+		if err != nil {
+			return res, err
+		}
+
+		chunk = append(chunk, row)
+		if len(chunk) < chunkSize {
+			continue
+		}
+
+		if err = importer.importChunk(ctx, &res, chunk, seen); err != nil {
+			return res, err
+		}
+
+		chunk = chunk[:0]
+	}
+
+	if len(chunk) > 0 {
+		if err = importer.importChunk(ctx, &res, chunk, seen); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// importChunk creates the products of a chunk of rows, adding their outcome to res.
+// The products found valid on a dry run are remembered in seen, since they are not
+// created for the next chunks to clash with. When the chunk cannot be created, its rows
+// are failed and only the end of ctx is returned.
+func (importer *ProductImporter) importChunk(ctx context.Context, res *domain.ProductImport, chunk []importRow, seen map[productName]bool) error {
+	items := make([]domain.ProductBulkItem, len(chunk))
+	for i, row := range chunk {
+		items[i] = row.item
+	}
+
+	items, err := importer.service.ProductService.CreateProducts(ctx, items, domain.ProductBulkOptions{DryRun: res.DryRun})
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+
+		log.Printf("[ImportProducts] chunk from line %d failed: %v", chunk[0].line, err)
+		items = failChunk(chunk, err)
+	}
+
+	for i, item := range items {
+		if item.Status == constant.ProductBulkValid {
+			key := productName{item.Product.CategoryID, item.Product.Name}
+			if seen[key] {
+				item.Status = constant.ProductBulkDuplicate
+				item.Err = domain.ErrProductAlreadyExist
+			}
+
+			seen[key] = true
+		}
+
+		res.Total++
+
+		switch item.Status {
+		case constant.ProductBulkCreated:
+			res.Created++
+			continue
+		case constant.ProductBulkValid:
+			res.Valid++
+			continue
+		}
+
+		res.Failed++
+		row := domain.ProductImportRow{
+			Line:   chunk[i].line,
+			Name:   item.Product.Name,
+			Status: item.Status,
+			Err:    item.Err,
+		}
+
+		for _, fieldError := range chunk[i].fieldErrors {
+			row.FieldErrors = append(row.FieldErrors, domain.ProductFieldError{
+				Field: fieldError.Field,
+				Code:  fieldError.Tag,
+				Param: fieldError.Value,
+			})
+		}
+
+		res.Rows = append(res.Rows, row)
+	}
+
+	return nil
+}
+
+// failChunk fails the rows of a chunk that could not be created. The rows failing
+// validation stay invalid, the others take err, or ErrProductImportChunkFailed when err
+// is not meant for the client.
+func failChunk(chunk []importRow, err error) []domain.ProductBulkItem {
+	if apperror.KindOf(err) == apperror.KindInternal {
+		err = domain.ErrProductImportChunkFailed
+	}
+
+	items := make([]domain.ProductBulkItem, len(chunk))
+	for i, row := range chunk {
+		items[i] = row.item
+		if items[i].Err != nil {
+			items[i].Status = constant.ProductBulkInvalid
+			continue
+		}
+
+		items[i].Status = constant.ProductBulkFailed
+		items[i].Err = err
+	}
+
+	return items
+}
+
+// parseHeader finds the known columns in the header row of a file. Column names are
+// case insensitive, and unknown columns are ignored.
+func parseHeader(cells []string) (columns, error) {
+	res := columns{}
+	for i, cell := range cells {
+		// spreadsheets may start their CSV exports with a byte order mark
+		if i == 0 {
+			cell = strings.TrimPrefix(cell, "\ufeff")
+		}
+
+		name := strings.ToLower(strings.TrimSpace(cell))
+		if _, ok := res[name]; !ok {
+			res[name] = i
+		}
+	}
+
+	for _, column := range requiredColumns {
+		if _, ok := res[column]; !ok {
+			return nil, domain.ErrProductImportInvalidHeader
+		}
+	}
+
+	return res, nil
+}
+
+// value returns the trimmed cell of a row in the given column.
+func (c columns) value(cells []string, column string) string {
+	i, ok := c[column]
+	if !ok || i >= len(cells) {
+		return ""
+	}
+
+	return strings.TrimSpace(cells[i])
+}
+
+func isBlank(cells []string) bool {
+	return !slices.ContainsFunc(cells, func(cell string) bool {
+		return strings.TrimSpace(cell) != ""
+	})
+}
+
+// parseRow turns a row of the file into a product to create. The row is validated like
+// a dto.CreateProductRequest, its failed validations being named after the columns.
+func parseRow(ctx context.Context, names *resolver, columns columns, cells []string, line int) (res importRow, err error) {
+	var fieldErrors []*validator.ErrorResponse
+
+	req := dto.CreateProductRequest{
+		Name: columns.value(cells, constant.ProductImportColumnName),
+	}
+
+	if description := columns.value(cells, constant.ProductImportColumnDescription); description != "" {
+		req.Description = &description
+	}
+
+	if value := columns.value(cells, constant.ProductImportColumnBasePrice); value != "" {
+		if req.BasePrice, err = strconv.ParseFloat(value, 64); err != nil {
+			fieldErrors = append(fieldErrors, columnError(constant.ProductImportColumnBasePrice, "number"))
+		}
+	}
+
+	if value := columns.value(cells, constant.ProductImportColumnStock); value != "" {
+		if req.Stock, err = strconv.Atoi(value); err != nil {
+			fieldErrors = append(fieldErrors, columnError(constant.ProductImportColumnStock, "number"))
+		}
+	}
+
+	relations := []struct {
+		column string
+		id     *uuid.UUID
+	}{
+		{constant.ProductImportColumnCategory, &req.CategoryID},
+		{constant.ProductImportColumnSupplier, &req.SupplierID},
+		{constant.ProductImportColumnUnit, &req.UnitID},
+	}
+
+	for _, relation := range relations {
+		name := columns.value(cells, relation.column)
+		if name == "" {
+			continue
+		}
+
+		found, err := names.resolve(ctx, relation.column, name)
+		if err != nil {
+			return res, err
+		}
+
+		if found.code != "" {
+			fieldErrors = append(fieldErrors, columnError(relation.column, found.code))
+			continue
+		}
+
+		*relation.id = found.id
+	}
+
+	for _, fieldError := range validator.Validate(req) {
+		if column, ok := relationColumns[fieldError.Field]; ok {
+			fieldError.Field = column
+		}
+
+		// a column that could not be parsed or resolved is only reported once
+		if slices.ContainsFunc(fieldErrors, func(e *validator.ErrorResponse) bool { return e.Field == fieldError.Field }) {
+			continue
+		}
+
+		fieldErrors = append(fieldErrors, fieldError)
+	}
+
+	res = importRow{
+		line: line,
+		item: domain.ProductBulkItem{
+			Product: domain.Product{
+				CategoryID:  req.CategoryID,
+				SupplierID:  req.SupplierID,
+				UnitID:      req.UnitID,
+				Name:        req.Name,
+				Description: req.Description,
+				BasePrice:   req.BasePrice,
+				Stock:       req.Stock,
+			},
+		},
+		fieldErrors: fieldErrors,
+	}
+
+	if len(fieldErrors) > 0 {
+		res.item.Err = domain.ErrProductBulkItemInvalid
+	}
+
+	return res, nil
+}
+
+// columnError reports a column whose value is not valid.
+func columnError(column, code string) *validator.ErrorResponse {
+	return &validator.ErrorResponse{
+		FailedField: column,
+		Tag:         code,
+		Field:       column,
+	}
+}
+
+func (importer *ProductImporter) newResolver() *resolver {
+	service := importer.service
+
+	return &resolver{
+		lookups: map[string]func(ctx context.Context, name string) (uuid.UUID, error){
+			constant.ProductImportColumnCategory: func(ctx context.Context, name string) (uuid.UUID, error) {
+				category, err := service.CategoryService.GetCategoryByName(ctx, name)
+				return category.ID, err
+			},
+			constant.ProductImportColumnSupplier: func(ctx context.Context, name string) (uuid.UUID, error) {
+				supplier, err := service.SupplierService.GetSupplierByName(ctx, name)
+				return supplier.ID, err
+			},
+			constant.ProductImportColumnUnit: func(ctx context.Context, name string) (uuid.UUID, error) {
+				unit, err := service.UnitService.GetUnitByName(ctx, name)
+				return unit.ID, err
+			},
+		},
+		resolved: map[string]map[string]resolution{},
+	}
+}
+
+// resolve finds the record of the given column by its name. A name matching no record
+// resolves to the code of the not found error, any other error is returned.
+func (r *resolver) resolve(ctx context.Context, column, name string) (res resolution, err error) {
+	if res, ok := r.resolved[column][name]; ok {
+		return res, nil
+	}
+
+	id, err := r.lookups[column](ctx, name)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return res, err
+		}
+
+		res.code = apperror.CodeOf(err)
+	}
+
+	res.id = id
+	if r.resolved[column] == nil {
+		r.resolved[column] = map[string]resolution{}
+	}
+	r.resolved[column][name] = res
+
+	return res, nil
+}
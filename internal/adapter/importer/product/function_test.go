@@ -0,0 +1,231 @@
+package importer_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/config"
+	importer "github.com/gunawanpras/be-product-service/internal/adapter/importer/product"
+	categoryDomain "github.com/gunawanpras/be-product-service/internal/core/category/domain"
+	categoryPort "github.com/gunawanpras/be-product-service/internal/core/category/port"
+	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/internal/core/product/port"
+	supplierDomain "github.com/gunawanpras/be-product-service/internal/core/supplier/domain"
+	supplierPort "github.com/gunawanpras/be-product-service/internal/core/supplier/port"
+	unitDomain "github.com/gunawanpras/be-product-service/internal/core/unit/domain"
+	unitPort "github.com/gunawanpras/be-product-service/internal/core/unit/port"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubProductService creates the products of a bulk the way the product service does
+// when the bulk is not atomic, failing the calls listed in errs by their number.
+type stubProductService struct {
+	port.Service
+	calls int
+	errs  map[int]error
+}
+
+func (s *stubProductService) CreateProducts(ctx context.Context, items []domain.ProductBulkItem, opts domain.ProductBulkOptions) ([]domain.ProductBulkItem, error) {
+	s.calls++
+	if err := s.errs[s.calls]; err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.ProductBulkItem, len(items))
+	for i, item := range items {
+		switch {
+		case item.Err != nil:
+			item.Status = constant.ProductBulkInvalid
+		case opts.DryRun:
+			item.Status = constant.ProductBulkValid
+		default:
+			item.Status = constant.ProductBulkCreated
+		}
+
+		res[i] = item
+	}
+
+	return res, nil
+}
+
+type stubCategoryService struct {
+	categoryPort.Service
+}
+
+func (stubCategoryService) GetCategoryByName(ctx context.Context, name string) (categoryDomain.Category, error) {
+	if name != "Sayur" {
+		return categoryDomain.Category{}, categoryDomain.ErrCategoryNotFound
+	}
+
+	return categoryDomain.Category{ID: sayurID, Name: name}, nil
+}
+
+type stubSupplierService struct {
+	supplierPort.Service
+}
+
+func (stubSupplierService) GetSupplierByName(ctx context.Context, name string) (supplierDomain.Supplier, error) {
+	return supplierDomain.Supplier{ID: taniID, Name: name}, nil
+}
+
+type stubUnitService struct {
+	unitPort.Service
+}
+
+func (stubUnitService) GetUnitByName(ctx context.Context, name string) (unitDomain.Unit, error) {
+	return unitDomain.Unit{ID: ikatID, Name: name}, nil
+}
+
+var (
+	sayurID = uuid.New()
+	taniID  = uuid.New()
+	ikatID  = uuid.New()
+)
+
+func newImporter(products *stubProductService) *importer.ProductImporter {
+	return importer.New(importer.InitAttribute{
+		Service: importer.ServiceAttribute{
+			ProductService:  products,
+			CategoryService: stubCategoryService{},
+			SupplierService: stubSupplierService{},
+			UnitService:     stubUnitService{},
+		},
+		Config: &config.Config{
+			Server: config.ServerConfig{ImportChunkSize: 2},
+		},
+	})
+}
+
+func TestProductImporter_ImportProducts(t *testing.T) {
+	const file = "name,category,supplier,unit,base_price,stock\n" +
+		"Bayam,Sayur,Tani,Ikat,4000,50\n" +
+		"Kangkung,Buah,Tani,Ikat,3000,20\n" +
+		"\n" +
+		"Sawi,Sayur,Tani,Ikat,abc,10\n" +
+		"Bayam,Sayur,Tani,Ikat,4500,5\n" +
+		"Selada,Sayur,Tani,Ikat,6000,15\n"
+
+	tests := []struct {
+		name         string
+		file         string
+		dryRun       bool
+		errs         map[int]error
+		want         domain.ProductImport
+		wantLines    []int
+		wantStatuses []string
+		wantErr      error
+	}{
+		{
+			name:         "creates the rows by chunks and reports the invalid ones",
+			file:         file,
+			want:         domain.ProductImport{Total: 5, Created: 3, Failed: 2},
+			wantLines:    []int{3, 5},
+			wantStatuses: []string{constant.ProductBulkInvalid, constant.ProductBulkInvalid},
+		},
+		{
+			name:         "dry run reports the rows clashing with an earlier chunk",
+			file:         file,
+			dryRun:       true,
+			want:         domain.ProductImport{DryRun: true, Total: 5, Valid: 2, Failed: 3},
+			wantLines:    []int{3, 5, 6},
+			wantStatuses: []string{constant.ProductBulkInvalid, constant.ProductBulkInvalid, constant.ProductBulkDuplicate},
+		},
+		{
+			name:         "a chunk that cannot be created fails its rows and the import goes on",
+			file:         file,
+			errs:         map[int]error{2: errors.New("connection reset")},
+			want:         domain.ProductImport{Total: 5, Created: 2, Failed: 3},
+			wantLines:    []int{3, 5, 6},
+			wantStatuses: []string{constant.ProductBulkInvalid, constant.ProductBulkInvalid, constant.ProductBulkFailed},
+		},
+		{
+			name:    "file without header",
+			file:    "",
+			wantErr: domain.ErrProductImportInvalidHeader,
+		},
+		{
+			name:    "header missing a column",
+			file:    "name,category,supplier,unit,base_price\n",
+			wantErr: domain.ErrProductImportInvalidHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := importer.NewRowReader("products.csv", strings.NewReader(tt.file), int64(len(tt.file)))
+			assert.NoError(t, err)
+
+			res, err := newImporter(&stubProductService{errs: tt.errs}).ImportProducts(context.Background(), rows, tt.dryRun)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			var (
+				lines    []int
+				statuses []string
+			)
+			for _, row := range res.Rows {
+				lines = append(lines, row.Line)
+				statuses = append(statuses, row.Status)
+			}
+
+			assert.Equal(t, tt.wantLines, lines)
+			assert.Equal(t, tt.wantStatuses, statuses)
+
+			res.Rows = nil
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestProductImporter_ImportProducts_FailedChunk(t *testing.T) {
+	const file = "name,category,supplier,unit,base_price,stock\n" +
+		"Bayam,Sayur,Tani,Ikat,4000,50\n" +
+		"Sawi,Sayur,Tani,Ikat,5000,10\n"
+
+	t.Run("client errors are kept", func(t *testing.T) {
+		rows, err := importer.NewRowReader("products.csv", strings.NewReader(file), int64(len(file)))
+		assert.NoError(t, err)
+
+		products := &stubProductService{errs: map[int]error{1: domain.ErrProductAlreadyExist}}
+		res, err := newImporter(products).ImportProducts(context.Background(), rows, false)
+		assert.NoError(t, err)
+
+		if assert.Len(t, res.Rows, 2) {
+			assert.ErrorIs(t, res.Rows[0].Err, domain.ErrProductAlreadyExist)
+			assert.ErrorIs(t, res.Rows[1].Err, domain.ErrProductAlreadyExist)
+		}
+	})
+
+	t.Run("internal errors are not told to the client", func(t *testing.T) {
+		rows, err := importer.NewRowReader("products.csv", strings.NewReader(file), int64(len(file)))
+		assert.NoError(t, err)
+
+		products := &stubProductService{errs: map[int]error{1: errors.New("connection reset")}}
+		res, err := newImporter(products).ImportProducts(context.Background(), rows, false)
+		assert.NoError(t, err)
+
+		if assert.Len(t, res.Rows, 2) {
+			assert.ErrorIs(t, res.Rows[0].Err, domain.ErrProductImportChunkFailed)
+		}
+	})
+
+	t.Run("stops once the context is done", func(t *testing.T) {
+		rows, err := importer.NewRowReader("products.csv", strings.NewReader(file), int64(len(file)))
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		products := &stubProductService{errs: map[int]error{1: context.Canceled}}
+		_, err = newImporter(products).ImportProducts(ctx, rows, false)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,33 @@
+package importer
+
+import (
+	"fmt"
+	"log"
+)
+
+func New(attr InitAttribute) *ProductImporter {
+	if err := attr.validate(); err != nil {
+		log.Panic(err)
+	}
+	return &ProductImporter{
+		service: attr.Service,
+		config:  attr.Config,
+	}
+}
+
+func (attr InitAttribute) validate() error {
+	if !attr.Service.validate() {
+		return fmt.Errorf("missing product, category, supplier or unit service : %+v", attr.Service)
+	}
+
+	if attr.Config == nil {
+		return fmt.Errorf("missing config : %+v", attr.Config)
+	}
+
+	return nil
+}
+
+func (service ServiceAttribute) validate() bool {
+	return service.ProductService != nil && service.CategoryService != nil &&
+		service.SupplierService != nil && service.UnitService != nil
+}
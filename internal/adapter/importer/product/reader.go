@@ -0,0 +1,371 @@
+package importer
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+)
+
+const (
+	formatCSV  = ".csv"
+	formatXLSX = ".xlsx"
+)
+
+// NewRowReader reads the rows of a catalog file, picking the format from the extension
+// of its name. Neither format is loaded at once: CSV files are read line by line, and
+// the first sheet of XLSX files is decoded row by row out of the archive, only its table
+// of shared strings being held in memory.
+func NewRowReader(name string, file io.ReaderAt, size int64) (RowReader, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case formatCSV:
+		return newCSVReader(io.NewSectionReader(file, 0, size)), nil
+	case formatXLSX:
+		return newXLSXReader(file, size)
+	default:
+		return nil, domain.ErrProductImportUnsupportedFormat
+	}
+}
+
+// NewStreamRowReader reads the rows of a catalog file received as a stream, such as the
+// part of a multipart form. CSV files are read as they arrive, while XLSX files, whose
+// archive can only be read once complete, are first copied to a temporary file that is
+// removed on Close.
+func NewStreamRowReader(name string, file io.Reader) (RowReader, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case formatCSV:
+		return newCSVReader(file), nil
+	case formatXLSX:
+		return newSpooledXLSXReader(file)
+	default:
+		return nil, domain.ErrProductImportUnsupportedFormat
+	}
+}
+
+// malformed tells the client why its file could not be read.
+func malformed(err error) error {
+	return fmt.Errorf("%w: %v", domain.ErrProductImportMalformed, err)
+}
+
+type csvReader struct {
+	reader *csv.Reader
+}
+
+func newCSVReader(r io.Reader) *csvReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	return &csvReader{reader: reader}
+}
+
+func (r *csvReader) Read() (cells []string, line int, err error) {
+	cells, err = r.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, 0, io.EOF
+		}
+
+		return nil, 0, malformed(err)
+	}
+
+	line, _ = r.reader.FieldPos(0)
+
+	return cells, line, nil
+}
+
+func (r *csvReader) Close() error {
+	return nil
+}
+
+type (
+	xlsxReader struct {
+		sheet   io.ReadCloser
+		decoder *xml.Decoder
+		strings []string
+		line    int
+	}
+
+	xlsxWorkbook struct {
+		Sheets []struct {
+			RelationID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+
+	// xlsxText is either plain text or rich text split into runs. Phonetic hints are left out.
+	xlsxText struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	}
+
+	xlsxRow struct {
+		Line  int        `xml:"r,attr"`
+		Cells []xlsxCell `xml:"c"`
+	}
+
+	xlsxCell struct {
+		Ref    string   `xml:"r,attr"`
+		Type   string   `xml:"t,attr"`
+		Value  string   `xml:"v"`
+		Inline xlsxText `xml:"is"`
+	}
+)
+
+const (
+	xlsxWorkbookPath      = "xl/workbook.xml"
+	xlsxRelationshipsPath = "xl/_rels/workbook.xml.rels"
+	xlsxSharedStringsPath = "xl/sharedStrings.xml"
+)
+
+func newXLSXReader(file io.ReaderAt, size int64) (*xlsxReader, error) {
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		return nil, malformed(err)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := xlsxFirstSheet(files)
+	if err != nil {
+		return nil, malformed(err)
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if f, ok := files[xlsxSharedStringsPath]; ok {
+		if err := xlsxDecode(f, &sharedStrings); err != nil {
+			return nil, malformed(err)
+		}
+	}
+
+	sheet, ok := files[sheetPath]
+	if !ok {
+		return nil, malformed(fmt.Errorf("missing sheet %s", sheetPath))
+	}
+
+	rc, err := sheet.Open()
+	if err != nil {
+		return nil, malformed(err)
+	}
+
+	reader := &xlsxReader{
+		sheet:   rc,
+		decoder: xml.NewDecoder(rc),
+		strings: make([]string, len(sharedStrings.Items)),
+	}
+
+	for i, item := range sharedStrings.Items {
+		reader.strings[i] = item.String()
+	}
+
+	return reader, nil
+}
+
+// xlsxFirstSheet finds the part holding the first sheet of the workbook.
+func xlsxFirstSheet(files map[string]*zip.File) (string, error) {
+	var (
+		workbook      xlsxWorkbook
+		relationships xlsxRelationships
+	)
+
+	if err := xlsxDecode(files[xlsxWorkbookPath], &workbook); err != nil {
+		return "", err
+	}
+
+	if len(workbook.Sheets) == 0 {
+		return "", errors.New("workbook has no sheet")
+	}
+
+	if err := xlsxDecode(files[xlsxRelationshipsPath], &relationships); err != nil {
+		return "", err
+	}
+
+	for _, relationship := range relationships.Relationships {
+		if relationship.ID != workbook.Sheets[0].RelationID {
+			continue
+		}
+
+		if strings.HasPrefix(relationship.Target, "/") {
+			return strings.TrimPrefix(relationship.Target, "/"), nil
+		}
+
+		return path.Join(path.Dir(xlsxWorkbookPath), relationship.Target), nil
+	}
+
+	return "", errors.New("first sheet of the workbook not found")
+}
+
+func xlsxDecode(f *zip.File, v any) error {
+	if f == nil {
+		return errors.New("not a workbook")
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+
+	var text strings.Builder
+	for _, run := range t.Runs {
+		text.WriteString(run.Text)
+	}
+
+	return text.String()
+}
+
+func (r *xlsxReader) Read() (cells []string, line int, err error) {
+	for {
+		token, err := r.decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, 0, io.EOF
+			}
+
+			return nil, 0, malformed(err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+
+		var row xlsxRow
+		if err := r.decoder.DecodeElement(&row, &start); err != nil {
+			return nil, 0, malformed(err)
+		}
+
+		// rows and cells may leave out their reference, following the previous one
+		r.line++
+		if row.Line > 0 {
+			r.line = row.Line
+		}
+
+		for _, cell := range row.Cells {
+			column := len(cells)
+			if ref := xlsxColumn(cell.Ref); ref >= 0 {
+				column = ref
+			}
+
+			value, err := r.value(cell)
+			if err != nil {
+				return nil, 0, malformed(fmt.Errorf("cell %s: %w", cell.Ref, err))
+			}
+
+			for len(cells) < column {
+				cells = append(cells, "")
+			}
+
+			if column < len(cells) {
+				cells[column] = value
+				continue
+			}
+
+			cells = append(cells, value)
+		}
+
+		return cells, r.line, nil
+	}
+}
+
+func (r *xlsxReader) value(cell xlsxCell) (string, error) {
+	switch cell.Type {
+	case "s":
+		i, err := strconv.Atoi(cell.Value)
+		if err != nil || i < 0 || i >= len(r.strings) {
+			return "", fmt.Errorf("unknown shared string %q", cell.Value)
+		}
+
+		return r.strings[i], nil
+	case "inlineStr":
+		return cell.Inline.String(), nil
+	default:
+		return cell.Value, nil
+	}
+}
+
+func (r *xlsxReader) Close() error {
+	return r.sheet.Close()
+}
+
+// spooledReader reads the rows of a temporary copy of a catalog file.
+type spooledReader struct {
+	RowReader
+	file *os.File
+}
+
+func newSpooledXLSXReader(r io.Reader) (RowReader, error) {
+	file, err := os.CreateTemp("", "product-import-*"+formatXLSX)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := io.Copy(file, r)
+	if err != nil {
+		removeTempFile(file)
+		return nil, err
+	}
+
+	rows, err := newXLSXReader(file, size)
+	if err != nil {
+		removeTempFile(file)
+		return nil, err
+	}
+
+	return &spooledReader{RowReader: rows, file: file}, nil
+}
+
+func (r *spooledReader) Close() error {
+	return errors.Join(r.RowReader.Close(), removeTempFile(r.file))
+}
+
+func removeTempFile(file *os.File) error {
+	return errors.Join(file.Close(), os.Remove(file.Name()))
+}
+
+// xlsxColumn returns the zero-based column of a cell reference such as "AB12", or -1
+// when the reference is missing.
+func xlsxColumn(ref string) int {
+	column := 0
+	for _, c := range strings.ToUpper(ref) {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+
+		column = column*26 + int(c-'A'+1)
+	}
+
+	return column - 1
+}
@@ -0,0 +1,176 @@
+package importer_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	importer "github.com/gunawanpras/be-product-service/internal/adapter/importer/product"
+	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type row struct {
+	cells []string
+	line  int
+}
+
+func readAll(t *testing.T, rows importer.RowReader) (res []row) {
+	t.Helper()
+
+	for {
+		cells, line, err := rows.Read()
+		if errors.Is(err, io.EOF) {
+			return res
+		}
+
+		if !assert.NoError(t, err) {
+			return res
+		}
+
+		res = append(res, row{cells, line})
+	}
+}
+
+func xlsxFile(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := archive.Create(name)
+		assert.NoError(t, err)
+
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, archive.Close())
+
+	return buf.Bytes()
+}
+
+func TestNewRowReader(t *testing.T) {
+	workbook := `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+	<sheets><sheet name="Products" sheetId="1" r:id="rId2"/><sheet name="Notes" sheetId="2" r:id="rId1"/></sheets>
+</workbook>`
+
+	relationships := `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+	<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+	<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>
+</Relationships>`
+
+	sharedStrings := `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+	<si><t>name</t></si>
+	<si><t>stock</t></si>
+	<si><r><t>Bayam </t></r><r><t>Hijau</t></r><rPh><t>ignored</t></rPh></si>
+</sst>`
+
+	sheet := `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+	<sheetData>
+		<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>
+		<row r="3"><c r="A3" t="s"><v>2</v></c><c r="C3"><v>50</v></c></row>
+		<row><c t="inlineStr"><is><t>Kangkung</t></is></c><c><v>4000.5</v></c></row>
+	</sheetData>
+</worksheet>`
+
+	tests := []struct {
+		name    string
+		file    string
+		content []byte
+		want    []row
+		wantErr error
+	}{
+		{
+			name:    "csv",
+			file:    "products.CSV",
+			content: []byte("name,stock\n\"Bayam, Hijau\", 50\n\nKangkung,20,extra\n"),
+			want: []row{
+				{[]string{"name", "stock"}, 1},
+				{[]string{"Bayam, Hijau", "50"}, 2},
+				{[]string{"Kangkung", "20", "extra"}, 4},
+			},
+		},
+		{
+			name: "xlsx reads the first sheet",
+			file: "products.xlsx",
+			content: xlsxFile(t, map[string]string{
+				"xl/workbook.xml":            workbook,
+				"xl/_rels/workbook.xml.rels": relationships,
+				"xl/sharedStrings.xml":       sharedStrings,
+				"xl/worksheets/sheet1.xml":   `<worksheet><sheetData><row r="1"><c><v>notes</v></c></row></sheetData></worksheet>`,
+				"xl/worksheets/sheet2.xml":   sheet,
+			}),
+			want: []row{
+				{[]string{"name", "", "stock"}, 1},
+				{[]string{"Bayam Hijau", "", "50"}, 3},
+				{[]string{"Kangkung", "4000.5"}, 4},
+			},
+		},
+		{
+			name:    "xlsx that is not a workbook",
+			file:    "products.xlsx",
+			content: xlsxFile(t, map[string]string{"readme.txt": "hello"}),
+			wantErr: domain.ErrProductImportMalformed,
+		},
+		{
+			name:    "unsupported format",
+			file:    "products.json",
+			content: []byte(`[]`),
+			wantErr: domain.ErrProductImportUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := importer.NewRowReader(tt.file, bytes.NewReader(tt.content), int64(len(tt.content)))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			defer rows.Close()
+
+			assert.Equal(t, tt.want, readAll(t, rows))
+		})
+
+		t.Run(tt.name+" as a stream", func(t *testing.T) {
+			tempDir := t.TempDir()
+			t.Setenv("TMPDIR", tempDir)
+
+			rows, err := importer.NewStreamRowReader(tt.file, bytes.NewReader(tt.content))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, readAll(t, rows))
+				assert.NoError(t, rows.Close())
+			}
+
+			tempFiles, err := os.ReadDir(tempDir)
+			assert.NoError(t, err)
+			assert.Empty(t, tempFiles)
+		})
+	}
+}
+
+func TestNewRowReader_MalformedCSV(t *testing.T) {
+	content := "name,stock\n\"Bayam,50\n"
+
+	rows, err := importer.NewRowReader("products.csv", strings.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+
+	_, _, err = rows.Read()
+	assert.NoError(t, err)
+
+	_, _, err = rows.Read()
+	assert.ErrorIs(t, err, domain.ErrProductImportMalformed)
+}
@@ -0,0 +1,43 @@
+package importer
+
+import (
+	"context"
+
+	"github.com/gunawanpras/be-product-service/config"
+	categoryPort "github.com/gunawanpras/be-product-service/internal/core/category/port"
+	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/internal/core/product/port"
+	supplierPort "github.com/gunawanpras/be-product-service/internal/core/supplier/port"
+	unitPort "github.com/gunawanpras/be-product-service/internal/core/unit/port"
+)
+
+type (
+	// Importer creates products from the rows of a catalog file.
+	Importer interface {
+		ImportProducts(ctx context.Context, rows RowReader, dryRun bool) (res domain.ProductImport, err error)
+	}
+
+	// RowReader reads the rows of a catalog file one at a time, along with their line
+	// number in the file. Read returns io.EOF once every row has been read.
+	RowReader interface {
+		Read() (cells []string, line int, err error)
+		Close() error
+	}
+
+	ServiceAttribute struct {
+		ProductService  port.Service
+		CategoryService categoryPort.Service
+		SupplierService supplierPort.Service
+		UnitService     unitPort.Service
+	}
+
+	ProductImporter struct {
+		service ServiceAttribute
+		config  *config.Config
+	}
+
+	InitAttribute struct {
+		Service ServiceAttribute
+		Config  *config.Config
+	}
+)
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/domain"
+)
+
+// Publish keeps the event, or returns the error set by FailWith without keeping it.
+func (p *EventPublisher) Publish(ctx context.Context, event domain.Event) (err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil {
+		return p.err
+	}
+
+	p.events = append(p.events, event)
+
+	return nil
+}
+
+// Events returns the events published so far, in the order they were published.
+func (p *EventPublisher) Events() domain.Events {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append(domain.Events(nil), p.events...)
+}
+
+// FailWith makes every following Publish return err, until it is called again with nil.
+func (p *EventPublisher) FailWith(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.err = err
+}
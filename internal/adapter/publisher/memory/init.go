@@ -0,0 +1,5 @@
+package memory
+
+func NewEventPublisher() *EventPublisher {
+	return &EventPublisher{}
+}
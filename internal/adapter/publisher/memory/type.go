@@ -0,0 +1,18 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/domain"
+)
+
+type (
+	// EventPublisher keeps the events it publishes in memory. It stands in for a broker
+	// in tests and local runs, and can be made to fail to exercise the retries of the
+	// relay.
+	EventPublisher struct {
+		mu     sync.Mutex
+		events domain.Events
+		err    error
+	}
+)
@@ -0,0 +1,27 @@
+package multi
+
+import (
+	"context"
+
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/domain"
+)
+
+// Publish publishes the event with each publisher in order, stopping at the first which
+// fails. The event is then published again by the relay, including to the publishers
+// which already accepted it, so every publisher must tolerate duplicate events.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - event: domain.Event to publish.
+//
+// Returns:
+// - err: error of the first publisher which failed.
+func (p *EventPublisher) Publish(ctx context.Context, event domain.Event) (err error) {
+	for _, publisher := range p.publishers {
+		if err = publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
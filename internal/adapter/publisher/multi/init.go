@@ -0,0 +1,17 @@
+package multi
+
+import (
+	"log"
+
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/port"
+)
+
+func NewEventPublisher(publishers ...port.EventPublisher) port.EventPublisher {
+	if len(publishers) == 0 {
+		log.Panic("missing event publishers")
+	}
+
+	return &EventPublisher{
+		publishers: publishers,
+	}
+}
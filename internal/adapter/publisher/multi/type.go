@@ -0,0 +1,12 @@
+package multi
+
+import (
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/port"
+)
+
+type (
+	// EventPublisher hands every event over to several publishers in turn.
+	EventPublisher struct {
+		publishers []port.EventPublisher
+	}
+)
@@ -0,0 +1,46 @@
+package nats
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Publish publishes an event to the JetStream stream on the subject of its type, e.g.
+// catalog.product.created, and waits for the stream to acknowledge it. The ID of the
+// event is its message ID, which lets the stream drop the duplicates of an event sent
+// again within its duplicate window.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - event: domain.Event to publish.
+//
+// Returns:
+// - err: error if the stream did not acknowledge the event.
+func (p *EventPublisher) Publish(ctx context.Context, event domain.Event) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, p.publishTimeout())
+	defer cancel()
+
+	msg := natsgo.NewMsg(p.config.Nats.Subject + "." + event.Type)
+	msg.Data = event.Payload
+	msg.Header.Set(constant.HeaderEventType, event.Type)
+	msg.Header.Set(constant.HeaderEventSequence, strconv.FormatInt(event.Sequence, 10))
+	msg.Header.Set(constant.HeaderProductID, event.ProductID.String())
+
+	_, err = p.client.JetStream.PublishMsg(ctx, msg, jetstream.WithMsgID(event.ID.String()))
+	return err
+}
+
+func (p *EventPublisher) publishTimeout() time.Duration {
+	timeout := p.config.Nats.PublishTimeoutInSecond
+	if timeout <= 0 {
+		timeout = constant.NatsPublishTimeout
+	}
+
+	return time.Duration(timeout) * time.Second
+}
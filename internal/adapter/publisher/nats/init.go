@@ -0,0 +1,35 @@
+package nats
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/port"
+)
+
+func NewEventPublisher(attr InitAttribute) port.EventPublisher {
+	if err := attr.validate(); err != nil {
+		log.Panic(err)
+	}
+
+	return &EventPublisher{
+		client: attr.Client,
+		config: attr.Config,
+	}
+}
+
+func (init InitAttribute) validate() error {
+	if !init.Client.validate() {
+		return fmt.Errorf("missing nats jetstream client : %+v", init.Client)
+	}
+
+	if init.Config == nil || init.Config.Nats.Subject == "" {
+		return fmt.Errorf("missing nats subject : %+v", init.Config)
+	}
+
+	return nil
+}
+
+func (client Client) validate() bool {
+	return client.JetStream != nil
+}
@@ -0,0 +1,22 @@
+package nats
+
+import (
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type (
+	Client struct {
+		JetStream jetstream.JetStream
+	}
+
+	EventPublisher struct {
+		client Client
+		config *config.Config
+	}
+
+	InitAttribute struct {
+		Client Client
+		Config *config.Config
+	}
+)
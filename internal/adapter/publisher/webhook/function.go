@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/domain"
+	webhookDomain "github.com/gunawanpras/be-product-service/internal/core/webhook/domain"
+)
+
+// Publish queues a delivery of the event for every webhook subscribed to its type. The
+// deliveries are keyed by the ID of the event, so publishing it again queues nothing new.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - event: domain.Event to publish.
+//
+// Returns:
+// - err: error if the deliveries could not be queued.
+func (p *EventPublisher) Publish(ctx context.Context, event domain.Event) (err error) {
+	return p.service.WebhookService.EnqueueEvent(ctx, webhookDomain.Event{
+		ID:        event.ID,
+		Type:      event.Type,
+		Payload:   event.Payload,
+		CreatedAt: event.CreatedAt,
+	})
+}
@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/port"
+)
+
+func NewEventPublisher(attr InitAttribute) port.EventPublisher {
+	if err := attr.validate(); err != nil {
+		log.Panic(err)
+	}
+
+	return &EventPublisher{
+		service: attr.Service,
+	}
+}
+
+func (attr InitAttribute) validate() error {
+	if !attr.Service.validate() {
+		return fmt.Errorf("missing webhook service : %+v", attr.Service.WebhookService)
+	}
+
+	return nil
+}
+
+func (service ServiceAttribute) validate() bool {
+	return service.WebhookService != nil
+}
@@ -0,0 +1,21 @@
+package webhook
+
+import (
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/port"
+)
+
+type (
+	ServiceAttribute struct {
+		WebhookService port.Service
+	}
+
+	// EventPublisher queues the events of the outbox for the webhooks subscribed to
+	// them, which the webhook dispatcher then delivers.
+	EventPublisher struct {
+		service ServiceAttribute
+	}
+
+	InitAttribute struct {
+		Service ServiceAttribute
+	}
+)
@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/category/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
+	"github.com/gunawanpras/be-product-service/pkg/util/uuidutil"
+)
+
+// CreateCategory creates a new category in the system and assigns a new ID to it.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - category: domain.Category containing the details of the category to be created.
+//
+// Returns:
+// - res: uuid.UUID representing the ID of the newly created category.
+// - err: error if an error occurs during the creation process.
+func (repo *CategoryRepository) CreateCategory(ctx context.Context, category domain.Category) (res uuid.UUID, err error) {
+	category.ID = uuidutil.UUIDHelper.New()
+
+	repo.prepareCreateCategory()
+	_, err = repo.statement.CreateCategory.ExecContext(ctx, category.ID, category.Name, category.Description, category.CreatedAt, category.CreatedBy)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return category.ID, nil
+}
+
+// GetListCategory retrieves a list of categories ordered by name, optionally filtered by
+// a partial, case insensitive match on the name.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - categoryName: The name of the category to filter by (can be a partial match).
+//
+// Returns:
+// - res: domain.Categories representing the list of categories that match the criteria.
+// - err: error if an error occurs during the retrieval process.
+func (repo *CategoryRepository) GetListCategory(ctx context.Context, categoryName string) (res domain.Categories, err error) {
+	var (
+		query      []string = []string{queryGetListCategory}
+		args       []any
+		category   Category
+		categories Categories
+	)
+
+	if categoryName != "" {
+		query = append(query, "AND LOWER(c.name) LIKE LOWER(?)")
+		args = append(args, "%"+categoryName+"%")
+	}
+
+	query = append(query, "ORDER BY c.name ASC")
+
+	finalQuery := strings.Join(query, " ")
+	finalQuery = repo.db.Db.Rebind(finalQuery)
+
+	rows, err := repo.db.Db.QueryxContext(ctx, finalQuery, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		category = Category{}
+		err = rows.StructScan(&category)
+		if err != nil {
+			return res, err
+		}
+
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return res, err
+	}
+
+	if !categories.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return categories.ToModel(), nil
+}
+
+// GetCategoryByID retrieves a category by ID from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - categoryID: The ID of the category to retrieve.
+//
+// Returns:
+// - res: domain.Category representing the category with the provided ID.
+// - err: error if an error occurs during the retrieval process.
+func (repo *CategoryRepository) GetCategoryByID(ctx context.Context, categoryID uuid.UUID) (res domain.Category, err error) {
+	var category Category
+
+	repo.prepareGetCategoryByID()
+	err = repo.statement.GetCategoryByID.QueryRowxContext(ctx, categoryID).StructScan(&category)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+
+	if !category.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return category.ToModel(), nil
+}
+
+// GetCategoryByName retrieves a category by its exact name from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - categoryName: The name of the category to retrieve.
+//
+// Returns:
+// - res: domain.Category representing the category with the provided name.
+// - err: error if an error occurs during the retrieval process.
+func (repo *CategoryRepository) GetCategoryByName(ctx context.Context, categoryName string) (res domain.Category, err error) {
+	var category Category
+
+	repo.prepareGetCategoryByName()
+	err = repo.statement.GetCategoryByName.QueryRowxContext(ctx, categoryName).StructScan(&category)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+
+	if !category.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return category.ToModel(), nil
+}
+
+// UpdateCategory overwrites the name and description of an existing category, including
+// its UpdatedAt and UpdatedBy audit columns.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - category: domain.Category containing the ID of the category and its new details.
+//
+// Returns:
+// - err: error if no category matches the ID or an error occurs during the update process.
+func (repo *CategoryRepository) UpdateCategory(ctx context.Context, category domain.Category) (err error) {
+	repo.prepareUpdateCategory()
+	result, err := repo.statement.UpdateCategory.ExecContext(ctx, category.ID, category.Name, category.Description, category.UpdatedAt, category.UpdatedBy)
+	if err != nil {
+		return err
+	}
+
+	return dbutil.CheckRowsAffected(result)
+}
+
+// DeleteCategory permanently removes a category.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - categoryID: The ID of the category to remove.
+//
+// Returns:
+// - err: error if the category is missing, still referenced or cannot be removed.
+func (repo *CategoryRepository) DeleteCategory(ctx context.Context, categoryID uuid.UUID) (err error) {
+	repo.prepareDeleteCategory()
+	result, err := repo.statement.DeleteCategory.ExecContext(ctx, categoryID)
+	if err != nil {
+		if dbutil.IsForeignKeyViolation(err) {
+			return dbutil.ErrDataStillReferenced
+		}
+
+		return err
+	}
+
+	return dbutil.CheckRowsAffected(result)
+}
+
+// CountProductByCategoryID counts the products, soft-deleted ones included, that
+// reference a category.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - categoryID: The ID of the category.
+//
+// Returns:
+// - res: int representing the number of products in the category.
+// - err: error if an error occurs during the retrieval process.
+func (repo *CategoryRepository) CountProductByCategoryID(ctx context.Context, categoryID uuid.UUID) (res int, err error) {
+	repo.prepareCountProductByCategoryID()
+	err = repo.statement.CountProductByCategoryID.QueryRowxContext(ctx, categoryID).Scan(&res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
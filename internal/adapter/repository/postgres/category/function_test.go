@@ -0,0 +1,383 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	postgres "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/category"
+	"github.com/gunawanpras/be-product-service/internal/core/category/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
+	"github.com/gunawanpras/be-product-service/pkg/util/uuidutil"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	expectedQueryAddCategory = `
+		INSERT INTO categories (
+			id, 
+			name, 
+			description, 
+			created_at, 
+			created_by
+		)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	expectedQueryUpdateCategory = `
+		UPDATE categories
+		SET
+			name = $2,
+			description = $3,
+			updated_at = $4,
+			updated_by = $5
+		WHERE id = $1
+	`
+
+	expectedQueryDeleteCategory = `
+		DELETE FROM categories
+		WHERE id = $1
+	`
+
+	expectedQueryCountProductByCategoryID = `
+		SELECT COUNT(1)
+		FROM products p
+		WHERE p.category_id = $1
+	`
+
+	expectedQueryGetCategoryByID = `
+		SELECT
+			c.id,
+			c.name,
+			c.description,
+			c.created_at,
+			c.created_by,
+			c.updated_at,
+			c.updated_by
+		FROM categories c
+		WHERE c.id = $1
+	`
+)
+
+var (
+	ctx                 context.Context = context.Background()
+	categoryID                          = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971429")
+	categoryName                        = "Sayuran"
+	categoryDescription                 = "Category description"
+	categoryCreatedAt                   = time.Now()
+	categoryCreatedBy                   = "SYSTEM"
+	categoryUpdatedAt                   = time.Now()
+	categoryUpdatedBy                   = "SYSTEM"
+	categoryColumns                     = []string{"id", "name", "description", "created_at", "created_by", "updated_at", "updated_by"}
+)
+
+func newMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sqlx.NewDb(db, "sqlmock"), mock
+}
+
+func TestCategoryRepository_CreateCategory(t *testing.T) {
+	uuidutil.UUIDHelper = mockUUIDHelper{id: categoryID}
+
+	category := domain.Category{
+		Name:        categoryName,
+		Description: &categoryDescription,
+		CreatedAt:   categoryCreatedAt,
+		CreatedBy:   categoryCreatedBy,
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes uuid.UUID
+		wantErr bool
+	}{
+		{
+			name: "error when create category",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryAddCategory)).
+					WithArgs(categoryID, categoryName, &categoryDescription, categoryCreatedAt, categoryCreatedBy).
+					WillReturnError(errors.New("error"))
+			},
+			wantRes: uuid.Nil,
+			wantErr: true,
+		},
+		{
+			name: "success create category",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryAddCategory)).
+					WithArgs(categoryID, categoryName, &categoryDescription, categoryCreatedAt, categoryCreatedBy).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantRes: categoryID,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryAddCategory))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.CreateCategory(ctx, category)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CategoryRepository.CreateCategory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("CategoryRepository.CreateCategory() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestCategoryRepository_GetCategoryByID(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.Category
+		wantErr error
+	}{
+		{
+			name: "error when category not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetCategoryByID)).
+					WithArgs(categoryID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantRes: domain.Category{},
+			wantErr: dbutil.ErrDataNotFound,
+		},
+		{
+			name: "error when there is malformed data",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetCategoryByID)).
+					WithArgs(categoryID).
+					WillReturnRows(sqlmock.NewRows(categoryColumns).
+						AddRow(categoryID, "", categoryDescription, categoryCreatedAt, categoryCreatedBy, nil, nil))
+			},
+			wantRes: domain.Category{},
+			wantErr: dbutil.ErrMalformedData,
+		},
+		{
+			name: "success get category by id",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetCategoryByID)).
+					WithArgs(categoryID).
+					WillReturnRows(sqlmock.NewRows(categoryColumns).
+						AddRow(categoryID, categoryName, categoryDescription, categoryCreatedAt, categoryCreatedBy, categoryUpdatedAt, categoryUpdatedBy))
+			},
+			wantRes: domain.Category{
+				ID:          categoryID,
+				Name:        categoryName,
+				Description: &categoryDescription,
+				CreatedAt:   categoryCreatedAt,
+				CreatedBy:   categoryCreatedBy,
+				UpdatedAt:   &categoryUpdatedAt,
+				UpdatedBy:   &categoryUpdatedBy,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryGetCategoryByID))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetCategoryByID(ctx, categoryID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CategoryRepository.GetCategoryByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("CategoryRepository.GetCategoryByID() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestCategoryRepository_UpdateCategory(t *testing.T) {
+	category := domain.Category{
+		ID:          categoryID,
+		Name:        categoryName,
+		Description: &categoryDescription,
+		UpdatedAt:   &categoryUpdatedAt,
+		UpdatedBy:   &categoryUpdatedBy,
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error when update category",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryUpdateCategory)).
+					WithArgs(categoryID, categoryName, &categoryDescription, &categoryUpdatedAt, &categoryUpdatedBy).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when category not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryUpdateCategory)).
+					WithArgs(categoryID, categoryName, &categoryDescription, &categoryUpdatedAt, &categoryUpdatedBy).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success update category",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryUpdateCategory)).
+					WithArgs(categoryID, categoryName, &categoryDescription, &categoryUpdatedAt, &categoryUpdatedBy).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryUpdateCategory))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.UpdateCategory(ctx, category)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CategoryRepository.UpdateCategory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCategoryRepository_DeleteCategory(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "error when category is still referenced",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteCategory)).
+					WithArgs(categoryID).
+					WillReturnError(&pq.Error{Code: "23503"})
+			},
+			wantErr: dbutil.ErrDataStillReferenced,
+		},
+		{
+			name: "error when category not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteCategory)).
+					WithArgs(categoryID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: dbutil.ErrDataNotFound,
+		},
+		{
+			name: "success delete category",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteCategory)).
+					WithArgs(categoryID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryDeleteCategory))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.DeleteCategory(ctx, categoryID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CategoryRepository.DeleteCategory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCategoryRepository_CountProductByCategoryID(t *testing.T) {
+	dbx, mock := newMockDB(t)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryCountProductByCategoryID))
+	mock.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProductByCategoryID)).
+		WithArgs(categoryID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	repo := postgres.New(postgres.InitAttribute{
+		DB: postgres.DB{
+			Db: dbx,
+		},
+	})
+
+	gotRes, err := repo.CountProductByCategoryID(ctx, categoryID)
+	if err != nil {
+		t.Errorf("CategoryRepository.CountProductByCategoryID() error = %v", err)
+		return
+	}
+
+	if gotRes != 2 {
+		t.Errorf("CategoryRepository.CountProductByCategoryID() gotRes = %v, want %v", gotRes, 2)
+	}
+}
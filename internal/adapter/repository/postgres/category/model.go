@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/category/domain"
+)
+
+type Category struct {
+	ID          uuid.UUID  `db:"id"`
+	Name        string     `db:"name"`
+	Description *string    `db:"description"`
+	CreatedAt   time.Time  `db:"created_at"`
+	CreatedBy   string     `db:"created_by"`
+	UpdatedAt   *time.Time `db:"updated_at"`
+	UpdatedBy   *string    `db:"updated_by"`
+}
+
+func (c Category) Validate() bool {
+	if c.ID == uuid.Nil {
+		return false
+	}
+
+	if c.Name == "" {
+		return false
+	}
+
+	if c.CreatedAt.IsZero() {
+		return false
+	}
+
+	if c.UpdatedAt != nil && c.UpdatedAt.IsZero() {
+		return false
+	}
+
+	return true
+}
+
+func (c Category) ToModel() domain.Category {
+	return domain.Category{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		CreatedAt:   c.CreatedAt,
+		CreatedBy:   c.CreatedBy,
+		UpdatedAt:   c.UpdatedAt,
+		UpdatedBy:   c.UpdatedBy,
+	}
+}
+
+type Categories []Category
+
+func (c Categories) Validate() bool {
+	for _, category := range c {
+		if !category.Validate() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c Categories) ToModel() domain.Categories {
+	var categories domain.Categories
+
+	for _, category := range c {
+		categories = append(categories, category.ToModel())
+	}
+
+	return categories
+}
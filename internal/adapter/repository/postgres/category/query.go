@@ -0,0 +1,59 @@
+package postgres
+
+var (
+	queryCreateCategory = `
+		INSERT INTO categories (
+			id, 
+			name, 
+			description, 
+			created_at, 
+			created_by
+		)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	queryUpdateCategory = `
+		UPDATE categories
+		SET
+			name = $2,
+			description = $3,
+			updated_at = $4,
+			updated_by = $5
+		WHERE id = $1
+	`
+
+	queryDeleteCategory = `
+		DELETE FROM categories
+		WHERE id = $1
+	`
+
+	queryCountProductByCategoryID = `
+		SELECT COUNT(1)
+		FROM products p
+		WHERE p.category_id = $1
+	`
+
+	queryListCategory = `
+		SELECT
+			c.id,
+			c.name,
+			c.description,
+			c.created_at,
+			c.created_by,
+			c.updated_at,
+			c.updated_by
+		FROM categories c
+	`
+
+	queryGetListCategory = queryListCategory + `
+		WHERE 1=1
+	`
+
+	queryGetCategoryByID = queryListCategory + `
+		WHERE c.id = $1
+	`
+
+	queryGetCategoryByName = queryListCategory + `
+		WHERE c.name = $1
+	`
+)
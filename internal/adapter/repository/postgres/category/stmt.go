@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func (repo *CategoryRepository) prepareStatements() {
+	repo.statement = StatementList{}
+}
+
+func (repo *CategoryRepository) prepareCreateCategory() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryCreateCategory); err != nil {
+		log.Panic("[prepareCreateCategory] error:", err)
+	}
+	repo.statement.CreateCategory = stmt
+}
+
+func (repo *CategoryRepository) prepareGetCategoryByID() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetCategoryByID); err != nil {
+		log.Panic("[prepareGetCategoryByID] error:", err)
+	}
+	repo.statement.GetCategoryByID = stmt
+}
+
+func (repo *CategoryRepository) prepareGetCategoryByName() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetCategoryByName); err != nil {
+		log.Panic("[prepareGetCategoryByName] error:", err)
+	}
+	repo.statement.GetCategoryByName = stmt
+}
+
+func (repo *CategoryRepository) prepareUpdateCategory() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryUpdateCategory); err != nil {
+		log.Panic("[prepareUpdateCategory] error:", err)
+	}
+	repo.statement.UpdateCategory = stmt
+}
+
+func (repo *CategoryRepository) prepareDeleteCategory() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryDeleteCategory); err != nil {
+		log.Panic("[prepareDeleteCategory] error:", err)
+	}
+	repo.statement.DeleteCategory = stmt
+}
+
+func (repo *CategoryRepository) prepareCountProductByCategoryID() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryCountProductByCategoryID); err != nil {
+		log.Panic("[prepareCountProductByCategoryID] error:", err)
+	}
+	repo.statement.CountProductByCategoryID = stmt
+}
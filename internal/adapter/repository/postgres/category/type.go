@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	CategoryRepository struct {
+		db        DB
+		statement StatementList
+	}
+
+	DB struct {
+		Db *sqlx.DB
+	}
+
+	StatementList struct {
+		CreateCategory           *sqlx.Stmt
+		GetCategoryByID          *sqlx.Stmt
+		GetCategoryByName        *sqlx.Stmt
+		UpdateCategory           *sqlx.Stmt
+		DeleteCategory           *sqlx.Stmt
+		CountProductByCategoryID *sqlx.Stmt
+	}
+
+	InitAttribute struct {
+		DB DB
+	}
+)
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
+)
+
+// WithRelayLock runs fn while holding a session-level advisory lock on a connection set
+// aside for it, so that a single instance of the service relays the outbox at a time.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - fn: The work to run under the lock.
+//
+// Returns:
+// - locked: false when another instance holds the lock, fn is not run then.
+// - err: error returned by fn or if the lock could not be taken.
+func (repo *OutboxRepository) WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (locked bool, err error) {
+	conn, err := repo.db.Db.Connx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	if err = conn.QueryRowxContext(ctx, queryTryRelayLock, constant.OutboxRelayLockKey).Scan(&locked); err != nil || !locked {
+		return false, err
+	}
+
+	defer func() {
+		if _, errUnlock := conn.ExecContext(context.WithoutCancel(ctx), queryReleaseRelayLock, constant.OutboxRelayLockKey); errUnlock != nil {
+			// discarding the connection ends its session, which releases the lock
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}()
+
+	return true, fn(ctx)
+}
+
+// GetListPendingEvent retrieves the events of the outbox which are not published yet, in
+// the order they were recorded. The events of a product whose failed event is not due
+// for its next attempt yet are left out.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - now: The time the next attempts of failed events are compared to.
+// - limit: The maximum number of events to retrieve.
+//
+// Returns:
+// - res: domain.Events representing the pending events.
+// - err: error if an error occurs during the retrieval process.
+func (repo *OutboxRepository) GetListPendingEvent(ctx context.Context, now time.Time, limit int) (res domain.Events, err error) {
+	var (
+		event  Event
+		events Events
+	)
+
+	repo.prepareGetListPendingEvent()
+	rows, err := repo.statement.GetListPendingEvent.QueryxContext(ctx, now, limit)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		event = Event{}
+		err = rows.StructScan(&event)
+		if err != nil {
+			return res, err
+		}
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return res, err
+	}
+
+	if !events.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return events.ToModel(), nil
+}
+
+// MarkEventPublished stamps the publication of an event, which is then no longer pending.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - eventID: The ID of the published event.
+// - publishedAt: The time the event was published.
+//
+// Returns:
+// - err: error if no event matches the ID or an error occurs during the update process.
+func (repo *OutboxRepository) MarkEventPublished(ctx context.Context, eventID uuid.UUID, publishedAt time.Time) (err error) {
+	repo.prepareMarkEventPublished()
+	result, err := repo.statement.MarkEventPublished.ExecContext(ctx, eventID, publishedAt)
+	if err != nil {
+		return err
+	}
+
+	return dbutil.CheckRowsAffected(result)
+}
+
+// MarkEventFailed records a failed attempt to publish an event, along with the time of
+// its next attempt and the error of the failed one.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - eventID: The ID of the event which failed to publish.
+// - nextAttemptAt: The time from which the event is attempted again.
+// - lastError: The error of the failed attempt.
+//
+// Returns:
+// - err: error if no event matches the ID or an error occurs during the update process.
+func (repo *OutboxRepository) MarkEventFailed(ctx context.Context, eventID uuid.UUID, nextAttemptAt time.Time, lastError string) (err error) {
+	repo.prepareMarkEventFailed()
+	result, err := repo.statement.MarkEventFailed.ExecContext(ctx, eventID, nextAttemptAt, lastError)
+	if err != nil {
+		return err
+	}
+
+	return dbutil.CheckRowsAffected(result)
+}
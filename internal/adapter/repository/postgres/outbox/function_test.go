@@ -0,0 +1,393 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	postgres "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/outbox"
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	expectedQueryTryRelayLock = `
+		SELECT pg_try_advisory_lock($1)
+	`
+
+	expectedQueryReleaseRelayLock = `
+		SELECT pg_advisory_unlock($1)
+	`
+
+	expectedQueryGetListPendingEvent = `
+		SELECT
+			e.id,
+			e.event_id,
+			e.product_id,
+			e.event_type,
+			e.payload,
+			e.created_at,
+			e.attempts
+		FROM product_events e
+		WHERE
+			e.published_at IS NULL AND
+			NOT EXISTS (
+				SELECT 1
+				FROM product_events w
+				WHERE
+					w.product_id = e.product_id AND
+					w.published_at IS NULL AND
+					w.next_attempt_at > $1
+			)
+		ORDER BY e.id ASC
+		LIMIT $2
+	`
+
+	expectedQueryMarkEventPublished = `
+		UPDATE product_events
+		SET
+			published_at = $2,
+			attempts = attempts + 1,
+			last_error = NULL
+		WHERE event_id = $1
+	`
+
+	expectedQueryMarkEventFailed = `
+		UPDATE product_events
+		SET
+			attempts = attempts + 1,
+			next_attempt_at = $2,
+			last_error = $3
+		WHERE event_id = $1
+	`
+)
+
+var (
+	ctx            context.Context = context.Background()
+	eventID                        = uuid.MustParse("7b0f6a52-3c1e-4d8a-9f4b-2e6c8d1a5b01")
+	productID                      = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971427")
+	eventPayload                   = []byte(`{"id":"e5ec5a4e-509a-4260-9d16-845032971427","version":1}`)
+	eventCreatedAt                 = time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	now                            = time.Date(2025, 3, 1, 8, 5, 0, 0, time.UTC)
+)
+
+func newRepository(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sqlx.NewDb(db, "sqlmock"), mock
+}
+
+func TestOutboxRepository_WithRelayLock(t *testing.T) {
+	tests := []struct {
+		name       string
+		mockFn     func(mockdb sqlmock.Sqlmock)
+		fnErr      error
+		wantLocked bool
+		wantRun    bool
+		wantErr    bool
+	}{
+		{
+			name: "error when take lock",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryTryRelayLock)).
+					WithArgs(constant.OutboxRelayLockKey).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "lock held by another instance",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryTryRelayLock)).
+					WithArgs(constant.OutboxRelayLockKey).
+					WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(false))
+			},
+			wantLocked: false,
+			wantRun:    false,
+		},
+		{
+			name: "error returned by fn releases the lock",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryTryRelayLock)).
+					WithArgs(constant.OutboxRelayLockKey).
+					WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryReleaseRelayLock)).
+					WithArgs(constant.OutboxRelayLockKey).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			fnErr:      errors.New("error"),
+			wantLocked: true,
+			wantRun:    true,
+			wantErr:    true,
+		},
+		{
+			name: "success run under the lock",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryTryRelayLock)).
+					WithArgs(constant.OutboxRelayLockKey).
+					WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryReleaseRelayLock)).
+					WithArgs(constant.OutboxRelayLockKey).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantLocked: true,
+			wantRun:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newRepository(t)
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			var run bool
+			gotLocked, err := repo.WithRelayLock(ctx, func(ctx context.Context) error {
+				run = true
+				return tt.fnErr
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OutboxRepository.WithRelayLock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotLocked != tt.wantLocked {
+				t.Errorf("OutboxRepository.WithRelayLock() gotLocked = %v, want %v", gotLocked, tt.wantLocked)
+			}
+
+			if run != tt.wantRun {
+				t.Errorf("OutboxRepository.WithRelayLock() run = %v, want %v", run, tt.wantRun)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestOutboxRepository_GetListPendingEvent(t *testing.T) {
+	columns := []string{"id", "event_id", "product_id", "event_type", "payload", "created_at", "attempts"}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.Events
+		wantErr bool
+	}{
+		{
+			name: "error when get pending events",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListPendingEvent)).
+					WithArgs(now, 100).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when there is malformed data",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListPendingEvent)).
+					WithArgs(now, 100).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, eventID, productID, "", eventPayload, eventCreatedAt, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success get pending events",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListPendingEvent)).
+					WithArgs(now, 100).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, eventID, productID, constant.ProductEventCreated, eventPayload, eventCreatedAt, 0).
+						AddRow(2, productID, productID, constant.ProductEventUpdated, eventPayload, eventCreatedAt, 2))
+			},
+			wantRes: domain.Events{
+				{
+					ID:        eventID,
+					Sequence:  1,
+					ProductID: productID,
+					Type:      constant.ProductEventCreated,
+					Payload:   eventPayload,
+					CreatedAt: eventCreatedAt,
+				},
+				{
+					ID:        productID,
+					Sequence:  2,
+					ProductID: productID,
+					Type:      constant.ProductEventUpdated,
+					Payload:   eventPayload,
+					CreatedAt: eventCreatedAt,
+					Attempts:  2,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newRepository(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryGetListPendingEvent))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetListPendingEvent(ctx, now, 100)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OutboxRepository.GetListPendingEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("OutboxRepository.GetListPendingEvent() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestOutboxRepository_MarkEventPublished(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error when mark event published",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryMarkEventPublished)).
+					WithArgs(eventID, now).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when event not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryMarkEventPublished)).
+					WithArgs(eventID, now).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success mark event published",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryMarkEventPublished)).
+					WithArgs(eventID, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newRepository(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryMarkEventPublished))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.MarkEventPublished(ctx, eventID, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OutboxRepository.MarkEventPublished() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutboxRepository_MarkEventFailed(t *testing.T) {
+	nextAttemptAt := now.Add(time.Second)
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error when mark event failed",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryMarkEventFailed)).
+					WithArgs(eventID, nextAttemptAt, "nats: timeout").
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when event not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryMarkEventFailed)).
+					WithArgs(eventID, nextAttemptAt, "nats: timeout").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success mark event failed",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryMarkEventFailed)).
+					WithArgs(eventID, nextAttemptAt, "nats: timeout").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newRepository(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryMarkEventFailed))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.MarkEventFailed(ctx, eventID, nextAttemptAt, "nats: timeout")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OutboxRepository.MarkEventFailed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
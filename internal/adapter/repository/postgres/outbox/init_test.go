@@ -0,0 +1,18 @@
+package postgres_test
+
+import (
+	"testing"
+
+	postgres "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/outbox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	assert.Panics(t, func() {
+		postgres.New(postgres.InitAttribute{
+			DB: postgres.DB{
+				Db: nil,
+			},
+		})
+	})
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/domain"
+)
+
+type (
+	Event struct {
+		ID        int64     `db:"id"`
+		EventID   uuid.UUID `db:"event_id"`
+		ProductID uuid.UUID `db:"product_id"`
+		EventType string    `db:"event_type"`
+		Payload   []byte    `db:"payload"`
+		CreatedAt time.Time `db:"created_at"`
+		Attempts  int       `db:"attempts"`
+	}
+
+	Events []Event
+)
+
+func (e Event) Validate() bool {
+	if e.ID < 1 {
+		return false
+	}
+
+	if e.EventID == uuid.Nil {
+		return false
+	}
+
+	if e.ProductID == uuid.Nil {
+		return false
+	}
+
+	if e.EventType == "" {
+		return false
+	}
+
+	return len(e.Payload) > 0
+}
+
+func (e Event) ToModel() domain.Event {
+	return domain.Event{
+		ID:        e.EventID,
+		Sequence:  e.ID,
+		ProductID: e.ProductID,
+		Type:      e.EventType,
+		Payload:   e.Payload,
+		CreatedAt: e.CreatedAt,
+		Attempts:  e.Attempts,
+	}
+}
+
+func (e Events) Validate() bool {
+	for _, event := range e {
+		if !event.Validate() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e Events) ToModel() domain.Events {
+	var events domain.Events
+
+	for _, event := range e {
+		events = append(events, event.ToModel())
+	}
+
+	return events
+}
@@ -0,0 +1,55 @@
+package postgres
+
+var (
+	queryTryRelayLock = `
+		SELECT pg_try_advisory_lock($1)
+	`
+
+	queryReleaseRelayLock = `
+		SELECT pg_advisory_unlock($1)
+	`
+
+	// queryGetListPendingEvent leaves out every event of a product whose failed event
+	// still waits for its next attempt, so that the events of a product stay in order.
+	queryGetListPendingEvent = `
+		SELECT
+			e.id,
+			e.event_id,
+			e.product_id,
+			e.event_type,
+			e.payload,
+			e.created_at,
+			e.attempts
+		FROM product_events e
+		WHERE
+			e.published_at IS NULL AND
+			NOT EXISTS (
+				SELECT 1
+				FROM product_events w
+				WHERE
+					w.product_id = e.product_id AND
+					w.published_at IS NULL AND
+					w.next_attempt_at > $1
+			)
+		ORDER BY e.id ASC
+		LIMIT $2
+	`
+
+	queryMarkEventPublished = `
+		UPDATE product_events
+		SET
+			published_at = $2,
+			attempts = attempts + 1,
+			last_error = NULL
+		WHERE event_id = $1
+	`
+
+	queryMarkEventFailed = `
+		UPDATE product_events
+		SET
+			attempts = attempts + 1,
+			next_attempt_at = $2,
+			last_error = $3
+		WHERE event_id = $1
+	`
+)
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func (repo *OutboxRepository) prepareStatements() {
+	repo.statement = StatementList{}
+}
+
+func (repo *OutboxRepository) prepareGetListPendingEvent() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetListPendingEvent); err != nil {
+		log.Panic("[prepareGetListPendingEvent] error:", err)
+	}
+	repo.statement.GetListPendingEvent = stmt
+}
+
+func (repo *OutboxRepository) prepareMarkEventPublished() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryMarkEventPublished); err != nil {
+		log.Panic("[prepareMarkEventPublished] error:", err)
+	}
+	repo.statement.MarkEventPublished = stmt
+}
+
+func (repo *OutboxRepository) prepareMarkEventFailed() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryMarkEventFailed); err != nil {
+		log.Panic("[prepareMarkEventFailed] error:", err)
+	}
+	repo.statement.MarkEventFailed = stmt
+}
@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	OutboxRepository struct {
+		db        DB
+		statement StatementList
+	}
+
+	DB struct {
+		Db *sqlx.DB
+	}
+
+	StatementList struct {
+		GetListPendingEvent *sqlx.Stmt
+		MarkEventPublished  *sqlx.Stmt
+		MarkEventFailed     *sqlx.Stmt
+	}
+
+	InitAttribute struct {
+		DB DB
+	}
+)
@@ -3,17 +3,25 @@ package postgres
 import (
 	"context"
 	"database/sql"
-	"errors"
+	"encoding/json"
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/internal/core/product/port"
 	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
 	"github.com/gunawanpras/be-product-service/pkg/util/pageutil"
 	"github.com/gunawanpras/be-product-service/pkg/util/uuidutil"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
-// CreateProduct creates a new product in the system. It assigns a new ID to the product and uses the ExecContext method of the sqlx.NamedStmt to execute the query.
+// CreateProduct creates a new product in the system. It assigns a new ID to the product and
+// records the product.created event of the product in the same transaction, along with
+// the movement opening its stock in the stock ledger.
 //
 // Parameters:
 // - ctx: Context for controlling the lifetime of the request.
@@ -25,66 +33,414 @@ import (
 func (repo *ProductRepository) CreateProduct(ctx context.Context, product domain.Product) (res uuid.UUID, err error) {
 	product.ID = uuidutil.UUIDHelper.New()
 
-	repo.prepareCreateProduct()
-	_, err = repo.statement.CreateProduct.ExecContext(ctx, product.ID, product.CategoryID, product.SupplierID, product.UnitID, product.Name, product.Description, product.BasePrice, product.Stock, product.CreatedAt, product.CreatedBy)
+	tx, err := repo.db.Db.BeginTxx(ctx, nil)
 	if err != nil {
+		return uuid.Nil, fmt.Errorf(constant.DbBeginTransactionFailed, err)
+	}
+
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = fmt.Errorf(constant.DbRollbackTransactionFailed, errRollback)
+			}
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, queryCreateProduct, product.ID, product.CategoryID, product.SupplierID, product.UnitID, product.Name, product.Description, product.BasePrice, product.Stock, product.CreatedAt, product.CreatedBy, product.Version)
+	if err != nil {
+		return uuid.Nil, mapProductWriteError(err)
+	}
+
+	if err = createProductEvents(ctx, tx, constant.ProductEventCreated, product); err != nil {
 		return uuid.Nil, err
 	}
 
+	if product.Stock != 0 {
+		if _, err = createStockMovements(ctx, tx, toOpeningStockMovement(product)); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return uuid.Nil, fmt.Errorf(constant.DbCommitTransactionFailed, err)
+	}
+
 	return product.ID, nil
 }
 
-// GetListProduct retrieves a list of products filtered by product name, category type,
-// and sorted by a specified field and direction.
+// productInsertBatchSize is the number of products inserted by a single statement, which
+// keeps the placeholders of the statement well below the PostgreSQL limit.
+const productInsertBatchSize = 500
+
+// CreateProducts creates several products at once, with multi-row inserts inside a single
+// transaction so that either every product is created or none. Each product is assigned
+// a new ID, and the product.created events of the products are recorded in the same
+// transaction, along with the movements opening their stock.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - products: domain.Products containing the details of the products to be created.
+//
+// Returns:
+// - res: []uuid.UUID representing the IDs of the new products, in the order of products.
+// - err: error if an error occurs during the creation process.
+func (repo *ProductRepository) CreateProducts(ctx context.Context, products domain.Products) (res []uuid.UUID, err error) {
+	tx, err := repo.db.Db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(constant.DbBeginTransactionFailed, err)
+	}
+
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = fmt.Errorf(constant.DbRollbackTransactionFailed, errRollback)
+			}
+		}
+	}()
+
+	for chunk := range slices.Chunk(products, productInsertBatchSize) {
+		var (
+			rows      []string
+			args      []any
+			created   domain.Products
+			movements domain.StockMovements
+		)
+
+		for _, product := range chunk {
+			product.ID = uuidutil.UUIDHelper.New()
+			res = append(res, product.ID)
+			created = append(created, product)
+
+			if product.Stock != 0 {
+				movements = append(movements, toOpeningStockMovement(product))
+			}
+
+			rows = append(rows, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+			args = append(args, product.ID, product.CategoryID, product.SupplierID, product.UnitID, product.Name, product.Description, product.BasePrice, product.Stock, product.CreatedAt, product.CreatedBy, product.Version)
+		}
+
+		finalQuery := queryCreateProducts + strings.Join(rows, ", ")
+		finalQuery = tx.Rebind(finalQuery)
+
+		if _, err = tx.ExecContext(ctx, finalQuery, args...); err != nil {
+			return nil, mapProductWriteError(err)
+		}
+
+		if err = createProductEvents(ctx, tx, constant.ProductEventCreated, created...); err != nil {
+			return nil, err
+		}
+
+		if len(movements) > 0 {
+			if _, err = createStockMovements(ctx, tx, movements...); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf(constant.DbCommitTransactionFailed, err)
+	}
+
+	return res, nil
+}
+
+// GetListProduct retrieves a page of the products matching a filter, sorted by one or
+// more fields. Pages are read with keyset pagination: the rows following the cursor of
+// the page are selected on the sort keys and the product ID, which is also used to break
+// ties so that the order is stable. Numbered pages are read with LIMIT and OFFSET instead.
 //
 // Parameters:
 // - ctx: Context for controlling the lifetime of the request.
-// - productName: The name of the product to filter by (can be a partial match).
-// - categoryType: The category type to filter the products.
-// - sort: The field to sort the products by.
-// - direction: The direction to sort the products (asc or desc).
+// - filter: The criteria the products must match.
+// - sort: The keys to sort the products by, created_at asc when empty.
+// - page: The size of the page, and either its number or the cursor to continue from.
 //
 // Returns:
-// - res: domain.Products representing the list of products that match the criteria.
+// - res: domain.ProductPage holding the products of the page and the cursor of the next one.
 // - err: error if an error occurs during the retrieval process.
-func (repo *ProductRepository) GetListProduct(ctx context.Context, productName, categoryType, sort, direction string) (res domain.Products, err error) {
+func (repo *ProductRepository) GetListProduct(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort, page domain.ListPage) (res domain.ProductPage, err error) {
 	var (
-		query    []string = []string{queryGetListProduct}
-		args     []any
 		product  Product
 		products Products
 	)
 
-	// filter by category type
-	if categoryType != "" {
-		query = append(query, "AND c.name = ?")
-		args = append(args, categoryType)
+	query, args, err := listProductQuery(filter, &sort)
+	if err != nil {
+		return res, err
 	}
 
-	// search by product name (partial match, case insensitive)
-	if productName != "" {
-		query = append(query, "AND LOWER(p.name) LIKE LOWER(?)")
-		args = append(args, "%"+productName+"%")
+	// continue after the last product of the previous page
+	if page.After != nil && page.Number == 0 {
+		if !page.After.Matches(sort) {
+			return res, domain.ErrProductInvalidCursor
+		}
+
+		condition, conditionArgs := keysetCondition(sort.Keys, *page.After)
+		query = append(query, "AND "+condition)
+		args = append(args, conditionArgs...)
+	}
+
+	query = append(query, "ORDER BY "+orderByClause(sort.Keys))
+
+	switch {
+	case page.Limit > 0 && page.Number > 0:
+		query = append(query, "LIMIT ? OFFSET ?")
+		args = append(args, page.Limit, page.Offset())
+	case page.Limit > 0:
+		// fetch one more row than asked to know whether there is a next page
+		query = append(query, "LIMIT ?")
+		args = append(args, page.Limit+1)
+	}
+
+	finalQuery := strings.Join(query, " ")
+	finalQuery = repo.db.Db.Rebind(finalQuery)
+
+	rows, err := repo.db.Db.QueryxContext(ctx, finalQuery, args...)
+	if err != nil {
+		return res, err
 	}
+	defer rows.Close()
 
-	// sort and direction
-	if sort != "" {
-		if err = pageutil.ValidateSortDirection(constant.ValidProductSort, sort, direction); err != nil {
+	for rows.Next() {
+		product = Product{}
+		err = rows.StructScan(&product)
+		if err != nil {
 			return res, err
 		}
 
-		query = append(query, "ORDER BY p."+sort+" "+direction)
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return res, err
+	}
+
+	if !products.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	res.Products = products.ToModel()
+	if page.Limit > 0 && page.Number == 0 && len(res.Products) > page.Limit {
+		res.Products = res.Products[:page.Limit]
+
+		next := domain.NewProductCursor(res.Products[page.Limit-1], sort)
+		res.Next = &next
+	}
+
+	return res, nil
+}
+
+// CountProduct counts the products matching the filters of GetListProduct, so that the
+// total always agrees with the pages of the list.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - filter: The criteria the products must match.
+//
+// Returns:
+// - total: int representing the number of matching products.
+// - err: error if an error occurs during the counting process.
+func (repo *ProductRepository) CountProduct(ctx context.Context, filter domain.ProductFilter) (total int, err error) {
+	conditions, args := listProductFilter(filter)
+	query := append([]string{queryCountProduct}, conditions...)
+
+	finalQuery := strings.Join(query, " ")
+	finalQuery = repo.db.Db.Rebind(finalQuery)
+
+	err = repo.db.Db.GetContext(ctx, &total, finalQuery, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// ExportProduct opens a cursor over every product matching the filters of GetListProduct,
+// sorted like the list but without pages. The products are scanned one at a time as the
+// cursor moves, so that the whole catalog is never held in memory. The cursor must be
+// closed once read.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the cursor.
+// - filter: The criteria the products must match.
+// - sort: The keys to sort the products by, created_at asc when empty.
+//
+// Returns:
+// - res: port.ProductRows reading the matching products.
+// - err: error if an error occurs when running the query.
+func (repo *ProductRepository) ExportProduct(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort) (res port.ProductRows, err error) {
+	query, args, err := listProductQuery(filter, &sort)
+	if err != nil {
+		return nil, err
 	}
 
+	query = append(query, "ORDER BY "+orderByClause(sort.Keys))
+
 	finalQuery := strings.Join(query, " ")
 	finalQuery = repo.db.Db.Rebind(finalQuery)
 
 	rows, err := repo.db.Db.QueryxContext(ctx, finalQuery, args...)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return res, errors.New(constant.DataNotFound)
-		}
+		return nil, err
+	}
+
+	return &productRows{rows: rows}, nil
+}
+
+func (r *productRows) Next() bool {
+	return r.rows.Next()
+}
+
+func (r *productRows) Product() (res domain.Product, err error) {
+	var product Product
+	if err = r.rows.StructScan(&product); err != nil {
+		return res, err
+	}
+
+	if !product.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return product.ToModel(), nil
+}
+
+func (r *productRows) Err() error {
+	return r.rows.Err()
+}
+
+func (r *productRows) Close() error {
+	return r.rows.Close()
+}
+
+// GetProductFacets counts the products matching a filter by category, supplier, unit and
+// price bucket, using the filters of GetListProduct. Each facet leaves out the criteria of
+// its own dimension, e.g. categories are counted as if no category was selected.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - filter: The criteria the products must match.
+// - priceBounds: The lower bounds of the price buckets, in ascending order.
+//
+// Returns:
+// - res: domain.ProductFacets holding the counts of every facet.
+// - err: error if an error occurs during the counting process.
+func (repo *ProductRepository) GetProductFacets(ctx context.Context, filter domain.ProductFilter, priceBounds []float64) (res domain.ProductFacets, err error) {
+	var (
+		categories   FacetCounts
+		suppliers    FacetCounts
+		units        FacetCounts
+		priceBuckets PriceBucketCounts
+	)
+
+	categoryFilter := filter
+	categoryFilter.CategoryType = ""
+	categoryFilter.CategoryIDs = nil
+
+	err = repo.selectFacet(ctx, &categories, queryCountProductByCategory, nil, categoryFilter, "GROUP BY c.id, c.name ORDER BY count DESC, c.name ASC")
+	if err != nil {
+		return res, err
+	}
+
+	supplierFilter := filter
+	supplierFilter.SupplierIDs = nil
+
+	err = repo.selectFacet(ctx, &suppliers, queryCountProductBySupplier, nil, supplierFilter, "GROUP BY sp.id, sp.name ORDER BY count DESC, sp.name ASC")
+	if err != nil {
+		return res, err
+	}
+
+	unitFilter := filter
+	unitFilter.UnitIDs = nil
+
+	err = repo.selectFacet(ctx, &units, queryCountProductByUnit, nil, unitFilter, "GROUP BY u.id, u.unit_name ORDER BY count DESC, u.unit_name ASC")
+	if err != nil {
+		return res, err
+	}
+
+	priceFilter := filter
+	priceFilter.MinPrice = nil
+	priceFilter.MaxPrice = nil
+
+	err = repo.selectFacet(ctx, &priceBuckets, queryCountProductByPriceBucket, []any{pq.Array(priceBounds)}, priceFilter, "GROUP BY bucket ORDER BY bucket ASC")
+	if err != nil {
+		return res, err
+	}
+
+	return domain.ProductFacets{
+		Categories:   categories.ToModel(),
+		Suppliers:    suppliers.ToModel(),
+		Units:        units.ToModel(),
+		PriceBuckets: priceBuckets.ToModel(priceBounds),
+	}, nil
+}
+
+// selectFacet runs a facet query: query with its own args, followed by the conditions of
+// filter and the grouping clause.
+func (repo *ProductRepository) selectFacet(ctx context.Context, dest any, query string, args []any, filter domain.ProductFilter, groupBy string) error {
+	conditions, conditionArgs := listProductFilter(filter)
+	args = append(args, conditionArgs...)
+
+	finalQuery := strings.Join(append(append([]string{query}, conditions...), groupBy), " ")
+	finalQuery = repo.db.Db.Rebind(finalQuery)
+
+	return repo.db.Db.SelectContext(ctx, dest, finalQuery, args...)
+}
+
+// SuggestProduct retrieves the names of the products starting with or similar to a prefix,
+// to complete what a user is typing. Names starting with the prefix come first, the others
+// by decreasing trigram similarity to the prefix.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - prefix: The beginning of the product name typed so far.
+// - filter: The criteria the products must match, such as their categories.
+// - limit: The maximum number of suggestions.
+//
+// Returns:
+// - res: domain.ProductSuggestions holding the ID and name of the suggested products.
+// - err: error if an error occurs during the retrieval process.
+func (repo *ProductRepository) SuggestProduct(ctx context.Context, prefix string, filter domain.ProductFilter, limit int) (res domain.ProductSuggestions, err error) {
+	var suggestions ProductSuggestions
+
+	args := []any{prefix + "%", prefix}
+
+	conditions, conditionArgs := listProductFilter(filter)
+	query := append([]string{querySuggestProduct}, conditions...)
+	args = append(args, conditionArgs...)
+
+	query = append(query, "ORDER BY p.name ILIKE ? DESC, word_similarity(?, p.name) DESC, p.name ASC, p.id ASC LIMIT ?")
+	args = append(args, prefix+"%", prefix, limit)
+
+	finalQuery := strings.Join(query, " ")
+	finalQuery = repo.db.Db.Rebind(finalQuery)
+
+	err = repo.db.Db.SelectContext(ctx, &suggestions, finalQuery, args...)
+	if err != nil {
+		return res, err
+	}
+
+	return suggestions.ToModel(), nil
+}
 
+// GetListProductByIDs retrieves the products with the given IDs in a single query, in no
+// particular order. Soft-deleted products and unknown IDs are left out.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - productIDs: The IDs of the products to retrieve.
+//
+// Returns:
+// - res: domain.Products representing the products found.
+// - err: error if an error occurs during the retrieval process.
+func (repo *ProductRepository) GetListProductByIDs(ctx context.Context, productIDs []uuid.UUID) (res domain.Products, err error) {
+	var (
+		product  Product
+		products Products
+	)
+
+	repo.prepareGetListProductByIDs()
+	rows, err := repo.statement.GetListProductByIDs.QueryxContext(ctx, uuidArray(productIDs))
+	if err != nil {
 		return res, err
 	}
 	defer rows.Close()
@@ -99,59 +455,941 @@ func (repo *ProductRepository) GetListProduct(ctx context.Context, productName,
 		products = append(products, product)
 	}
 
+	if err = rows.Err(); err != nil {
+		return res, err
+	}
+
 	if !products.Validate() {
-		return res, errors.New(constant.DbReturnedMalformedData)
+		return res, dbutil.ErrMalformedData
 	}
 
 	return products.ToModel(), nil
 }
 
-// GetProductByID retrieves a product by ID from the database.
+// GetListProductByNames retrieves, in a single query, the products sharing both their
+// category and their name with any of the given products, to find out which names are
+// already taken. Soft-deleted products are left out.
 //
 // Parameters:
 // - ctx: Context for controlling the lifetime of the request.
-// - productID: The ID of the product to retrieve.
+// - products: domain.Products containing the category ID and name to look for.
 //
 // Returns:
-// - res: domain.Product representing the product with the provided ID.
+// - res: domain.Products representing the existing products.
 // - err: error if an error occurs during the retrieval process.
-func (repo *ProductRepository) GetProductByID(ctx context.Context, productID uuid.UUID) (res domain.Product, err error) {
-	var product Product
+func (repo *ProductRepository) GetListProductByNames(ctx context.Context, products domain.Products) (res domain.Products, err error) {
+	var (
+		product     Product
+		existing    Products
+		categoryIDs []uuid.UUID
+		names       []string
+	)
 
-	repo.prepareGetProductByID()
-	err = repo.statement.GetProductByID.QueryRowxContext(ctx, productID).StructScan(&product)
+	for _, p := range products {
+		categoryIDs = append(categoryIDs, p.CategoryID)
+		names = append(names, p.Name)
+	}
+
+	repo.prepareGetListProductByNames()
+	rows, err := repo.statement.GetListProductByNames.QueryxContext(ctx, uuidArray(categoryIDs), pq.Array(names))
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return res, errors.New(constant.DataNotFound)
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		product = Product{}
+		err = rows.StructScan(&product)
+		if err != nil {
+			return res, err
 		}
 
+		existing = append(existing, product)
+	}
+
+	if err = rows.Err(); err != nil {
 		return res, err
 	}
 
-	if !product.Validate() {
-		return res, errors.New(constant.DbReturnedMalformedData)
+	if !existing.Validate() {
+		return res, dbutil.ErrMalformedData
 	}
 
-	return product.ToModel(), nil
+	return existing.ToModel(), nil
 }
 
-// Get product by name
-func (repo *ProductRepository) GetProductByName(ctx context.Context, categoryID uuid.UUID, productName string) (res domain.Product, err error) {
-	var product Product
+// GetProductReferences finds out which of the categories, suppliers and units referenced
+// by the given products exist, with a single query per table.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - products: domain.Products containing the category, supplier and unit IDs to look for.
+//
+// Returns:
+// - res: domain.ProductReferences holding the IDs that exist.
+// - err: error if an error occurs during the retrieval process.
+func (repo *ProductRepository) GetProductReferences(ctx context.Context, products domain.Products) (res domain.ProductReferences, err error) {
+	var categoryIDs, supplierIDs, unitIDs []uuid.UUID
+	for _, product := range products {
+		categoryIDs = append(categoryIDs, product.CategoryID)
+		supplierIDs = append(supplierIDs, product.SupplierID)
+		unitIDs = append(unitIDs, product.UnitID)
+	}
 
-	repo.prepareGetProductByName()
-	err = repo.statement.GetProductByName.QueryRowxContext(ctx, categoryID, productName).StructScan(&product)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return res, errors.New(constant.DataNotFound)
-		}
+	if res.CategoryIDs, err = repo.getExistingIDs(ctx, queryGetExistingCategoryIDs, categoryIDs); err != nil {
+		return res, err
+	}
 
+	if res.SupplierIDs, err = repo.getExistingIDs(ctx, queryGetExistingSupplierIDs, supplierIDs); err != nil {
 		return res, err
 	}
 
-	if !product.Validate() {
-		return res, errors.New(constant.DbReturnedMalformedData)
+	if res.UnitIDs, err = repo.getExistingIDs(ctx, queryGetExistingUnitIDs, unitIDs); err != nil {
+		return res, err
 	}
 
-	return product.ToModel(), nil
+	return res, nil
+}
+
+// getExistingIDs runs one of the queries keeping the IDs that exist in a table.
+func (repo *ProductRepository) getExistingIDs(ctx context.Context, query string, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	var existing []uuid.UUID
+	if err := repo.db.Db.SelectContext(ctx, &existing, query, uuidArray(ids)); err != nil {
+		return nil, err
+	}
+
+	res := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		res[id] = true
+	}
+
+	return res, nil
+}
+
+// GetListProductBySupplierID retrieves the products of a supplier ordered by name.
+// Soft-deleted products are left out.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplierID: The ID of the supplier.
+//
+// Returns:
+// - res: domain.Products representing the products of the supplier.
+// - err: error if an error occurs during the retrieval process.
+func (repo *ProductRepository) GetListProductBySupplierID(ctx context.Context, supplierID uuid.UUID) (res domain.Products, err error) {
+	var (
+		product  Product
+		products Products
+	)
+
+	repo.prepareGetListProductBySupplierID()
+	rows, err := repo.statement.GetListProductBySupplierID.QueryxContext(ctx, supplierID)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		product = Product{}
+		err = rows.StructScan(&product)
+		if err != nil {
+			return res, err
+		}
+
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return res, err
+	}
+
+	if !products.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return products.ToModel(), nil
+}
+
+// GetProductByID retrieves a product by ID from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - productID: The ID of the product to retrieve.
+//
+// Returns:
+// - res: domain.Product representing the product with the provided ID.
+// - err: error if an error occurs during the retrieval process.
+func (repo *ProductRepository) GetProductByID(ctx context.Context, productID uuid.UUID) (res domain.Product, err error) {
+	var product Product
+
+	repo.prepareGetProductByID()
+	err = repo.statement.GetProductByID.QueryRowxContext(ctx, productID).StructScan(&product)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+
+	if !product.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return product.ToModel(), nil
+}
+
+// GetDeletedProductByID retrieves a soft-deleted product by ID from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - productID: The ID of the product to retrieve.
+//
+// Returns:
+// - res: domain.Product representing the soft-deleted product with the provided ID.
+// - err: error if no soft-deleted product matches the ID or an error occurs during the retrieval process.
+func (repo *ProductRepository) GetDeletedProductByID(ctx context.Context, productID uuid.UUID) (res domain.Product, err error) {
+	var product Product
+
+	repo.prepareGetDeletedProductByID()
+	err = repo.statement.GetDeletedProductByID.QueryRowxContext(ctx, productID).StructScan(&product)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+
+	if !product.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return product.ToModel(), nil
+}
+
+// Get product by name
+func (repo *ProductRepository) GetProductByName(ctx context.Context, categoryID uuid.UUID, productName string) (res domain.Product, err error) {
+	var product Product
+
+	repo.prepareGetProductByName()
+	err = repo.statement.GetProductByName.QueryRowxContext(ctx, categoryID, productName).StructScan(&product)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+
+	if !product.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return product.ToModel(), nil
+}
+
+// UpdateProduct overwrites the mutable columns of an existing product, including its
+// UpdatedAt and UpdatedBy audit columns, and bumps its version. The update only applies
+// when the stored version still equals product.Version, and records the product.updated
+// event of the product. An update changing the stock of the product also records the
+// change as an adjustment in the stock ledger, along with its stock.changed event.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - product: domain.Product containing the ID and expected version of the product and its new details.
+//
+// Returns:
+// - err: error if no product matches the ID and version or an error occurs during the update process.
+func (repo *ProductRepository) UpdateProduct(ctx context.Context, product domain.Product) (err error) {
+	var previousStock int
+
+	tx, err := repo.db.Db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(constant.DbBeginTransactionFailed, err)
+	}
+
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = fmt.Errorf(constant.DbRollbackTransactionFailed, errRollback)
+			}
+		}
+	}()
+
+	// Lock the product first so that the stock read is the one the update replaces.
+	if err = tx.GetContext(ctx, &previousStock, queryGetProductStockForUpdate, product.ID); err != nil {
+		if err == sql.ErrNoRows {
+			return dbutil.ErrDataNotFound
+		}
+
+		return err
+	}
+
+	updated, err := writeProductChange(ctx, tx, constant.ProductEventUpdated, queryUpdateProduct, product.ID, product.CategoryID, product.SupplierID, product.UnitID, product.Name, product.Description, product.BasePrice, product.Stock, product.UpdatedAt, product.UpdatedBy, product.Version)
+	if err != nil {
+		return mapProductWriteError(err)
+	}
+
+	if updated.Stock != previousStock {
+		if _, err = recordStockMovement(ctx, tx, toUpdateStockMovement(previousStock, updated), updated); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf(constant.DbCommitTransactionFailed, err)
+	}
+
+	return nil
+}
+
+// DeleteProduct soft deletes a product by stamping its DeletedAt and DeletedBy columns.
+// Soft-deleted products are hidden from every read unless explicitly requested. The
+// deletion only applies when the stored version still equals product.Version, and records
+// the product.deleted event of the product.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - product: domain.Product containing the ID and expected version of the product and its deletion audit fields.
+//
+// Returns:
+// - err: error if no active product matches the ID and version or an error occurs during the deletion process.
+func (repo *ProductRepository) DeleteProduct(ctx context.Context, product domain.Product) (err error) {
+	return repo.changeProduct(ctx, constant.ProductEventDeleted, queryDeleteProduct, product.ID, product.DeletedAt, product.DeletedBy, product.Version)
+}
+
+// RestoreProduct brings a soft-deleted product back by clearing its deletion audit
+// columns and stamping its UpdatedAt and UpdatedBy columns, and records the
+// product.restored event of the product. The restore only applies when the stored version
+// still equals product.Version. Restoring a product whose name was taken in its category
+// in the meantime is reported as ErrDataAlreadyExist.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - product: domain.Product containing the ID and expected version of the product and its update audit fields.
+//
+// Returns:
+// - err: error if no soft-deleted product matches the ID and version or an error occurs during the restore process.
+func (repo *ProductRepository) RestoreProduct(ctx context.Context, product domain.Product) (err error) {
+	err = repo.changeProduct(ctx, constant.ProductEventRestored, queryRestoreProduct, product.ID, product.UpdatedAt, product.UpdatedBy, product.Version)
+	if dbutil.IsUniqueViolation(err) {
+		return dbutil.ErrDataAlreadyExist
+	}
+
+	return err
+}
+
+// PurgeProduct permanently removes a product, whether soft-deleted or not, together with
+// its discount inside a single transaction, which also records the product.purged event
+// of the product as it stood before its removal. The removal only applies when the stored
+// version still equals product.Version.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - product: domain.Product containing the ID and expected version of the product to remove.
+//
+// Returns:
+// - err: error if no product matches the ID and version or an error occurs during the removal process.
+func (repo *ProductRepository) PurgeProduct(ctx context.Context, product domain.Product) (err error) {
+	tx, err := repo.db.Db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(constant.DbBeginTransactionFailed, err)
+	}
+
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = fmt.Errorf(constant.DbRollbackTransactionFailed, errRollback)
+			}
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, queryDeleteProductDiscount, product.ID); err != nil {
+		return err
+	}
+
+	if _, err = writeProductChange(ctx, tx, constant.ProductEventPurged, queryPurgeProduct, product.ID, product.Version); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf(constant.DbCommitTransactionFailed, err)
+	}
+
+	return nil
+}
+
+// changeProduct runs a write of a single product inside a transaction, which also records
+// the event of the change.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - eventType: The type of the event of the change.
+// - query: The write, which must return the product it changed.
+// - args: The arguments of the write.
+//
+// Returns:
+// - err: error if the write did not change any product or an error occurs during the process.
+func (repo *ProductRepository) changeProduct(ctx context.Context, eventType string, query string, args ...any) (err error) {
+	tx, err := repo.db.Db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(constant.DbBeginTransactionFailed, err)
+	}
+
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = fmt.Errorf(constant.DbRollbackTransactionFailed, errRollback)
+			}
+		}
+	}()
+
+	if _, err = writeProductChange(ctx, tx, eventType, query, args...); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf(constant.DbCommitTransactionFailed, err)
+	}
+
+	return nil
+}
+
+// writeProductChange runs a write of a single product which returns the product, then
+// records the event of the change with the returned product.
+func writeProductChange(ctx context.Context, tx *sqlx.Tx, eventType string, query string, args ...any) (res domain.Product, err error) {
+	var product Product
+
+	if err = tx.QueryRowxContext(ctx, query, args...).StructScan(&product); err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+
+	res = product.ToModel()
+	return res, createProductEvents(ctx, tx, eventType, res)
+}
+
+// createProductEvents records the events of product changes in the outbox. It runs in the
+// transaction of the changes, so that an event exists if and only if its change is
+// committed.
+func createProductEvents(ctx context.Context, tx *sqlx.Tx, eventType string, products ...domain.Product) error {
+	events := make([]outboxEvent, 0, len(products))
+	for _, product := range products {
+		events = append(events, outboxEvent{
+			productID: product.ID,
+			eventType: eventType,
+			payload:   toProductEvent(product),
+		})
+	}
+
+	return createOutboxEvents(ctx, tx, events...)
+}
+
+// createOutboxEvents records events in the outbox in a single statement, assigning a new
+// ID to each.
+func createOutboxEvents(ctx context.Context, tx *sqlx.Tx, events ...outboxEvent) error {
+	var (
+		rows []string
+		args []any
+	)
+
+	for _, event := range events {
+		payload, err := json.Marshal(event.payload)
+		if err != nil {
+			return err
+		}
+
+		rows = append(rows, "(?, ?, ?, ?)")
+		args = append(args, uuidutil.UUIDHelper.New(), event.productID, event.eventType, string(payload))
+	}
+
+	finalQuery := queryCreateProductEvents + strings.Join(rows, ", ")
+	finalQuery = tx.Rebind(finalQuery)
+
+	_, err := tx.ExecContext(ctx, finalQuery, args...)
+	return err
+}
+
+// CreateStockMovement records a movement in the stock ledger of a product and applies it
+// to the stock of the product in the same transaction, bumping its version. The
+// product.updated and stock.changed events of the movement are recorded along with it.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - movement: domain.StockMovement containing the product ID, the signed quantity and the details of the movement.
+//
+// Returns:
+// - res: domain.StockMovement representing the recorded movement, with its ID and the stock after it.
+// - err: error if no active product matches the ID, the stock would go below zero, the
+// reference was already recorded or an error occurs during the process.
+func (repo *ProductRepository) CreateStockMovement(ctx context.Context, movement domain.StockMovement) (res domain.StockMovement, err error) {
+	tx, err := repo.db.Db.BeginTxx(ctx, nil)
+	if err != nil {
+		return res, fmt.Errorf(constant.DbBeginTransactionFailed, err)
+	}
+
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = fmt.Errorf(constant.DbRollbackTransactionFailed, errRollback)
+			}
+		}
+	}()
+
+	product, err := writeProductChange(ctx, tx, constant.ProductEventUpdated, queryMoveProductStock, movement.ProductID, movement.Quantity, movement.CreatedAt, movement.Actor)
+	if err != nil {
+		// the stock is checked to never go below zero
+		if dbutil.IsCheckViolation(err) {
+			return res, dbutil.ErrDataOutOfRange
+		}
+
+		return res, err
+	}
+
+	movement, err = recordStockMovement(ctx, tx, movement, product)
+	if err != nil {
+		return res, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return res, fmt.Errorf(constant.DbCommitTransactionFailed, err)
+	}
+
+	return movement, nil
+}
+
+// GetListStockMovement retrieves a page of the stock ledger of a product, newest movement
+// first, along with the number of movements matching the filter.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - productID: The ID of the product.
+// - filter: domain.StockMovementFilter selecting the type of the movements.
+// - page: domain.ListPage selecting the numbered page of movements.
+//
+// Returns:
+// - res: domain.StockHistoryPage representing the page of movements and their total.
+// - err: error if an error occurs during the retrieval process.
+func (repo *ProductRepository) GetListStockMovement(ctx context.Context, productID uuid.UUID, filter domain.StockMovementFilter, page domain.ListPage) (res domain.StockHistoryPage, err error) {
+	var (
+		conditions []string
+		args       = []any{productID}
+		movement   StockMovement
+		movements  StockMovements
+	)
+
+	if filter.Type != "" {
+		conditions = append(conditions, "AND movement_type = ?")
+		args = append(args, filter.Type)
+	}
+
+	countQuery := strings.Join(append([]string{queryCountStockMovement}, conditions...), " ")
+	countQuery = repo.db.Db.Rebind(countQuery)
+
+	if err = repo.db.Db.GetContext(ctx, &res.Total, countQuery, args...); err != nil {
+		return res, err
+	}
+
+	query := append([]string{queryGetListStockMovement}, conditions...)
+	query = append(query, "ORDER BY created_at DESC, id DESC", "LIMIT ? OFFSET ?")
+	args = append(args, page.Limit, page.Offset())
+
+	finalQuery := strings.Join(query, " ")
+	finalQuery = repo.db.Db.Rebind(finalQuery)
+
+	rows, err := repo.db.Db.QueryxContext(ctx, finalQuery, args...)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		movement = StockMovement{}
+		err = rows.StructScan(&movement)
+		if err != nil {
+			return res, err
+		}
+
+		movements = append(movements, movement)
+	}
+
+	if err = rows.Err(); err != nil {
+		return res, err
+	}
+
+	if !movements.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	res.Movements = movements.ToModel()
+
+	return res, nil
+}
+
+// recordStockMovement records a movement in the stock ledger along with its stock.changed
+// event, product being the product as it stands after the movement.
+func recordStockMovement(ctx context.Context, tx *sqlx.Tx, movement domain.StockMovement, product domain.Product) (res domain.StockMovement, err error) {
+	movement.StockAfter = product.Stock
+
+	movements, err := createStockMovements(ctx, tx, movement)
+	if err != nil {
+		return res, err
+	}
+
+	if err = createOutboxEvents(ctx, tx, toStockChangedEvent(movements[0], product)); err != nil {
+		return res, err
+	}
+
+	return movements[0], nil
+}
+
+// createStockMovements records movements in the stock ledger in a single statement,
+// assigning a new ID to each. A movement reusing the reference of another movement of
+// the same product and type is reported as ErrDataAlreadyExist.
+func createStockMovements(ctx context.Context, tx *sqlx.Tx, movements ...domain.StockMovement) (res domain.StockMovements, err error) {
+	var (
+		rows []string
+		args []any
+	)
+
+	for _, movement := range movements {
+		movement.ID = uuidutil.UUIDHelper.New()
+		res = append(res, movement)
+
+		rows = append(rows, "(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args, movement.ID, movement.ProductID, movement.Type, movement.Quantity, movement.StockAfter, movement.Reason, movement.ReferenceID, movement.Actor, movement.CreatedAt)
+	}
+
+	finalQuery := queryCreateStockMovements + strings.Join(rows, ", ")
+	finalQuery = tx.Rebind(finalQuery)
+
+	if _, err = tx.ExecContext(ctx, finalQuery, args...); err != nil {
+		if dbutil.IsUniqueViolation(err) {
+			return nil, dbutil.ErrDataAlreadyExist
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CreateProductDiscount attaches a discount window to a product.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - discount: domain.ProductDiscount containing the product ID and the discount details.
+//
+// Returns:
+// - err: error if the product already has a discount or an error occurs during the creation process.
+func (repo *ProductRepository) CreateProductDiscount(ctx context.Context, discount domain.ProductDiscount) (err error) {
+	repo.prepareCreateProductDiscount()
+	_, err = repo.statement.CreateProductDiscount.ExecContext(ctx, discount.ProductID, discount.DiscountPercent, discount.StartDate, discount.EndDate, discount.MaxPurchaseQty, discount.CreatedAt, discount.CreatedBy)
+	if err != nil {
+		if dbutil.IsUniqueViolation(err) {
+			return dbutil.ErrDataAlreadyExist
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// UpdateProductDiscount overwrites the discount window of a product, including its
+// UpdatedAt and UpdatedBy audit columns.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - discount: domain.ProductDiscount containing the product ID and the new discount details.
+//
+// Returns:
+// - err: error if the product has no discount or an error occurs during the update process.
+func (repo *ProductRepository) UpdateProductDiscount(ctx context.Context, discount domain.ProductDiscount) (err error) {
+	repo.prepareUpdateProductDiscount()
+	result, err := repo.statement.UpdateProductDiscount.ExecContext(ctx, discount.ProductID, discount.DiscountPercent, discount.StartDate, discount.EndDate, discount.MaxPurchaseQty, discount.UpdatedAt, discount.UpdatedBy)
+	if err != nil {
+		return err
+	}
+
+	return dbutil.CheckRowsAffected(result)
+}
+
+// DeleteProductDiscount removes the discount window of a product.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - productID: The ID of the product.
+//
+// Returns:
+// - err: error if the product has no discount or an error occurs during the removal process.
+func (repo *ProductRepository) DeleteProductDiscount(ctx context.Context, productID uuid.UUID) (err error) {
+	repo.prepareDeleteProductDiscount()
+	result, err := repo.statement.DeleteProductDiscount.ExecContext(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	return dbutil.CheckRowsAffected(result)
+}
+
+// listProductQuery selects the products matching a filter, with the rank and highlights
+// of the products when searching. The sort keys default to created_at asc and are
+// validated, for the caller to order the products by.
+func listProductQuery(filter domain.ProductFilter, sort *domain.ProductSort) (query []string, args []any, err error) {
+	query = []string{queryGetListProduct}
+	switch {
+	case filter.Query != "" && filter.Fuzzy:
+		query = []string{queryFuzzySearchProduct}
+		args = append(args, filter.Query)
+	case filter.Query != "":
+		query = []string{querySearchProduct}
+		args = append(args, filter.Query)
+	}
+
+	conditions, conditionArgs := listProductFilter(filter)
+	query = append(query, conditions...)
+	args = append(args, conditionArgs...)
+
+	// sort keys, products equal on all of them are ordered by id
+	if len(sort.Keys) == 0 {
+		sort.Keys = pageutil.ParseSort("", "", "", constant.ProductSortCreatedAt)
+	}
+
+	if err = pageutil.ValidateSort(constant.ValidProductSort, sort.Keys); err != nil {
+		return nil, nil, err
+	}
+
+	if filter.Query == "" && slices.ContainsFunc(sort.Keys, isRelevanceKey) {
+		return nil, nil, domain.ErrProductSortRequiresQuery
+	}
+
+	return query, args, nil
+}
+
+// listProductFilter builds the conditions shared by the product list and its count,
+// to be appended to a query ending with a WHERE clause.
+func listProductFilter(filter domain.ProductFilter) (query []string, args []any) {
+	// exclude soft-deleted products unless asked otherwise
+	if !filter.IncludeDeleted {
+		query = append(query, "AND p.deleted_at IS NULL")
+	}
+
+	// filter by category type
+	if filter.CategoryType != "" {
+		query = append(query, "AND c.name = ?")
+		args = append(args, filter.CategoryType)
+	}
+
+	// full-text search on the name and description, or similarity of the name
+	switch {
+	case filter.Query != "" && filter.Fuzzy:
+		query = append(query, "AND ? <% p.name")
+		args = append(args, filter.Query)
+	case filter.Query != "":
+		query = append(query, "AND p.search_vector @@ websearch_to_tsquery('simple', ?)")
+		args = append(args, filter.Query)
+	}
+
+	// search by product name (partial match, case insensitive)
+	if filter.ProductName != "" {
+		query = append(query, "AND LOWER(p.name) LIKE LOWER(?)")
+		args = append(args, "%"+filter.ProductName+"%")
+	}
+
+	// filter by any of the given categories, suppliers and units
+	if len(filter.CategoryIDs) > 0 {
+		query = append(query, "AND p.category_id = ANY(?::uuid[])")
+		args = append(args, uuidArray(filter.CategoryIDs))
+	}
+
+	if len(filter.SupplierIDs) > 0 {
+		query = append(query, "AND p.supplier_id = ANY(?::uuid[])")
+		args = append(args, uuidArray(filter.SupplierIDs))
+	}
+
+	if len(filter.UnitIDs) > 0 {
+		query = append(query, "AND p.unit_id = ANY(?::uuid[])")
+		args = append(args, uuidArray(filter.UnitIDs))
+	}
+
+	// price and stock ranges, both bounds included
+	if filter.MinPrice != nil {
+		query = append(query, "AND p.base_price >= ?")
+		args = append(args, *filter.MinPrice)
+	}
+
+	if filter.MaxPrice != nil {
+		query = append(query, "AND p.base_price <= ?")
+		args = append(args, *filter.MaxPrice)
+	}
+
+	if filter.MinStock != nil {
+		query = append(query, "AND p.stock >= ?")
+		args = append(args, *filter.MinStock)
+	}
+
+	if filter.MaxStock != nil {
+		query = append(query, "AND p.stock <= ?")
+		args = append(args, *filter.MaxStock)
+	}
+
+	if filter.InStock {
+		query = append(query, "AND p.stock > 0")
+	}
+
+	// creation window, both bounds included
+	if filter.CreatedFrom != nil {
+		query = append(query, "AND p.created_at >= ?")
+		args = append(args, *filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		query = append(query, "AND p.created_at <= ?")
+		args = append(args, *filter.CreatedTo)
+	}
+
+	return query, args
+}
+
+// orderByClause writes the sort keys as an ORDER BY list, with the product id as the last
+// key. The keys must be validated already, as their fields are written into the query.
+func orderByClause(keys []pageutil.SortKey) string {
+	var clause []string
+
+	for _, key := range keys {
+		column := sortColumn(key.Field) + " " + key.Direction
+		switch key.Nulls {
+		case constant.SortNullsFirst:
+			column += " NULLS FIRST"
+		case constant.SortNullsLast:
+			column += " NULLS LAST"
+		}
+
+		clause = append(clause, column)
+	}
+
+	clause = append(clause, "p.id "+keys[len(keys)-1].Direction)
+
+	return strings.Join(clause, ", ")
+}
+
+// keysetCondition builds the condition matching the products sorted after the cursor. When
+// every key goes the same way and cannot be NULL, a single row comparison is enough.
+// Otherwise a product comes after the cursor when it is equal on the first keys and
+// after it on the next one, NULL values being placed as set by each key.
+func keysetCondition(keys []pageutil.SortKey, after domain.ProductCursor) (condition string, args []any) {
+	idKey := pageutil.SortKey{Field: "id", Direction: keys[len(keys)-1].Direction}
+
+	uniform := true
+	for _, key := range keys {
+		if key.Direction != idKey.Direction || pageutil.ItemExists(constant.NullableProductSort, key.Field) {
+			uniform = false
+		}
+	}
+
+	if uniform {
+		var columns, placeholders []string
+		for i, key := range keys {
+			columns = append(columns, sortColumn(key.Field))
+			placeholders = append(placeholders, "?")
+			args = append(args, *after.Values[i])
+		}
+
+		return "(" + strings.Join(columns, ", ") + ", p.id) " + keysetOperator(idKey) + " (" + strings.Join(placeholders, ", ") + ", ?)", append(args, after.ID)
+	}
+
+	var (
+		terms     []string
+		equal     []string
+		equalArgs []any
+	)
+
+	for i, key := range keys {
+		column := sortColumn(key.Field)
+		value := after.Values[i]
+		nullable := pageutil.ItemExists(constant.NullableProductSort, key.Field)
+
+		// products after the cursor on this key
+		var next string
+		switch {
+		case value == nil && !key.NullsLast():
+			next = column + " IS NOT NULL"
+		case value != nil && nullable && key.NullsLast():
+			next = "(" + column + " " + keysetOperator(key) + " ? OR " + column + " IS NULL)"
+		case value != nil:
+			next = column + " " + keysetOperator(key) + " ?"
+		}
+
+		if next != "" {
+			terms = append(terms, "("+strings.Join(append(slices.Clone(equal), next), " AND ")+")")
+			args = append(args, equalArgs...)
+			if value != nil {
+				args = append(args, *value)
+			}
+		}
+
+		// products equal to the cursor on this key
+		if value == nil {
+			equal = append(equal, column+" IS NULL")
+		} else {
+			equal = append(equal, column+" = ?")
+			equalArgs = append(equalArgs, *value)
+		}
+	}
+
+	terms = append(terms, "("+strings.Join(append(equal, "p.id "+keysetOperator(idKey)+" ?"), " AND ")+")")
+	args = append(append(args, equalArgs...), after.ID)
+
+	return "(" + strings.Join(terms, " OR ") + ")", args
+}
+
+// sortColumn returns the expression a sort field is read from. Relevance is the negated
+// rank of the search, so that the best matches come first in ascending order.
+func sortColumn(field string) string {
+	if field == constant.ProductSortRelevance {
+		return "-r.rank"
+	}
+
+	return "p." + field
+}
+
+func isRelevanceKey(key pageutil.SortKey) bool {
+	return key.Field == constant.ProductSortRelevance
+}
+
+// keysetOperator returns the operator matching the values sorted after another by key.
+func keysetOperator(key pageutil.SortKey) string {
+	if key.Direction == constant.SortDirectionDesc {
+		return "<"
+	}
+
+	return ">"
+}
+
+// uuidArray converts ids into a Postgres array parameter.
+func uuidArray(ids []uuid.UUID) any {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+
+	return pq.Array(values)
+}
+
+// mapProductWriteError reports a name taken in the category as ErrDataAlreadyExist and
+// a category, supplier or unit that does not exist as ErrDataReferenceNotFound.
+func mapProductWriteError(err error) error {
+	switch {
+	case dbutil.IsUniqueViolation(err):
+		return dbutil.ErrDataAlreadyExist
+	case dbutil.IsForeignKeyViolation(err):
+		return dbutil.ErrDataReferenceNotFound
+	}
+
+	return err
 }
@@ -3,6 +3,8 @@ package postgres_test
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"reflect"
 	"regexp"
@@ -13,8 +15,12 @@ import (
 	"github.com/google/uuid"
 	postgres "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/product"
 	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
+	"github.com/gunawanpras/be-product-service/pkg/util/pageutil"
 	"github.com/gunawanpras/be-product-service/pkg/util/uuidutil"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 var (
@@ -29,9 +35,195 @@ var (
 			base_price, 
 			stock, 
 			created_at, 
+			created_by,
+			version
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	expectedQueryCreateProducts = `
+		INSERT INTO products (
+			id, 
+			category_id, 
+			supplier_id, 
+			unit_id, 
+			name, 
+			description, 
+			base_price, 
+			stock, 
+			created_at, 
+			created_by,
+			version
+		)
+		VALUES
+	`
+
+	expectedQueryCreateProductEvents = `
+		INSERT INTO product_events (
+			event_id, 
+			product_id, 
+			event_type, 
+			payload
+		)
+		VALUES
+	`
+
+	expectedQueryReturningProduct = `
+		RETURNING
+			id,
+			category_id,
+			supplier_id,
+			unit_id,
+			name,
+			description,
+			base_price,
+			stock,
+			created_at,
+			created_by,
+			updated_at,
+			updated_by,
+			deleted_at,
+			deleted_by,
+			version
+	`
+
+	expectedQueryGetProductStockForUpdate = `
+		SELECT stock
+		FROM products
+		WHERE 
+			id = $1 AND 
+			deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	expectedQueryMoveProductStock = `
+		UPDATE products
+		SET
+			stock = stock + $2,
+			updated_at = $3,
+			updated_by = $4,
+			version = version + 1
+		WHERE 
+			id = $1 AND 
+			deleted_at IS NULL
+	` + expectedQueryReturningProduct
+
+	expectedQueryCreateStockMovements = `
+		INSERT INTO stock_movements (
+			id, 
+			product_id, 
+			movement_type, 
+			quantity, 
+			stock_after, 
+			reason, 
+			reference_id, 
+			actor, 
+			created_at
+		)
+		VALUES
+	`
+
+	expectedQueryCountStockMovement = `
+		SELECT COUNT(*)
+		FROM stock_movements
+		WHERE product_id = ?
+	`
+
+	expectedQueryGetListStockMovement = `
+		SELECT
+			id,
+			product_id,
+			movement_type,
+			quantity,
+			stock_after,
+			reason,
+			reference_id,
+			actor,
+			created_at
+		FROM stock_movements
+		WHERE product_id = ?
+	`
+
+	expectedQueryUpdateProduct = `
+		UPDATE products
+		SET
+			category_id = $2,
+			supplier_id = $3,
+			unit_id = $4,
+			name = $5,
+			description = $6,
+			base_price = $7,
+			stock = $8,
+			updated_at = $9,
+			updated_by = $10,
+			version = version + 1
+		WHERE 
+			id = $1 AND 
+			version = $11 AND 
+			deleted_at IS NULL
+	` + expectedQueryReturningProduct
+
+	expectedQueryDeleteProduct = `
+		UPDATE products
+		SET
+			deleted_at = $2,
+			deleted_by = $3,
+			version = version + 1
+		WHERE 
+			id = $1 AND 
+			version = $4 AND 
+			deleted_at IS NULL
+	` + expectedQueryReturningProduct
+
+	expectedQueryRestoreProduct = `
+		UPDATE products
+		SET
+			deleted_at = NULL,
+			deleted_by = NULL,
+			updated_at = $2,
+			updated_by = $3,
+			version = version + 1
+		WHERE 
+			id = $1 AND 
+			version = $4 AND 
+			deleted_at IS NOT NULL
+	` + expectedQueryReturningProduct
+
+	expectedQueryDeleteProductDiscount = `
+		DELETE FROM product_discounts
+		WHERE product_id = $1
+	`
+
+	expectedQueryPurgeProduct = `
+		DELETE FROM products
+		WHERE 
+			id = $1 AND 
+			version = $2
+	` + expectedQueryReturningProduct
+
+	expectedQueryCreateProductDiscount = `
+		INSERT INTO product_discounts (
+			product_id, 
+			discount_percent, 
+			discount_start_date, 
+			discount_end_date, 
+			max_purchase_qty, 
+			created_at, 
 			created_by
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	expectedQueryUpdateProductDiscount = `
+		UPDATE product_discounts
+		SET
+			discount_percent = $2,
+			discount_start_date = $3,
+			discount_end_date = $4,
+			max_purchase_qty = $5,
+			updated_at = $6,
+			updated_by = $7
+		WHERE product_id = $1
 	`
 
 	expectedQueryGetProduct = `
@@ -47,46 +239,237 @@ var (
 			p.created_at,
 			p.created_by,
 			p.updated_at,
-			p.updated_by
+			p.updated_by,
+			p.deleted_at,
+			p.deleted_by,
+			p.version,
+			d.discount_percent,
+			d.discount_start_date,
+			d.discount_end_date,
+			d.max_purchase_qty,
+			c.name AS category_name,
+			sp.name AS supplier_name,
+			u.unit_name AS unit_name
 		FROM products p
+		JOIN categories c on p.category_id = c.id
+		LEFT JOIN suppliers sp on p.supplier_id = sp.id
+		JOIN units u on p.unit_id = u.id
+		LEFT JOIN product_discounts d on d.product_id = p.id
 	`
 
 	expectedQueryListProduct = expectedQueryGetProduct + `
+		WHERE 1=1
+	`
+
+	expectedQuerySearchProduct = `
+		SELECT
+			p.id,
+			p.category_id,
+			p.supplier_id,
+			p.unit_id,
+			p.name,
+			p.description,
+			p.base_price,
+			p.stock,
+			p.created_at,
+			p.created_by,
+			p.updated_at,
+			p.updated_by,
+			p.deleted_at,
+			p.deleted_by,
+			p.version,
+			d.discount_percent,
+			d.discount_start_date,
+			d.discount_end_date,
+			d.max_purchase_qty,
+			c.name AS category_name,
+			sp.name AS supplier_name,
+			u.unit_name AS unit_name,
+			r.rank AS search_rank,
+			ts_headline('simple', p.name, s.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS name_highlight,
+			ts_headline('simple', COALESCE(p.description, ''), s.query, 'StartSel=<mark>, StopSel=</mark>') AS description_highlight
+		FROM products p
+		CROSS JOIN websearch_to_tsquery('simple', ?) s(query)
+		CROSS JOIN LATERAL (SELECT ts_rank(p.search_vector, s.query) AS rank) r
+		JOIN categories c on p.category_id = c.id
+		LEFT JOIN suppliers sp on p.supplier_id = sp.id
+		JOIN units u on p.unit_id = u.id
+		LEFT JOIN product_discounts d on d.product_id = p.id
+		WHERE 1=1
+	`
+
+	expectedQuerySuggestProduct = `
+		SELECT
+			p.id,
+			p.name
+		FROM products p
+		JOIN categories c on p.category_id = c.id
+		WHERE (p.name ILIKE ? OR ? <% p.name)
+	`
+
+	expectedQueryCountProductByCategory = `
+		SELECT
+			c.id,
+			c.name,
+			COUNT(*) AS count
+		FROM products p
+		JOIN categories c on p.category_id = c.id
+		WHERE 1=1
+	`
+
+	expectedQueryCountProductBySupplier = `
+		SELECT
+			sp.id,
+			sp.name,
+			COUNT(*) AS count
+		FROM products p
+		JOIN categories c on p.category_id = c.id
+		JOIN suppliers sp on p.supplier_id = sp.id
+		WHERE 1=1
+	`
+
+	expectedQueryCountProductByUnit = `
+		SELECT
+			u.id,
+			u.unit_name AS name,
+			COUNT(*) AS count
+		FROM products p
+		JOIN categories c on p.category_id = c.id
+		JOIN units u on p.unit_id = u.id
+		WHERE 1=1
+	`
+
+	expectedQueryCountProductByPriceBucket = `
+		SELECT
+			width_bucket(p.base_price, ?::numeric[]) AS bucket,
+			COUNT(*) AS count
+		FROM products p
+		JOIN categories c on p.category_id = c.id
+		WHERE 1=1
+	`
+
+	expectedQueryCountProduct = `
+		SELECT COUNT(*)
+		FROM products p
 		JOIN categories c on p.category_id = c.id
 		WHERE 1=1
 	`
 
 	expectedQueryGetProductByID = expectedQueryGetProduct + `		
-		WHERE p.id = $1
+		WHERE 
+			p.id = $1 AND 
+			p.deleted_at IS NULL
+	`
+
+	expectedQueryGetExistingCategoryIDs = `SELECT id FROM categories WHERE id = ANY($1::uuid[])`
+	expectedQueryGetExistingSupplierIDs = `SELECT id FROM suppliers WHERE id = ANY($1::uuid[])`
+	expectedQueryGetExistingUnitIDs     = `SELECT id FROM units WHERE id = ANY($1::uuid[])`
+
+	expectedQueryGetDeletedProductByID = expectedQueryGetProduct + `
+		WHERE 
+			p.id = $1 AND 
+			p.deleted_at IS NOT NULL
+	`
+
+	expectedQueryGetListProductByIDs = expectedQueryGetProduct + `
+		WHERE 
+			p.id = ANY($1::uuid[]) AND 
+			p.deleted_at IS NULL
+	`
+
+	expectedQueryGetListProductByNames = expectedQueryGetProduct + `
+		WHERE 
+			(p.category_id, p.name) IN (SELECT * FROM unnest($1::uuid[], $2::text[])) AND 
+			p.deleted_at IS NULL
+	`
+
+	expectedQueryGetListProductBySupplierID = expectedQueryGetProduct + `
+		WHERE 
+			p.supplier_id = $1 AND 
+			p.deleted_at IS NULL
+		ORDER BY p.name ASC
 	`
 
 	expectedQueryGetProductByName = expectedQueryGetProduct + `
 		WHERE 
 			p.category_id = $1 AND 
-			p.name = $2
+			p.name = $2 AND 
+			p.deleted_at IS NULL
 	`
 )
 
 var (
-	ctx                context.Context = context.Background()
-	productID                          = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971427")
-	categoryID                         = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971429")
-	supplierID                         = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971431")
-	unitID                             = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971432")
-	productName                        = "Kangkung Potong 1"
-	productDescription                 = "Product description"
-	productBasePrice                   = 3000
-	productStock                       = 100
-	productCreatedAt                   = time.Now()
-	productCreatedBy                   = "SYSTEM"
-	productUpdatedAt                   = time.Now()
-	productUpdatedBy                   = "SYSTEM"
+	ctx                    context.Context = context.Background()
+	productID                              = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971427")
+	categoryID                             = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971429")
+	supplierID                             = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971431")
+	unitID                                 = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971432")
+	productName                            = "Kangkung Potong 1"
+	productDescription                     = "Product description"
+	productBasePrice                       = 3000
+	productStock                           = 100
+	productCreatedAt                       = time.Now()
+	productCreatedBy                       = "SYSTEM"
+	productUpdatedAt                       = time.Now()
+	productUpdatedBy                       = "SYSTEM"
+	productDeletedAt                       = time.Now()
+	productDeletedBy                       = "SYSTEM"
+	productVersion                         = 1
+	discountPercent                        = 15.0
+	discountStartDate                      = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	discountEndDate                        = time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)
+	discountMaxPurchaseQty                 = 5
+	movementReason                         = "Restock from supplier"
+	movementReferenceID                    = "PO-2025-0001"
+	movementCreatedAt                      = time.Now()
+	movementActor                          = "warehouse"
+	reasonInitialStock                     = constant.StockMovementReasonInitialStock
+	reasonProductUpdate                    = constant.StockMovementReasonProductUpdate
 )
 
-func TestProductRepository_CreateProduct(t *testing.T) {
-	type fields struct {
+// productEventPayload matches the payload of a product event by the ID and version of
+// the product it holds.
+type productEventPayload struct {
+	id      uuid.UUID
+	version int
+}
+
+func (p productEventPayload) Match(v driver.Value) bool {
+	payload, ok := v.(string)
+	if !ok {
+		return false
+	}
+
+	var event postgres.ProductEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return false
+	}
+
+	return event.ID == p.id && event.Version == p.version
+}
+
+// stockEventPayload matches the payload of a stock.changed event by the stock of the
+// product before and after the change.
+type stockEventPayload struct {
+	previousStock int
+	stock         int
+}
+
+func (p stockEventPayload) Match(v driver.Value) bool {
+	payload, ok := v.(string)
+	if !ok {
+		return false
+	}
+
+	var event postgres.StockEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return false
 	}
 
+	return event.PreviousStock == p.previousStock && event.Stock == p.stock
+}
+
+func TestProductRepository_CreateProduct(t *testing.T) {
 	type args struct {
 		ctx     context.Context
 		product domain.Product
@@ -94,45 +477,93 @@ func TestProductRepository_CreateProduct(t *testing.T) {
 
 	uuidutil.UUIDHelper = mockUUIDHelper{id: productID}
 
+	product := domain.Product{
+		CategoryID:  categoryID,
+		SupplierID:  supplierID,
+		UnitID:      unitID,
+		Name:        productName,
+		Description: &productDescription,
+		BasePrice:   float64(productBasePrice),
+		Stock:       productStock,
+		CreatedAt:   productCreatedAt,
+		CreatedBy:   productCreatedBy,
+		Version:     productVersion,
+	}
+
+	withoutStock := product
+	withoutStock.Stock = 0
+
 	tests := []struct {
 		name    string
-		fields  fields
 		args    args
 		mockFn  func(mockdb sqlmock.Sqlmock)
 		wantRes uuid.UUID
 		wantErr bool
 	}{
+		{
+			name: "error when begin transaction",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin().WillReturnError(errors.New("error"))
+			},
+			wantRes: uuid.Nil,
+			wantErr: true,
+		},
 		{
 			name: "error when create product",
 			args: args{
-				ctx: ctx,
-				product: domain.Product{
-					CategoryID:  categoryID,
-					SupplierID:  supplierID,
-					UnitID:      unitID,
-					Name:        productName,
-					Description: &productDescription,
-					BasePrice:   float64(productBasePrice),
-					Stock:       productStock,
-					CreatedAt:   productCreatedAt,
-					CreatedBy:   productCreatedBy,
-				},
+				ctx:     ctx,
+				product: product,
 			},
 			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
 				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryAddProduct)).
-					WithArgs(
-						productID,
-						categoryID,
-						supplierID,
-						unitID,
-						productName,
-						&productDescription,
-						"invalid_base_price",
-						productStock,
-						productCreatedAt,
-						productCreatedBy,
-					).
-					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+					WithArgs(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, productVersion).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
+			},
+			wantRes: uuid.Nil,
+			wantErr: true,
+		},
+		{
+			name: "error when create product event",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryAddProduct)).
+					WithArgs(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, productVersion).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventCreated, productEventPayload{id: productID, version: productVersion}).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
+			},
+			wantRes: uuid.Nil,
+			wantErr: true,
+		},
+		{
+			name: "error when create opening stock movement",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryAddProduct)).
+					WithArgs(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, productVersion).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventCreated, productEventPayload{id: productID, version: productVersion}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateStockMovements + "(?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
 			},
 			wantRes: uuid.Nil,
 			wantErr: true,
@@ -140,35 +571,40 @@ func TestProductRepository_CreateProduct(t *testing.T) {
 		{
 			name: "success create product",
 			args: args{
-				ctx: ctx,
-
-				product: domain.Product{
-					CategoryID:  categoryID,
-					SupplierID:  supplierID,
-					UnitID:      unitID,
-					Name:        productName,
-					Description: &productDescription,
-					BasePrice:   float64(productBasePrice),
-					Stock:       productStock,
-					CreatedAt:   productCreatedAt,
-					CreatedBy:   productCreatedBy,
-				},
+				ctx:     ctx,
+				product: product,
 			},
 			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
 				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryAddProduct)).
-					WithArgs(
-						productID,
-						categoryID,
-						supplierID,
-						unitID,
-						productName,
-						&productDescription,
-						float64(productBasePrice),
-						productStock,
-						productCreatedAt,
-						productCreatedBy,
-					).
+					WithArgs(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, productVersion).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventCreated, productEventPayload{id: productID, version: productVersion}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateStockMovements+"(?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.StockMovementAdjustment, productStock, productStock, &reasonInitialStock, nil, productCreatedBy, productCreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectCommit()
+			},
+			wantRes: productID,
+			wantErr: false,
+		},
+		{
+			name: "success create product without stock",
+			args: args{
+				ctx:     ctx,
+				product: withoutStock,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryAddProduct)).
+					WithArgs(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), 0, productCreatedAt, productCreatedBy, productVersion).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventCreated, productEventPayload{id: productID, version: productVersion}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectCommit()
 			},
 			wantRes: productID,
 			wantErr: false,
@@ -180,8 +616,6 @@ func TestProductRepository_CreateProduct(t *testing.T) {
 			db, mock, _ := sqlmock.New()
 			dbx := sqlx.NewDb(db, "sqlmock")
 
-			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryAddProduct))
-
 			if tt.mockFn != nil {
 				tt.mockFn(mock)
 			}
@@ -192,7 +626,7 @@ func TestProductRepository_CreateProduct(t *testing.T) {
 				},
 			})
 
-			gotRes, err := repo.CreateProduct(ctx, tt.args.product)
+			gotRes, err := repo.CreateProduct(tt.args.ctx, tt.args.product)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ProductRepository.CreateProduct() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -201,65 +635,235 @@ func TestProductRepository_CreateProduct(t *testing.T) {
 			if !reflect.DeepEqual(gotRes, tt.wantRes) {
 				t.Errorf("ProductRepository.CreateProduct() gotRes = %v, want %v", gotRes, tt.wantRes)
 			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
 		})
 	}
-
 }
 
-func TestProductRepository_GetProductByID(t *testing.T) {
-	type args struct {
-		ctx       context.Context
-		productID uuid.UUID
+func TestProductRepository_CreateProducts(t *testing.T) {
+	uuidutil.UUIDHelper = mockUUIDHelper{id: productID}
+
+	products := domain.Products{
+		{
+			CategoryID:  categoryID,
+			SupplierID:  supplierID,
+			UnitID:      unitID,
+			Name:        productName,
+			Description: &productDescription,
+			BasePrice:   float64(productBasePrice),
+			Stock:       productStock,
+			CreatedAt:   productCreatedAt,
+			CreatedBy:   productCreatedBy,
+			Version:     productVersion,
+		},
+		{
+			CategoryID: categoryID,
+			SupplierID: supplierID,
+			UnitID:     unitID,
+			Name:       "Kangkung Potong 2",
+			BasePrice:  float64(productBasePrice),
+			Stock:      productStock,
+			CreatedAt:  productCreatedAt,
+			CreatedBy:  productCreatedBy,
+			Version:    productVersion,
+		},
 	}
 
 	tests := []struct {
-		name    string
-		args    args
-		mockFn  func(mockdb sqlmock.Sqlmock)
-		wantRes domain.Product
-		wantErr bool
+		name      string
+		mockFn    func(mockdb sqlmock.Sqlmock)
+		wantRes   []uuid.UUID
+		wantErr   bool
+		wantErrIs error
 	}{
 		{
-			name: "error when get product by id",
-			args: args{
-				ctx:       ctx,
-				productID: productID,
+			name: "error when begin transaction",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin().WillReturnError(errors.New("error"))
 			},
+			wantErr: true,
+		},
+		{
+			name: "error when insert products",
 			mockFn: func(mockdb sqlmock.Sqlmock) {
-				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductByID)).
-					WithArgs(productID).
+				mockdb.ExpectBegin()
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProducts)).
 					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
 			},
-			wantRes: domain.Product{},
 			wantErr: true,
 		},
 		{
-			name: "error when get product not found",
-			args: args{
-				ctx:       ctx,
-				productID: productID,
-			},
+			name: "error when a name is taken by another product",
 			mockFn: func(mockdb sqlmock.Sqlmock) {
-				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductByID)).
-					WithArgs(productID).
-					WillReturnError(sql.ErrNoRows)
+				mockdb.ExpectBegin()
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProducts)).
+					WillReturnError(&pq.Error{Code: "23505"})
+				mockdb.ExpectRollback()
 			},
-			wantRes: domain.Product{},
-			wantErr: true,
+			wantErr:   true,
+			wantErrIs: dbutil.ErrDataAlreadyExist,
 		},
 		{
-			name: "error when there is malformed data",
-			args: args{
-				ctx:       ctx,
-				productID: productID,
+			name: "error when a category, supplier or unit does not exist",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProducts)).
+					WillReturnError(&pq.Error{Code: "23503"})
+				mockdb.ExpectRollback()
 			},
+			wantErr:   true,
+			wantErrIs: dbutil.ErrDataReferenceNotFound,
+		},
+		{
+			name: "error when create product events",
 			mockFn: func(mockdb sqlmock.Sqlmock) {
-				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductByID)).
-					WithArgs(productID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by"}).
-						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, -1, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy))
+				mockdb.ExpectBegin()
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProducts)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents)).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
 			},
-			wantRes: domain.Product{},
+			wantErr: true,
+		},
+		{
+			name: "error when create opening stock movements",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProducts)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateStockMovements)).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success create products with a single insert",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProducts+"(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+					WithArgs(
+						productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, productVersion,
+						productID, categoryID, supplierID, unitID, "Kangkung Potong 2", nil, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, productVersion,
+					).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?), (?, ?, ?, ?)")).
+					WithArgs(
+						productID, productID, constant.ProductEventCreated, productEventPayload{id: productID, version: productVersion},
+						productID, productID, constant.ProductEventCreated, productEventPayload{id: productID, version: productVersion},
+					).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateStockMovements+"(?, ?, ?, ?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+					WithArgs(
+						productID, productID, constant.StockMovementAdjustment, productStock, productStock, &reasonInitialStock, nil, productCreatedBy, productCreatedAt,
+						productID, productID, constant.StockMovementAdjustment, productStock, productStock, &reasonInitialStock, nil, productCreatedBy, productCreatedAt,
+					).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mockdb.ExpectCommit()
+			},
+			wantRes: []uuid.UUID{productID, productID},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.CreateProducts(ctx, products)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.CreateProducts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("ProductRepository.CreateProducts() error = %v, wantErrIs %v", err, tt.wantErrIs)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("ProductRepository.CreateProducts() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepository_GetProductByID(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		productID uuid.UUID
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.Product
+		wantErr bool
+	}{
+		{
+			name: "error when get product by id",
+			args: args{
+				ctx:       ctx,
+				productID: productID,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductByID)).
+					WithArgs(productID).
+					WillReturnError(errors.New("error"))
+			},
+			wantRes: domain.Product{},
+			wantErr: true,
+		},
+		{
+			name: "error when get product not found",
+			args: args{
+				ctx:       ctx,
+				productID: productID,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductByID)).
+					WithArgs(productID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantRes: domain.Product{},
+			wantErr: true,
+		},
+		{
+			name: "error when there is malformed data",
+			args: args{
+				ctx:       ctx,
+				productID: productID,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductByID)).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, -1, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion))
+			},
+			wantRes: domain.Product{},
 			wantErr: true,
 		},
 
@@ -272,8 +876,67 @@ func TestProductRepository_GetProductByID(t *testing.T) {
 			mockFn: func(mockdb sqlmock.Sqlmock) {
 				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductByID)).
 					WithArgs(productID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by"}).
-						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion))
+			},
+			wantRes: domain.Product{
+				ID:          productID,
+				CategoryID:  categoryID,
+				SupplierID:  supplierID,
+				UnitID:      unitID,
+				Name:        productName,
+				Description: &productDescription,
+				BasePrice:   float64(productBasePrice),
+				Stock:       productStock,
+				CreatedAt:   productCreatedAt,
+				CreatedBy:   productCreatedBy,
+				UpdatedAt:   &productUpdatedAt,
+				UpdatedBy:   &productUpdatedBy,
+				Version:     productVersion,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success get product by id with its relations",
+			args: args{
+				ctx:       ctx,
+				productID: productID,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductByID)).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "version", "category_name", "supplier_name", "unit_name"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion, "Sayuran", "Toko Sayuran Segar", "Kg"))
+			},
+			wantRes: domain.Product{
+				ID:          productID,
+				CategoryID:  categoryID,
+				SupplierID:  supplierID,
+				UnitID:      unitID,
+				Name:        productName,
+				Description: &productDescription,
+				BasePrice:   float64(productBasePrice),
+				Stock:       productStock,
+				CreatedAt:   productCreatedAt,
+				CreatedBy:   productCreatedBy,
+				Version:     productVersion,
+				Category:    &domain.ProductRelation{ID: categoryID, Name: "Sayuran"},
+				Supplier:    &domain.ProductRelation{ID: supplierID, Name: "Toko Sayuran Segar"},
+				Unit:        &domain.ProductRelation{ID: unitID, Name: "Kg"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success get product by id with discount",
+			args: args{
+				ctx:       ctx,
+				productID: productID,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductByID)).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version", "discount_percent", "discount_start_date", "discount_end_date", "max_purchase_qty"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion, "15.00", discountStartDate, discountEndDate, discountMaxPurchaseQty))
 			},
 			wantRes: domain.Product{
 				ID:          productID,
@@ -288,6 +951,14 @@ func TestProductRepository_GetProductByID(t *testing.T) {
 				CreatedBy:   productCreatedBy,
 				UpdatedAt:   &productUpdatedAt,
 				UpdatedBy:   &productUpdatedBy,
+				Version:     productVersion,
+				Discount: &domain.ProductDiscount{
+					ProductID:       productID,
+					DiscountPercent: discountPercent,
+					StartDate:       discountStartDate,
+					EndDate:         discountEndDate,
+					MaxPurchaseQty:  &discountMaxPurchaseQty,
+				},
 			},
 			wantErr: false,
 		},
@@ -323,6 +994,92 @@ func TestProductRepository_GetProductByID(t *testing.T) {
 	}
 }
 
+func TestProductRepository_GetDeletedProductByID(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		productID uuid.UUID
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.Product
+		wantErr error
+	}{
+		{
+			name: "error when product is not deleted",
+			args: args{
+				ctx:       ctx,
+				productID: productID,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetDeletedProductByID)).
+					WithArgs(productID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: dbutil.ErrDataNotFound,
+		},
+		{
+			name: "success get deleted product by id",
+			args: args{
+				ctx:       ctx,
+				productID: productID,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetDeletedProductByID)).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "deleted_at", "deleted_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productDeletedAt, productDeletedBy, productVersion))
+			},
+			wantRes: domain.Product{
+				ID:          productID,
+				CategoryID:  categoryID,
+				SupplierID:  supplierID,
+				UnitID:      unitID,
+				Name:        productName,
+				Description: &productDescription,
+				BasePrice:   float64(productBasePrice),
+				Stock:       productStock,
+				CreatedAt:   productCreatedAt,
+				CreatedBy:   productCreatedBy,
+				DeletedAt:   &productDeletedAt,
+				DeletedBy:   &productDeletedBy,
+				Version:     productVersion,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryGetDeletedProductByID))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetDeletedProductByID(tt.args.ctx, tt.args.productID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ProductRepository.GetDeletedProductByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("ProductRepository.GetDeletedProductByID() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
 func TestProductRepository_GetProductByName(t *testing.T) {
 	type args struct {
 		ctx         context.Context
@@ -379,8 +1136,8 @@ func TestProductRepository_GetProductByName(t *testing.T) {
 			mockFn: func(mockdb sqlmock.Sqlmock) {
 				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductByName)).
 					WithArgs(categoryID, productName).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by"}).
-						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, -1, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, -1, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion))
 			},
 			wantRes: domain.Product{},
 			wantErr: true,
@@ -395,8 +1152,8 @@ func TestProductRepository_GetProductByName(t *testing.T) {
 			mockFn: func(mockdb sqlmock.Sqlmock) {
 				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductByName)).
 					WithArgs(categoryID, productName).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by"}).
-						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion))
 			},
 			wantRes: domain.Product{
 				ID:          productID,
@@ -411,6 +1168,7 @@ func TestProductRepository_GetProductByName(t *testing.T) {
 				CreatedBy:   productCreatedBy,
 				UpdatedAt:   &productUpdatedAt,
 				UpdatedBy:   &productUpdatedBy,
+				Version:     productVersion,
 			},
 			wantErr: false,
 		},
@@ -448,30 +1206,39 @@ func TestProductRepository_GetProductByName(t *testing.T) {
 
 func TestProductRepository_GetListProduct(t *testing.T) {
 	type args struct {
-		ctx          context.Context
-		productName  string
-		categoryType string
-		sort         string
-		direction    string
+		ctx    context.Context
+		filter domain.ProductFilter
+		sort   domain.ProductSort
+		page   domain.ListPage
 	}
 
 	ctx := context.Background()
 
+	secondProductID := uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971428")
+
+	var (
+		minPrice        = float64(1000)
+		maxPrice        = float64(10000)
+		maxStock        = 500
+		createdFrom     = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		cursorPrice     = "5000"
+		cursorStock     = "10"
+		cursorUpdatedAt = "2024-03-01T10:00:00Z"
+		cursorRelevance = "-0.25"
+	)
+
 	tests := []struct {
-		name    string
-		args    args
-		mockFn  func(mockdb sqlmock.Sqlmock)
-		wantRes domain.Products
-		wantErr bool
+		name     string
+		args     args
+		mockFn   func(mockdb sqlmock.Sqlmock)
+		wantRes  domain.Products
+		wantNext *domain.ProductCursor
+		wantErr  bool
 	}{
 		{
 			name: "error when get list product",
 			args: args{
-				ctx:          ctx,
-				productName:  "",
-				categoryType: "",
-				sort:         "",
-				direction:    "",
+				ctx: ctx,
 			},
 			mockFn: func(mockdb sqlmock.Sqlmock) {
 				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
@@ -483,11 +1250,7 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 		{
 			name: "error when product not found",
 			args: args{
-				ctx:          ctx,
-				productName:  "",
-				categoryType: "",
-				sort:         "",
-				direction:    "",
+				ctx: ctx,
 			},
 			mockFn: func(mockdb sqlmock.Sqlmock) {
 				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
@@ -496,19 +1259,29 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 			wantRes: nil,
 			wantErr: true,
 		},
+		{
+			name: "error when reading the rows of the list",
+			args: args{
+				ctx: ctx,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion).
+						RowError(0, errors.New("error")))
+			},
+			wantRes: nil,
+			wantErr: true,
+		},
 		{
 			name: "error when there is malformed data",
 			args: args{
-				ctx:          ctx,
-				productName:  "",
-				categoryType: "",
-				sort:         "",
-				direction:    "",
+				ctx: ctx,
 			},
 			mockFn: func(mockdb sqlmock.Sqlmock) {
 				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by"}).
-						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, -1, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, -1, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion))
 			},
 			wantRes: nil,
 			wantErr: true,
@@ -516,16 +1289,12 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 		{
 			name: "success get product list",
 			args: args{
-				ctx:          ctx,
-				productName:  "",
-				categoryType: "",
-				sort:         "",
-				direction:    "",
+				ctx: ctx,
 			},
 			mockFn: func(mockdb sqlmock.Sqlmock) {
 				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by"}).
-						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion))
 			},
 			wantRes: domain.Products{
 				{
@@ -541,6 +1310,7 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 					CreatedBy:   productCreatedBy,
 					UpdatedAt:   &productUpdatedAt,
 					UpdatedBy:   &productUpdatedBy,
+					Version:     productVersion,
 				},
 			},
 			wantErr: false,
@@ -548,16 +1318,13 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 		{
 			name: "success to filter product list by category type",
 			args: args{
-				ctx:          ctx,
-				productName:  "",
-				categoryType: "Sayuran",
-				sort:         "",
-				direction:    "",
+				ctx:    ctx,
+				filter: domain.ProductFilter{CategoryType: "Sayuran"},
 			},
 			mockFn: func(mockdb sqlmock.Sqlmock) {
 				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by"}).
-						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion))
 			},
 			wantRes: domain.Products{
 				{
@@ -573,6 +1340,7 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 					CreatedBy:   productCreatedBy,
 					UpdatedAt:   &productUpdatedAt,
 					UpdatedBy:   &productUpdatedBy,
+					Version:     productVersion,
 				},
 			},
 			wantErr: false,
@@ -580,16 +1348,14 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 		{
 			name: "success to filter product list by category type, sort by base price, and direction is asc",
 			args: args{
-				ctx:          ctx,
-				productName:  "",
-				categoryType: "sayuran",
-				sort:         "base_price",
-				direction:    "asc",
+				ctx:    ctx,
+				filter: domain.ProductFilter{CategoryType: "sayuran"},
+				sort:   domain.ProductSort{Keys: []pageutil.SortKey{{Field: "base_price", Direction: "asc"}}},
 			},
 			mockFn: func(mockdb sqlmock.Sqlmock) {
 				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by"}).
-						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion))
 			},
 			wantRes: domain.Products{
 				{
@@ -605,6 +1371,7 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 					CreatedBy:   productCreatedBy,
 					UpdatedAt:   &productUpdatedAt,
 					UpdatedBy:   &productUpdatedBy,
+					Version:     productVersion,
 				},
 			},
 			wantErr: false,
@@ -612,16 +1379,13 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 		{
 			name: "success to partial-match search product list by product name",
 			args: args{
-				ctx:          ctx,
-				productName:  "kangkung",
-				categoryType: "",
-				sort:         "",
-				direction:    "",
+				ctx:    ctx,
+				filter: domain.ProductFilter{ProductName: "kangkung"},
 			},
 			mockFn: func(mockdb sqlmock.Sqlmock) {
 				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by"}).
-						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion))
 			},
 			wantRes: domain.Products{
 				{
@@ -637,6 +1401,7 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 					CreatedBy:   productCreatedBy,
 					UpdatedAt:   &productUpdatedAt,
 					UpdatedBy:   &productUpdatedBy,
+					Version:     productVersion,
 				},
 			},
 			wantErr: false,
@@ -644,16 +1409,14 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 		{
 			name: "success to partial-match search product list by product name, filter by category type, sort by product name, and direction is desc",
 			args: args{
-				ctx:          ctx,
-				productName:  "kangkung",
-				categoryType: "sayuran",
-				sort:         "name",
-				direction:    "desc",
+				ctx:    ctx,
+				filter: domain.ProductFilter{ProductName: "kangkung", CategoryType: "sayuran"},
+				sort:   domain.ProductSort{Keys: []pageutil.SortKey{{Field: "name", Direction: "desc"}}},
 			},
 			mockFn: func(mockdb sqlmock.Sqlmock) {
 				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by"}).
-						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion))
 			},
 			wantRes: domain.Products{
 				{
@@ -669,40 +1432,390 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 					CreatedBy:   productCreatedBy,
 					UpdatedAt:   &productUpdatedAt,
 					UpdatedBy:   &productUpdatedBy,
+					Version:     productVersion,
 				},
 			},
 			wantErr: false,
 		},
 		{
-			name: "error when get list product with invalid sort",
-			args: args{
-				ctx:          ctx,
-				productName:  "",
-				categoryType: "",
-				sort:         "updated_at",
-				direction:    "",
-			},
-			mockFn: func(mockdb sqlmock.Sqlmock) {
-				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
-					WillReturnError(errors.New("error"))
-			},
-			wantRes: nil,
-			wantErr: true,
-		},
-		{
-			name: "error when get list product with invalid direction",
+			name: "success get product list including soft-deleted products",
 			args: args{
-				ctx:          ctx,
-				productName:  "",
-				categoryType: "",
-				sort:         "",
-				direction:    "invalid_direction",
+				ctx:    ctx,
+				filter: domain.ProductFilter{IncludeDeleted: true},
 			},
 			mockFn: func(mockdb sqlmock.Sqlmock) {
 				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
-					WillReturnError(errors.New("error"))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productDeletedAt, productDeletedBy, productVersion))
 			},
-			wantRes: nil,
+			wantRes: domain.Products{
+				{
+					ID:          productID,
+					CategoryID:  categoryID,
+					SupplierID:  supplierID,
+					UnitID:      unitID,
+					Name:        productName,
+					Description: &productDescription,
+					BasePrice:   float64(productBasePrice),
+					Stock:       productStock,
+					CreatedAt:   productCreatedAt,
+					CreatedBy:   productCreatedBy,
+					UpdatedAt:   &productUpdatedAt,
+					UpdatedBy:   &productUpdatedBy,
+					DeletedAt:   &productDeletedAt,
+					DeletedBy:   &productDeletedBy,
+					Version:     productVersion,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success get first page with more products left",
+			args: args{
+				ctx:  ctx,
+				sort: domain.ProductSort{Keys: []pageutil.SortKey{{Field: "name", Direction: "asc"}}},
+				page: domain.ListPage{Limit: 1},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct + " AND p.deleted_at IS NULL ORDER BY p.name asc, p.id asc LIMIT ?")).
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion).
+						AddRow(secondProductID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion))
+			},
+			wantRes: domain.Products{
+				{
+					ID:          productID,
+					CategoryID:  categoryID,
+					SupplierID:  supplierID,
+					UnitID:      unitID,
+					Name:        productName,
+					Description: &productDescription,
+					BasePrice:   float64(productBasePrice),
+					Stock:       productStock,
+					CreatedAt:   productCreatedAt,
+					CreatedBy:   productCreatedBy,
+					Version:     productVersion,
+				},
+			},
+			wantNext: &domain.ProductCursor{
+				Sort:   []pageutil.SortKey{{Field: "name", Direction: "asc"}},
+				Values: []*string{&productName},
+				ID:     productID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success get last page after a cursor sorted by base price desc",
+			args: args{
+				ctx:  ctx,
+				sort: domain.ProductSort{Keys: []pageutil.SortKey{{Field: "base_price", Direction: "desc"}}},
+				page: domain.ListPage{
+					Limit: 1,
+					After: &domain.ProductCursor{Sort: []pageutil.SortKey{{Field: "base_price", Direction: "desc"}}, Values: []*string{&cursorPrice}, ID: secondProductID},
+				},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct+" AND p.deleted_at IS NULL AND (p.base_price, p.id) < (?, ?) ORDER BY p.base_price desc, p.id desc LIMIT ?")).
+					WithArgs("5000", secondProductID, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion))
+			},
+			wantRes: domain.Products{
+				{
+					ID:          productID,
+					CategoryID:  categoryID,
+					SupplierID:  supplierID,
+					UnitID:      unitID,
+					Name:        productName,
+					Description: &productDescription,
+					BasePrice:   float64(productBasePrice),
+					Stock:       productStock,
+					CreatedAt:   productCreatedAt,
+					CreatedBy:   productCreatedBy,
+					Version:     productVersion,
+				},
+			},
+			wantNext: nil,
+			wantErr:  false,
+		},
+		{
+			name: "success get numbered page",
+			args: args{
+				ctx:    ctx,
+				filter: domain.ProductFilter{CategoryType: "Sayuran"},
+				page:   domain.ListPage{Limit: 10, Number: 3},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct+" AND p.deleted_at IS NULL AND c.name = ? ORDER BY p.created_at asc, p.id asc LIMIT ? OFFSET ?")).
+					WithArgs("Sayuran", 10, 20).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion))
+			},
+			wantRes: domain.Products{
+				{
+					ID:          productID,
+					CategoryID:  categoryID,
+					SupplierID:  supplierID,
+					UnitID:      unitID,
+					Name:        productName,
+					Description: &productDescription,
+					BasePrice:   float64(productBasePrice),
+					Stock:       productStock,
+					CreatedAt:   productCreatedAt,
+					CreatedBy:   productCreatedBy,
+					Version:     productVersion,
+				},
+			},
+			wantNext: nil,
+			wantErr:  false,
+		},
+		{
+			name: "success filter product list by supplier, unit, price and stock ranges, and created window",
+			args: args{
+				ctx: ctx,
+				filter: domain.ProductFilter{
+					SupplierIDs: []uuid.UUID{supplierID},
+					UnitIDs:     []uuid.UUID{unitID},
+					MinPrice:    &minPrice,
+					MaxPrice:    &maxPrice,
+					MaxStock:    &maxStock,
+					InStock:     true,
+					CreatedFrom: &createdFrom,
+				},
+				page: domain.ListPage{Limit: 10},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct+" AND p.deleted_at IS NULL AND p.supplier_id = ANY(?::uuid[]) AND p.unit_id = ANY(?::uuid[]) AND p.base_price >= ? AND p.base_price <= ? AND p.stock <= ? AND p.stock > 0 AND p.created_at >= ? ORDER BY p.created_at asc, p.id asc LIMIT ?")).
+					WithArgs(pq.Array([]string{supplierID.String()}), pq.Array([]string{unitID.String()}), minPrice, maxPrice, maxStock, createdFrom, 11).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion))
+			},
+			wantRes: domain.Products{
+				{
+					ID:          productID,
+					CategoryID:  categoryID,
+					SupplierID:  supplierID,
+					UnitID:      unitID,
+					Name:        productName,
+					Description: &productDescription,
+					BasePrice:   float64(productBasePrice),
+					Stock:       productStock,
+					CreatedAt:   productCreatedAt,
+					CreatedBy:   productCreatedBy,
+					Version:     productVersion,
+				},
+			},
+			wantNext: nil,
+			wantErr:  false,
+		},
+		{
+			name: "success get page after a cursor sorted by several fields with nulls last",
+			args: args{
+				ctx: ctx,
+				sort: domain.ProductSort{Keys: []pageutil.SortKey{
+					{Field: "updated_at", Direction: "desc", Nulls: "last"},
+					{Field: "name", Direction: "asc"},
+				}},
+				page: domain.ListPage{
+					Limit: 1,
+					After: &domain.ProductCursor{
+						Sort: []pageutil.SortKey{
+							{Field: "updated_at", Direction: "desc", Nulls: "last"},
+							{Field: "name", Direction: "asc"},
+						},
+						Values: []*string{&cursorUpdatedAt, &productName},
+						ID:     secondProductID,
+					},
+				},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct+" AND p.deleted_at IS NULL AND (((p.updated_at < ? OR p.updated_at IS NULL)) OR (p.updated_at = ? AND p.name > ?) OR (p.updated_at = ? AND p.name = ? AND p.id > ?)) ORDER BY p.updated_at desc NULLS LAST, p.name asc, p.id asc LIMIT ?")).
+					WithArgs(cursorUpdatedAt, cursorUpdatedAt, productName, cursorUpdatedAt, productName, secondProductID, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion))
+			},
+			wantRes: domain.Products{
+				{
+					ID:          productID,
+					CategoryID:  categoryID,
+					SupplierID:  supplierID,
+					UnitID:      unitID,
+					Name:        productName,
+					Description: &productDescription,
+					BasePrice:   float64(productBasePrice),
+					Stock:       productStock,
+					CreatedAt:   productCreatedAt,
+					CreatedBy:   productCreatedBy,
+					Version:     productVersion,
+				},
+			},
+			wantNext: nil,
+			wantErr:  false,
+		},
+		{
+			name: "success get page after a cursor on a product never updated",
+			args: args{
+				ctx: ctx,
+				sort: domain.ProductSort{Keys: []pageutil.SortKey{
+					{Field: "stock", Direction: "asc"},
+					{Field: "updated_at", Direction: "asc", Nulls: "first"},
+				}},
+				page: domain.ListPage{
+					Limit: 1,
+					After: &domain.ProductCursor{
+						Sort: []pageutil.SortKey{
+							{Field: "stock", Direction: "asc"},
+							{Field: "updated_at", Direction: "asc", Nulls: "first"},
+						},
+						Values: []*string{&cursorStock, nil},
+						ID:     secondProductID,
+					},
+				},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct+" AND p.deleted_at IS NULL AND ((p.stock > ?) OR (p.stock = ? AND p.updated_at IS NOT NULL) OR (p.stock = ? AND p.updated_at IS NULL AND p.id > ?)) ORDER BY p.stock asc, p.updated_at asc NULLS FIRST, p.id asc LIMIT ?")).
+					WithArgs(cursorStock, cursorStock, cursorStock, secondProductID, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion))
+			},
+			wantRes: domain.Products{
+				{
+					ID:          productID,
+					CategoryID:  categoryID,
+					SupplierID:  supplierID,
+					UnitID:      unitID,
+					Name:        productName,
+					Description: &productDescription,
+					BasePrice:   float64(productBasePrice),
+					Stock:       productStock,
+					CreatedAt:   productCreatedAt,
+					CreatedBy:   productCreatedBy,
+					Version:     productVersion,
+				},
+			},
+			wantNext: nil,
+			wantErr:  false,
+		},
+		{
+			name: "error when the cursor was issued for another sort",
+			args: args{
+				ctx:  ctx,
+				sort: domain.ProductSort{Keys: []pageutil.SortKey{{Field: "name", Direction: "asc"}}},
+				page: domain.ListPage{
+					Limit: 1,
+					After: &domain.ProductCursor{Sort: []pageutil.SortKey{{Field: "name", Direction: "desc"}}, Values: []*string{&productName}, ID: secondProductID},
+				},
+			},
+			wantRes: nil,
+			wantErr: true,
+		},
+		{
+			name: "success search product list sorted by relevance",
+			args: args{
+				ctx:    ctx,
+				filter: domain.ProductFilter{Query: "kangkung segar"},
+				sort:   domain.ProductSort{Keys: []pageutil.SortKey{{Field: "relevance", Direction: "asc"}}},
+				page:   domain.ListPage{Limit: 1},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQuerySearchProduct+" AND p.deleted_at IS NULL AND p.search_vector @@ websearch_to_tsquery('simple', ?) ORDER BY -r.rank asc, p.id asc LIMIT ?")).
+					WithArgs("kangkung segar", "kangkung segar", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "version", "search_rank", "name_highlight", "description_highlight"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion, 0.25, "<mark>Kangkung</mark> Potong", "").
+						AddRow(secondProductID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion, 0.1, "<mark>Kangkung</mark> Potong", ""))
+			},
+			wantRes: domain.Products{
+				{
+					ID:          productID,
+					CategoryID:  categoryID,
+					SupplierID:  supplierID,
+					UnitID:      unitID,
+					Name:        productName,
+					Description: &productDescription,
+					BasePrice:   float64(productBasePrice),
+					Stock:       productStock,
+					CreatedAt:   productCreatedAt,
+					CreatedBy:   productCreatedBy,
+					Version:     productVersion,
+					Match: &domain.ProductMatch{
+						Rank:          0.25,
+						NameHighlight: "<mark>Kangkung</mark> Potong",
+					},
+				},
+			},
+			wantNext: &domain.ProductCursor{
+				Sort:   []pageutil.SortKey{{Field: "relevance", Direction: "asc"}},
+				Values: []*string{&cursorRelevance},
+				ID:     productID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success fuzzy search product list by name similarity",
+			args: args{
+				ctx:    ctx,
+				filter: domain.ProductFilter{Query: "kangkng", Fuzzy: true},
+				sort:   domain.ProductSort{Keys: []pageutil.SortKey{{Field: "relevance", Direction: "asc"}}},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery("(?s)"+regexp.QuoteMeta("CROSS JOIN LATERAL (SELECT word_similarity(?, p.name) AS rank) r")+".*"+regexp.QuoteMeta(" AND p.deleted_at IS NULL AND ? <% p.name ORDER BY -r.rank asc, p.id asc")).
+					WithArgs("kangkng", "kangkng").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "version", "search_rank"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion, 0.6))
+			},
+			wantRes: domain.Products{
+				{
+					ID:          productID,
+					CategoryID:  categoryID,
+					SupplierID:  supplierID,
+					UnitID:      unitID,
+					Name:        productName,
+					Description: &productDescription,
+					BasePrice:   float64(productBasePrice),
+					Stock:       productStock,
+					CreatedAt:   productCreatedAt,
+					CreatedBy:   productCreatedBy,
+					Version:     productVersion,
+					Match:       &domain.ProductMatch{Rank: 0.6},
+				},
+			},
+			wantNext: nil,
+			wantErr:  false,
+		},
+		{
+			name: "error when sorting by relevance without a search query",
+			args: args{
+				ctx:  ctx,
+				sort: domain.ProductSort{Keys: []pageutil.SortKey{{Field: "relevance", Direction: "asc"}}},
+			},
+			wantRes: nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get list product with invalid sort",
+			args: args{
+				ctx:  ctx,
+				sort: domain.ProductSort{Keys: []pageutil.SortKey{{Field: "description", Direction: "asc"}}},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
+					WillReturnError(errors.New("error"))
+			},
+			wantRes: nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get list product with invalid direction",
+			args: args{
+				ctx:  ctx,
+				sort: domain.ProductSort{Keys: []pageutil.SortKey{{Field: "created_at", Direction: "invalid_direction"}}},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
+					WillReturnError(errors.New("error"))
+			},
+			wantRes: nil,
 			wantErr: true,
 		},
 	}
@@ -722,14 +1835,1798 @@ func TestProductRepository_GetListProduct(t *testing.T) {
 				tt.mockFn(mock)
 			}
 
-			gotRes, err := repo.GetListProduct(ctx, tt.args.productName, tt.args.categoryType, tt.args.sort, tt.args.direction)
+			gotRes, err := repo.GetListProduct(ctx, tt.args.filter, tt.args.sort, tt.args.page)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ProductRepository.GetListProduct() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 
-			if !reflect.DeepEqual(gotRes, tt.wantRes) {
-				t.Errorf("ProductRepository.GetListProduct() gotRes = %v, want %v", gotRes, tt.wantRes)
+			if !reflect.DeepEqual(gotRes.Products, tt.wantRes) {
+				t.Errorf("ProductRepository.GetListProduct() gotRes = %v, want %v", gotRes.Products, tt.wantRes)
+			}
+
+			if !reflect.DeepEqual(gotRes.Next, tt.wantNext) {
+				t.Errorf("ProductRepository.GetListProduct() gotNext = %v, want %v", gotRes.Next, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestProductRepository_CountProduct(t *testing.T) {
+	type args struct {
+		filter domain.ProductFilter
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		mockFn    func(mockdb sqlmock.Sqlmock)
+		wantTotal int
+		wantErr   bool
+	}{
+		{
+			name: "error when count product",
+			args: args{},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProduct)).
+					WillReturnError(errors.New("error"))
+			},
+			wantTotal: 0,
+			wantErr:   true,
+		},
+		{
+			name: "success count product with the list filters",
+			args: args{
+				filter: domain.ProductFilter{ProductName: "kangkung", CategoryType: "Sayuran"},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProduct+" AND p.deleted_at IS NULL AND c.name = ? AND LOWER(p.name) LIKE LOWER(?)")).
+					WithArgs("Sayuran", "%kangkung%").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+			},
+			wantTotal: 42,
+			wantErr:   false,
+		},
+		{
+			name: "success count product including soft-deleted products",
+			args: args{
+				filter: domain.ProductFilter{IncludeDeleted: true},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProduct)).
+					WithoutArgs().
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+			},
+			wantTotal: 7,
+			wantErr:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			gotTotal, err := repo.CountProduct(ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.CountProduct() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if gotTotal != tt.wantTotal {
+				t.Errorf("ProductRepository.CountProduct() gotTotal = %v, want %v", gotTotal, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestProductRepository_ExportProduct(t *testing.T) {
+	type args struct {
+		filter domain.ProductFilter
+		sort   domain.ProductSort
+	}
+
+	columns := []string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "version"}
+
+	tests := []struct {
+		name        string
+		args        args
+		mockFn      func(mockdb sqlmock.Sqlmock)
+		wantRes     domain.Products
+		wantErr     bool
+		wantRowsErr bool
+	}{
+		{
+			name: "error when export product",
+			args: args{},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when sorting by relevance without a search query",
+			args: args{
+				sort: domain.ProductSort{Keys: []pageutil.SortKey{{Field: "relevance", Direction: "desc"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "success export product with the list filters and sort, without pages",
+			args: args{
+				filter: domain.ProductFilter{CategoryType: "Sayuran"},
+				sort:   domain.ProductSort{Keys: []pageutil.SortKey{{Field: "base_price", Direction: "desc"}}},
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct + " AND p.deleted_at IS NULL AND c.name = ? ORDER BY p.base_price desc, p.id desc")).
+					WithArgs("Sayuran").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion))
+			},
+			wantRes: domain.Products{
+				{
+					ID:          productID,
+					CategoryID:  categoryID,
+					SupplierID:  supplierID,
+					UnitID:      unitID,
+					Name:        productName,
+					Description: &productDescription,
+					BasePrice:   float64(productBasePrice),
+					Stock:       productStock,
+					CreatedAt:   productCreatedAt,
+					CreatedBy:   productCreatedBy,
+					Version:     productVersion,
+				},
+			},
+		},
+		{
+			name: "error when reading the rows of the export",
+			args: args{},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryListProduct)).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion).
+						RowError(0, errors.New("error")))
+			},
+			wantRowsErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			rows, err := repo.ExportProduct(ctx, tt.args.filter, tt.args.sort)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.ExportProduct() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+			defer rows.Close()
+
+			var gotRes domain.Products
+			for rows.Next() {
+				product, err := rows.Product()
+				if err != nil {
+					t.Errorf("ProductRows.Product() error = %v", err)
+					return
+				}
+
+				gotRes = append(gotRes, product)
+			}
+
+			if err = rows.Err(); (err != nil) != tt.wantRowsErr {
+				t.Errorf("ProductRows.Err() error = %v, wantErr %v", err, tt.wantRowsErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("ProductRepository.ExportProduct() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestProductRepository_SuggestProduct(t *testing.T) {
+	type args struct {
+		prefix string
+		filter domain.ProductFilter
+		limit  int
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.ProductSuggestions
+		wantErr bool
+	}{
+		{
+			name: "error when suggest product",
+			args: args{prefix: "kang", limit: 10},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQuerySuggestProduct)).
+					WillReturnError(errors.New("error"))
+			},
+			wantRes: nil,
+			wantErr: true,
+		},
+		{
+			name: "success suggest product within a category",
+			args: args{
+				prefix: "kangkng",
+				filter: domain.ProductFilter{CategoryType: "Sayuran"},
+				limit:  5,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQuerySuggestProduct+" AND p.deleted_at IS NULL AND c.name = ? ORDER BY p.name ILIKE ? DESC, word_similarity(?, p.name) DESC, p.name ASC, p.id ASC LIMIT ?")).
+					WithArgs("kangkng%", "kangkng", "Sayuran", "kangkng%", "kangkng", 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+						AddRow(productID, productName))
+			},
+			wantRes: domain.ProductSuggestions{
+				{ID: productID, Name: productName},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success suggest nothing",
+			args: args{prefix: "zzz", limit: 10},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQuerySuggestProduct)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+			},
+			wantRes: domain.ProductSuggestions{},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			gotRes, err := repo.SuggestProduct(ctx, tt.args.prefix, tt.args.filter, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.SuggestProduct() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("ProductRepository.SuggestProduct() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestProductRepository_GetProductFacets(t *testing.T) {
+	type args struct {
+		filter      domain.ProductFilter
+		priceBounds []float64
+	}
+
+	var (
+		priceBounds = []float64{0, 5000, 10000}
+		minPrice    = 1000.0
+		maxStock    = 50
+	)
+
+	filter := domain.ProductFilter{
+		CategoryType: "Sayuran",
+		CategoryIDs:  []uuid.UUID{categoryID},
+		SupplierIDs:  []uuid.UUID{supplierID},
+		UnitIDs:      []uuid.UUID{unitID},
+		MinPrice:     &minPrice,
+		MaxStock:     &maxStock,
+	}
+
+	categoryIDs := pq.Array([]string{categoryID.String()})
+	supplierIDs := pq.Array([]string{supplierID.String()})
+	unitIDs := pq.Array([]string{unitID.String()})
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.ProductFacets
+		wantErr bool
+	}{
+		{
+			name: "error when count product by category",
+			args: args{priceBounds: priceBounds},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProductByCategory)).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when count product by price bucket",
+			args: args{priceBounds: priceBounds},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProductByCategory)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "count"}))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProductBySupplier)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "count"}))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProductByUnit)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "count"}))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProductByPriceBucket)).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success get product facets, each ignoring its own filter",
+			args: args{filter: filter, priceBounds: priceBounds},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProductByCategory+" AND p.deleted_at IS NULL AND p.supplier_id = ANY(?::uuid[]) AND p.unit_id = ANY(?::uuid[]) AND p.base_price >= ? AND p.stock <= ? GROUP BY c.id, c.name ORDER BY count DESC, c.name ASC")).
+					WithArgs(supplierIDs, unitIDs, minPrice, maxStock).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "count"}).
+						AddRow(categoryID, "Sayuran", 3))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProductBySupplier+" AND p.deleted_at IS NULL AND c.name = ? AND p.category_id = ANY(?::uuid[]) AND p.unit_id = ANY(?::uuid[]) AND p.base_price >= ? AND p.stock <= ? GROUP BY sp.id, sp.name ORDER BY count DESC, sp.name ASC")).
+					WithArgs("Sayuran", categoryIDs, unitIDs, minPrice, maxStock).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "count"}).
+						AddRow(supplierID, "Supplier 1", 2))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProductByUnit+" AND p.deleted_at IS NULL AND c.name = ? AND p.category_id = ANY(?::uuid[]) AND p.supplier_id = ANY(?::uuid[]) AND p.base_price >= ? AND p.stock <= ? GROUP BY u.id, u.unit_name ORDER BY count DESC, u.unit_name ASC")).
+					WithArgs("Sayuran", categoryIDs, supplierIDs, minPrice, maxStock).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "count"}).
+						AddRow(unitID, "Kg", 2))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProductByPriceBucket+" AND p.deleted_at IS NULL AND c.name = ? AND p.category_id = ANY(?::uuid[]) AND p.supplier_id = ANY(?::uuid[]) AND p.unit_id = ANY(?::uuid[]) AND p.stock <= ? GROUP BY bucket ORDER BY bucket ASC")).
+					WithArgs(pq.Array(priceBounds), "Sayuran", categoryIDs, supplierIDs, unitIDs, maxStock).
+					WillReturnRows(sqlmock.NewRows([]string{"bucket", "count"}).
+						AddRow(1, 1).
+						AddRow(3, 1))
+			},
+			wantRes: domain.ProductFacets{
+				Categories: []domain.FacetCount{{ID: categoryID, Name: "Sayuran", Count: 3}},
+				Suppliers:  []domain.FacetCount{{ID: supplierID, Name: "Supplier 1", Count: 2}},
+				Units:      []domain.FacetCount{{ID: unitID, Name: "Kg", Count: 2}},
+				PriceBuckets: []domain.PriceBucketCount{
+					{Min: &priceBounds[0], Max: &priceBounds[1], Count: 1},
+					{Min: &priceBounds[1], Max: &priceBounds[2], Count: 0},
+					{Min: &priceBounds[2], Count: 1},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			gotRes, err := repo.GetProductFacets(ctx, tt.args.filter, tt.args.priceBounds)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.GetProductFacets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("ProductRepository.GetProductFacets() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestProductRepository_GetListProductByIDs(t *testing.T) {
+	otherProductID := uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971428")
+	productIDs := []uuid.UUID{productID, otherProductID}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.Products
+		wantErr bool
+	}{
+		{
+			name: "error when get product list by ids",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListProductByIDs)).
+					WithArgs(pq.Array([]string{productID.String(), otherProductID.String()})).
+					WillReturnError(errors.New("error"))
+			},
+			wantRes: nil,
+			wantErr: true,
+		},
+		{
+			name: "error when reading the rows of the product list by ids",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListProductByIDs)).
+					WithArgs(pq.Array([]string{productID.String(), otherProductID.String()})).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion).
+						RowError(0, errors.New("error")))
+			},
+			wantRes: nil,
+			wantErr: true,
+		},
+		{
+			name: "success get product list by ids, leaving out unknown ids",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListProductByIDs)).
+					WithArgs(pq.Array([]string{productID.String(), otherProductID.String()})).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion))
+			},
+			wantRes: domain.Products{
+				{
+					ID:          productID,
+					CategoryID:  categoryID,
+					SupplierID:  supplierID,
+					UnitID:      unitID,
+					Name:        productName,
+					Description: &productDescription,
+					BasePrice:   float64(productBasePrice),
+					Stock:       productStock,
+					CreatedAt:   productCreatedAt,
+					CreatedBy:   productCreatedBy,
+					Version:     productVersion,
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryGetListProductByIDs))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetListProductByIDs(ctx, productIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.GetListProductByIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("ProductRepository.GetListProductByIDs() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestProductRepository_GetListProductByNames(t *testing.T) {
+	products := domain.Products{
+		{CategoryID: categoryID, Name: productName},
+		{CategoryID: categoryID, Name: "Kangkung Potong 2"},
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.Products
+		wantErr bool
+	}{
+		{
+			name: "error when get product list by names",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListProductByNames)).
+					WithArgs(pq.Array([]string{categoryID.String(), categoryID.String()}), pq.Array([]string{productName, "Kangkung Potong 2"})).
+					WillReturnError(errors.New("error"))
+			},
+			wantRes: nil,
+			wantErr: true,
+		},
+		{
+			name: "error when reading the rows of the product list by names",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListProductByNames)).
+					WithArgs(pq.Array([]string{categoryID.String(), categoryID.String()}), pq.Array([]string{productName, "Kangkung Potong 2"})).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "base_price", "stock", "created_at", "created_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion).
+						RowError(0, errors.New("error")))
+			},
+			wantRes: nil,
+			wantErr: true,
+		},
+		{
+			name: "success get product list by names",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListProductByNames)).
+					WithArgs(pq.Array([]string{categoryID.String(), categoryID.String()}), pq.Array([]string{productName, "Kangkung Potong 2"})).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "base_price", "stock", "created_at", "created_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productBasePrice, productStock, productCreatedAt, productCreatedBy, productVersion))
+			},
+			wantRes: domain.Products{
+				{
+					ID:         productID,
+					CategoryID: categoryID,
+					SupplierID: supplierID,
+					UnitID:     unitID,
+					Name:       productName,
+					BasePrice:  float64(productBasePrice),
+					Stock:      productStock,
+					CreatedAt:  productCreatedAt,
+					CreatedBy:  productCreatedBy,
+					Version:    productVersion,
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryGetListProductByNames))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetListProductByNames(ctx, products)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.GetListProductByNames() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("ProductRepository.GetListProductByNames() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestProductRepository_GetProductReferences(t *testing.T) {
+	otherUnitID := uuid.MustParse("3d1f8b3e-6a55-4f0b-9a8e-7c2f4b5d6e71")
+
+	products := domain.Products{
+		{CategoryID: categoryID, SupplierID: supplierID, UnitID: unitID},
+		{CategoryID: categoryID, SupplierID: supplierID, UnitID: otherUnitID},
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.ProductReferences
+		wantErr bool
+	}{
+		{
+			name: "error when get existing categories",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetExistingCategoryIDs)).
+					WithArgs(pq.Array([]string{categoryID.String(), categoryID.String()})).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success get product references",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetExistingCategoryIDs)).
+					WithArgs(pq.Array([]string{categoryID.String(), categoryID.String()})).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(categoryID))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetExistingSupplierIDs)).
+					WithArgs(pq.Array([]string{supplierID.String(), supplierID.String()})).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(supplierID))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetExistingUnitIDs)).
+					WithArgs(pq.Array([]string{unitID.String(), otherUnitID.String()})).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(unitID))
+			},
+			wantRes: domain.ProductReferences{
+				CategoryIDs: map[uuid.UUID]bool{categoryID: true},
+				SupplierIDs: map[uuid.UUID]bool{supplierID: true},
+				UnitIDs:     map[uuid.UUID]bool{unitID: true},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetProductReferences(ctx, products)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.GetProductReferences() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("ProductRepository.GetProductReferences() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+
+			if !tt.wantErr && (!gotRes.Resolve(products[0]) || gotRes.Resolve(products[1])) {
+				t.Errorf("ProductRepository.GetProductReferences() resolves the wrong products")
+			}
+		})
+	}
+}
+
+func TestProductRepository_GetListProductBySupplierID(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.Products
+		wantErr bool
+	}{
+		{
+			name: "error when get product list by supplier id",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListProductBySupplierID)).
+					WithArgs(supplierID).
+					WillReturnError(errors.New("error"))
+			},
+			wantRes: nil,
+			wantErr: true,
+		},
+		{
+			name: "error when reading the rows of the product list by supplier id",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListProductBySupplierID)).
+					WithArgs(supplierID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion).
+						RowError(0, errors.New("error")))
+			},
+			wantRes: nil,
+			wantErr: true,
+		},
+		{
+			name: "success get product list by supplier id",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListProductBySupplierID)).
+					WithArgs(supplierID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, productDescription, productBasePrice, productStock, productCreatedAt, productCreatedBy, productUpdatedAt, productUpdatedBy, productVersion))
+			},
+			wantRes: domain.Products{
+				{
+					ID:          productID,
+					CategoryID:  categoryID,
+					SupplierID:  supplierID,
+					UnitID:      unitID,
+					Name:        productName,
+					Description: &productDescription,
+					BasePrice:   float64(productBasePrice),
+					Stock:       productStock,
+					CreatedAt:   productCreatedAt,
+					CreatedBy:   productCreatedBy,
+					UpdatedAt:   &productUpdatedAt,
+					UpdatedBy:   &productUpdatedBy,
+					Version:     productVersion,
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryGetListProductBySupplierID))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetListProductBySupplierID(ctx, supplierID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.GetListProductBySupplierID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("ProductRepository.GetListProductBySupplierID() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestProductRepository_UpdateProduct(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		product domain.Product
+	}
+
+	uuidutil.UUIDHelper = mockUUIDHelper{id: productID}
+
+	product := domain.Product{
+		ID:          productID,
+		CategoryID:  categoryID,
+		SupplierID:  supplierID,
+		UnitID:      unitID,
+		Name:        productName,
+		Description: &productDescription,
+		BasePrice:   float64(productBasePrice),
+		Stock:       productStock,
+		UpdatedAt:   &productUpdatedAt,
+		UpdatedBy:   &productUpdatedBy,
+		Version:     productVersion,
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error when begin transaction",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin().WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when lock product",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductStockForUpdate)).
+					WithArgs(productID).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when product to lock not found",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductStockForUpdate)).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"stock"}))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when update product",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductStockForUpdate)).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(productStock))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryUpdateProduct)).
+					WithArgs(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, &productUpdatedAt, &productUpdatedBy, productVersion).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when product not found",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductStockForUpdate)).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(productStock))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryUpdateProduct)).
+					WithArgs(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, &productUpdatedAt, &productUpdatedBy, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when create product event",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductStockForUpdate)).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(productStock))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryUpdateProduct)).
+					WithArgs(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, &productUpdatedAt, &productUpdatedBy, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, &productUpdatedAt, &productUpdatedBy, nil, nil, productVersion+1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventUpdated, productEventPayload{id: productID, version: productVersion + 1}).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success update product",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductStockForUpdate)).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(productStock))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryUpdateProduct)).
+					WithArgs(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, &productUpdatedAt, &productUpdatedBy, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, &productUpdatedAt, &productUpdatedBy, nil, nil, productVersion+1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventUpdated, productEventPayload{id: productID, version: productVersion + 1}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error when create stock changed event",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductStockForUpdate)).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(productStock - 2))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryUpdateProduct)).
+					WithArgs(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, &productUpdatedAt, &productUpdatedBy, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, &productUpdatedAt, &productUpdatedBy, nil, nil, productVersion+1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventUpdated, productEventPayload{id: productID, version: productVersion + 1}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateStockMovements+"(?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.StockMovementAdjustment, 2, productStock, &reasonProductUpdate, nil, productUpdatedBy, productUpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.StockEventChanged, stockEventPayload{previousStock: productStock - 2, stock: productStock}).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success update product with stock change",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetProductStockForUpdate)).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(productStock - 2))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryUpdateProduct)).
+					WithArgs(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, &productUpdatedAt, &productUpdatedBy, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, &productUpdatedAt, &productUpdatedBy, nil, nil, productVersion+1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventUpdated, productEventPayload{id: productID, version: productVersion + 1}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateStockMovements+"(?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.StockMovementAdjustment, 2, productStock, &reasonProductUpdate, nil, productUpdatedBy, productUpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.StockEventChanged, stockEventPayload{previousStock: productStock - 2, stock: productStock}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectCommit()
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.UpdateProduct(tt.args.ctx, tt.args.product)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.UpdateProduct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepository_DeleteProduct(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		product domain.Product
+	}
+
+	product := domain.Product{
+		ID:        productID,
+		DeletedAt: &productDeletedAt,
+		DeletedBy: &productDeletedBy,
+		Version:   productVersion,
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error when begin transaction",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin().WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when delete product",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryDeleteProduct)).
+					WithArgs(productID, &productDeletedAt, &productDeletedBy, productVersion).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when product not found or already deleted",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryDeleteProduct)).
+					WithArgs(productID, &productDeletedAt, &productDeletedBy, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when create product event",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryDeleteProduct)).
+					WithArgs(productID, &productDeletedAt, &productDeletedBy, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, &productUpdatedAt, &productUpdatedBy, &productDeletedAt, &productDeletedBy, productVersion+1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventDeleted, productEventPayload{id: productID, version: productVersion + 1}).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success delete product",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryDeleteProduct)).
+					WithArgs(productID, &productDeletedAt, &productDeletedBy, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, &productUpdatedAt, &productUpdatedBy, &productDeletedAt, &productDeletedBy, productVersion+1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventDeleted, productEventPayload{id: productID, version: productVersion + 1}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectCommit()
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.DeleteProduct(tt.args.ctx, tt.args.product)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.DeleteProduct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepository_RestoreProduct(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		product domain.Product
+	}
+
+	product := domain.Product{
+		ID:        productID,
+		UpdatedAt: &productUpdatedAt,
+		UpdatedBy: &productUpdatedBy,
+		Version:   productVersion,
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error when begin transaction",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin().WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when restore product",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryRestoreProduct)).
+					WithArgs(productID, &productUpdatedAt, &productUpdatedBy, productVersion).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when product is not deleted or its version changed",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryRestoreProduct)).
+					WithArgs(productID, &productUpdatedAt, &productUpdatedBy, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when name is taken by another product",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryRestoreProduct)).
+					WithArgs(productID, &productUpdatedAt, &productUpdatedBy, productVersion).
+					WillReturnError(&pq.Error{Code: "23505"})
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when create product event",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryRestoreProduct)).
+					WithArgs(productID, &productUpdatedAt, &productUpdatedBy, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, &productUpdatedAt, &productUpdatedBy, nil, nil, productVersion+1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventRestored, productEventPayload{id: productID, version: productVersion + 1}).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success restore product",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryRestoreProduct)).
+					WithArgs(productID, &productUpdatedAt, &productUpdatedBy, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, &productUpdatedAt, &productUpdatedBy, nil, nil, productVersion+1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventRestored, productEventPayload{id: productID, version: productVersion + 1}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectCommit()
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.RestoreProduct(tt.args.ctx, tt.args.product)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.RestoreProduct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepository_PurgeProduct(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		product domain.Product
+	}
+
+	product := domain.Product{
+		ID:      productID,
+		Version: productVersion,
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error when begin transaction",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin().WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when purge product discount",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteProductDiscount)).
+					WithArgs(productID).
+					WillReturnError(errors.New("error"))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when product not found or its version changed",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteProductDiscount)).
+					WithArgs(productID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryPurgeProduct)).
+					WithArgs(productID, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}))
+				mockdb.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success purge product",
+			args: args{
+				ctx:     ctx,
+				product: product,
+			},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteProductDiscount)).
+					WithArgs(productID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryPurgeProduct)).
+					WithArgs(productID, productVersion).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}).
+						AddRow(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock, productCreatedAt, productCreatedBy, &productUpdatedAt, &productUpdatedBy, nil, nil, productVersion))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventPurged, productEventPayload{id: productID, version: productVersion}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectCommit()
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.PurgeProduct(tt.args.ctx, tt.args.product)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.PurgeProduct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepository_CreateProductDiscount(t *testing.T) {
+	discount := domain.ProductDiscount{
+		ProductID:       productID,
+		DiscountPercent: discountPercent,
+		StartDate:       discountStartDate,
+		EndDate:         discountEndDate,
+		MaxPurchaseQty:  &discountMaxPurchaseQty,
+		CreatedAt:       productCreatedAt,
+		CreatedBy:       productCreatedBy,
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "error when product already has a discount",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductDiscount)).
+					WithArgs(productID, discountPercent, discountStartDate, discountEndDate, &discountMaxPurchaseQty, productCreatedAt, productCreatedBy).
+					WillReturnError(&pq.Error{Code: "23505"})
+			},
+			wantErr: dbutil.ErrDataAlreadyExist,
+		},
+		{
+			name: "success create product discount",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductDiscount)).
+					WithArgs(productID, discountPercent, discountStartDate, discountEndDate, &discountMaxPurchaseQty, productCreatedAt, productCreatedBy).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryCreateProductDiscount))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.CreateProductDiscount(ctx, discount)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ProductRepository.CreateProductDiscount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProductRepository_UpdateProductDiscount(t *testing.T) {
+	discount := domain.ProductDiscount{
+		ProductID:       productID,
+		DiscountPercent: discountPercent,
+		StartDate:       discountStartDate,
+		EndDate:         discountEndDate,
+		UpdatedAt:       &productUpdatedAt,
+		UpdatedBy:       &productUpdatedBy,
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error when product has no discount",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryUpdateProductDiscount)).
+					WithArgs(productID, discountPercent, discountStartDate, discountEndDate, nil, &productUpdatedAt, &productUpdatedBy).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success update product discount",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryUpdateProductDiscount)).
+					WithArgs(productID, discountPercent, discountStartDate, discountEndDate, nil, &productUpdatedAt, &productUpdatedBy).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryUpdateProductDiscount))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.UpdateProductDiscount(ctx, discount)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.UpdateProductDiscount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProductRepository_DeleteProductDiscount(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error when product has no discount",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteProductDiscount)).
+					WithArgs(productID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success delete product discount",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteProductDiscount)).
+					WithArgs(productID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryDeleteProductDiscount))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.DeleteProductDiscount(ctx, productID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.DeleteProductDiscount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProductRepository_CreateStockMovement(t *testing.T) {
+	uuidutil.UUIDHelper = mockUUIDHelper{id: productID}
+
+	errMock := errors.New("error")
+	movement := domain.StockMovement{
+		ProductID:   productID,
+		Type:        constant.StockMovementReceipt,
+		Quantity:    20,
+		Reason:      &movementReason,
+		ReferenceID: &movementReferenceID,
+		Actor:       movementActor,
+		CreatedAt:   movementCreatedAt,
+	}
+
+	productColumns := []string{"id", "category_id", "supplier_id", "unit_id", "name", "description", "base_price", "stock", "created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by", "version"}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.StockMovement
+		wantErr error
+	}{
+		{
+			name: "error when product not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryMoveProductStock)).
+					WithArgs(productID, 20, movementCreatedAt, movementActor).
+					WillReturnRows(sqlmock.NewRows(productColumns))
+				mockdb.ExpectRollback()
+			},
+			wantErr: dbutil.ErrDataNotFound,
+		},
+		{
+			name: "error when stock goes below zero",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryMoveProductStock)).
+					WithArgs(productID, 20, movementCreatedAt, movementActor).
+					WillReturnError(&pq.Error{Code: "23514"})
+				mockdb.ExpectRollback()
+			},
+			wantErr: dbutil.ErrDataOutOfRange,
+		},
+		{
+			name: "error when reference already recorded",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryMoveProductStock)).
+					WithArgs(productID, 20, movementCreatedAt, movementActor).
+					WillReturnRows(sqlmock.NewRows(productColumns).
+						AddRow(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock+20, productCreatedAt, productCreatedBy, &movementCreatedAt, &movementActor, nil, nil, productVersion+1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventUpdated, productEventPayload{id: productID, version: productVersion + 1}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateStockMovements+"(?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.StockMovementReceipt, 20, productStock+20, &movementReason, &movementReferenceID, movementActor, movementCreatedAt).
+					WillReturnError(&pq.Error{Code: "23505"})
+				mockdb.ExpectRollback()
+			},
+			wantErr: dbutil.ErrDataAlreadyExist,
+		},
+		{
+			name: "error when create stock changed event",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryMoveProductStock)).
+					WithArgs(productID, 20, movementCreatedAt, movementActor).
+					WillReturnRows(sqlmock.NewRows(productColumns).
+						AddRow(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock+20, productCreatedAt, productCreatedBy, &movementCreatedAt, &movementActor, nil, nil, productVersion+1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventUpdated, productEventPayload{id: productID, version: productVersion + 1}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateStockMovements+"(?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.StockMovementReceipt, 20, productStock+20, &movementReason, &movementReferenceID, movementActor, movementCreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.StockEventChanged, stockEventPayload{previousStock: productStock, stock: productStock + 20}).
+					WillReturnError(errMock)
+				mockdb.ExpectRollback()
+			},
+			wantErr: errMock,
+		},
+		{
+			name: "success create stock movement",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectBegin()
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryMoveProductStock)).
+					WithArgs(productID, 20, movementCreatedAt, movementActor).
+					WillReturnRows(sqlmock.NewRows(productColumns).
+						AddRow(productID, categoryID, supplierID, unitID, productName, &productDescription, float64(productBasePrice), productStock+20, productCreatedAt, productCreatedBy, &movementCreatedAt, &movementActor, nil, nil, productVersion+1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.ProductEventUpdated, productEventPayload{id: productID, version: productVersion + 1}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateStockMovements+"(?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.StockMovementReceipt, 20, productStock+20, &movementReason, &movementReferenceID, movementActor, movementCreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateProductEvents+"(?, ?, ?, ?)")).
+					WithArgs(productID, productID, constant.StockEventChanged, stockEventPayload{previousStock: productStock, stock: productStock + 20}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockdb.ExpectCommit()
+			},
+			wantRes: domain.StockMovement{
+				ID:          productID,
+				ProductID:   productID,
+				Type:        constant.StockMovementReceipt,
+				Quantity:    20,
+				StockAfter:  productStock + 20,
+				Reason:      &movementReason,
+				ReferenceID: &movementReferenceID,
+				Actor:       movementActor,
+				CreatedAt:   movementCreatedAt,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.CreateStockMovement(ctx, movement)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ProductRepository.CreateStockMovement() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("ProductRepository.CreateStockMovement() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepository_GetListStockMovement(t *testing.T) {
+	movementColumns := []string{"id", "product_id", "movement_type", "quantity", "stock_after", "reason", "reference_id", "actor", "created_at"}
+
+	tests := []struct {
+		name    string
+		filter  domain.StockMovementFilter
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.StockHistoryPage
+		wantErr bool
+	}{
+		{
+			name: "error when count stock movements",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountStockMovement)).
+					WithArgs(productID).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when stock movement is malformed",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountStockMovement)).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListStockMovement+" ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?")).
+					WithArgs(productID, 2, 2).
+					WillReturnRows(sqlmock.NewRows(movementColumns).
+						AddRow(productID, productID, constant.StockMovementSale, 0, productStock, nil, nil, movementActor, movementCreatedAt))
+			},
+			wantErr: true,
+		},
+		{
+			name:   "success get stock movements of a type",
+			filter: domain.StockMovementFilter{Type: constant.StockMovementReceipt},
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountStockMovement+" AND movement_type = ?")).
+					WithArgs(productID, constant.StockMovementReceipt).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListStockMovement+" AND movement_type = ? ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?")).
+					WithArgs(productID, constant.StockMovementReceipt, 2, 2).
+					WillReturnRows(sqlmock.NewRows(movementColumns).
+						AddRow(productID, productID, constant.StockMovementReceipt, 20, productStock, &movementReason, &movementReferenceID, movementActor, movementCreatedAt))
+			},
+			wantRes: domain.StockHistoryPage{
+				Movements: domain.StockMovements{
+					{
+						ID:          productID,
+						ProductID:   productID,
+						Type:        constant.StockMovementReceipt,
+						Quantity:    20,
+						StockAfter:  productStock,
+						Reason:      &movementReason,
+						ReferenceID: &movementReferenceID,
+						Actor:       movementActor,
+						CreatedAt:   movementCreatedAt,
+					},
+				},
+				Total: 3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			dbx := sqlx.NewDb(db, "sqlmock")
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetListStockMovement(ctx, productID, tt.filter, domain.ListPage{Limit: 2, Number: 2})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductRepository.GetListStockMovement() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("ProductRepository.GetListStockMovement() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
 			}
 		})
 	}
@@ -5,34 +5,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
 )
 
 type (
-	Category struct {
-		CategoryID  uuid.UUID  `db:"category_id"`
-		Name        string     `db:"name"`
-		Description string     `db:"description"`
-		CreatedAt   time.Time  `db:"created_at"`
-		CreatedBy   string     `db:"created_by"`
-		UpdatedAt   *time.Time `db:"updated_at"`
-		UpdatedBy   *string    `db:"updated_by"`
-	}
-
-	Supplier struct {
-		SupplierID  uuid.UUID  `db:"supplier_id"`
-		Name        string     `db:"name"`
-		ContactInfo string     `db:"contact_info"`
-		CreatedAt   time.Time  `db:"created_at"`
-		CreatedBy   string     `db:"created_by"`
-		UpdatedAt   *time.Time `db:"updated_at"`
-		UpdatedBy   *string    `db:"updated_by"`
-	}
-
-	Unit struct {
-		UnitID uuid.UUID `db:"unit_id"`
-		Name   string    `db:"name"`
-	}
-
 	Product struct {
 		ID          uuid.UUID  `db:"id"`
 		CategoryId  uuid.UUID  `db:"category_id"`
@@ -46,19 +22,59 @@ type (
 		CreatedBy   string     `db:"created_by"`
 		UpdatedAt   *time.Time `db:"updated_at"`
 		UpdatedBy   *string    `db:"updated_by"`
+		DeletedAt   *time.Time `db:"deleted_at"`
+		DeletedBy   *string    `db:"deleted_by"`
+		Version     int        `db:"version"`
+		ProductDiscount
+		ProductMatch
+		ProductRelations
 	}
 
+	// ProductDiscount holds the columns of the discount LEFT JOINed to a product,
+	// all of them are nil when the product has no discount.
 	ProductDiscount struct {
-		ProductID         uuid.UUID  `db:"id"`
-		Discount          float64    `db:"discount"`
-		DiscountPercent   float64    `db:"discount_percent"`
-		DiscountStartDate time.Time  `db:"discount_start_date"`
-		DiscountEndDate   time.Time  `db:"discount_end_date"`
-		MaxPurchaseQty    int        `db:"max_purchase_qty"`
-		CreatedAt         time.Time  `db:"created_at"`
-		CreatedBy         string     `db:"created_by"`
-		UpdatedAt         *time.Time `db:"updated_at"`
-		UpdatedBy         *string    `db:"updated_by"`
+		DiscountPercent   *float64   `db:"discount_percent"`
+		DiscountStartDate *time.Time `db:"discount_start_date"`
+		DiscountEndDate   *time.Time `db:"discount_end_date"`
+		MaxPurchaseQty    *int       `db:"max_purchase_qty"`
+	}
+
+	// ProductRelations holds the names of the records JOINed to a product, all of them
+	// are nil when the query does not read them.
+	ProductRelations struct {
+		CategoryName *string `db:"category_name"`
+		SupplierName *string `db:"supplier_name"`
+		UnitName     *string `db:"unit_name"`
+	}
+
+	ProductSuggestion struct {
+		ID   uuid.UUID `db:"id"`
+		Name string    `db:"name"`
+	}
+
+	ProductSuggestions []ProductSuggestion
+
+	FacetCount struct {
+		ID    uuid.UUID `db:"id"`
+		Name  string    `db:"name"`
+		Count int       `db:"count"`
+	}
+
+	FacetCounts []FacetCount
+
+	PriceBucketCount struct {
+		Bucket int `db:"bucket"`
+		Count  int `db:"count"`
+	}
+
+	PriceBucketCounts []PriceBucketCount
+
+	// ProductMatch holds the columns computed by a full-text search, all of them are nil
+	// when the product was not listed by a search.
+	ProductMatch struct {
+		SearchRank           *float64 `db:"search_rank"`
+		NameHighlight        *string  `db:"name_highlight"`
+		DescriptionHighlight *string  `db:"description_highlight"`
 	}
 )
 
@@ -111,9 +127,75 @@ func (p Product) Validate() bool {
 		return false
 	}
 
+	if p.DeletedAt != nil && p.DeletedAt.IsZero() {
+		return false
+	}
+
+	if p.DeletedBy != nil && *p.DeletedBy == "" {
+		return false
+	}
+
+	if p.Version < 1 {
+		return false
+	}
+
+	return p.ProductDiscount.Validate()
+}
+
+func (d ProductDiscount) Validate() bool {
+	if d.DiscountPercent == nil {
+		return true
+	}
+
+	if *d.DiscountPercent <= 0 || *d.DiscountPercent > 100 {
+		return false
+	}
+
+	if d.DiscountStartDate == nil || d.DiscountEndDate == nil {
+		return false
+	}
+
+	if d.MaxPurchaseQty != nil && *d.MaxPurchaseQty <= 0 {
+		return false
+	}
+
 	return true
 }
 
+func (d ProductDiscount) ToModel(productID uuid.UUID) *domain.ProductDiscount {
+	if d.DiscountPercent == nil {
+		return nil
+	}
+
+	return &domain.ProductDiscount{
+		ProductID:       productID,
+		DiscountPercent: *d.DiscountPercent,
+		StartDate:       *d.DiscountStartDate,
+		EndDate:         *d.DiscountEndDate,
+		MaxPurchaseQty:  d.MaxPurchaseQty,
+	}
+}
+
+func (m ProductMatch) ToModel() *domain.ProductMatch {
+	if m.SearchRank == nil {
+		return nil
+	}
+
+	match := &domain.ProductMatch{
+		Rank: *m.SearchRank,
+	}
+
+	if m.NameHighlight != nil {
+		match.NameHighlight = *m.NameHighlight
+	}
+
+	if m.DescriptionHighlight != nil {
+		match.DescriptionHighlight = *m.DescriptionHighlight
+	}
+
+	return match
+}
+
 func (p Product) ToModel() domain.Product {
 	return domain.Product{
 		ID:          p.ID,
@@ -128,9 +210,214 @@ func (p Product) ToModel() domain.Product {
 		CreatedBy:   p.CreatedBy,
 		UpdatedAt:   p.UpdatedAt,
 		UpdatedBy:   p.UpdatedBy,
+		DeletedAt:   p.DeletedAt,
+		DeletedBy:   p.DeletedBy,
+		Version:     p.Version,
+		Discount:    p.ProductDiscount.ToModel(p.ID),
+		Match:       p.ProductMatch.ToModel(),
+		Category:    toRelation(p.CategoryId, p.CategoryName),
+		Supplier:    toRelation(p.SupplierId, p.SupplierName),
+		Unit:        toRelation(p.UnitId, p.UnitName),
 	}
 }
 
+func toRelation(id uuid.UUID, name *string) *domain.ProductRelation {
+	if name == nil {
+		return nil
+	}
+
+	return &domain.ProductRelation{
+		ID:   id,
+		Name: *name,
+	}
+}
+
+// ProductEvent is the payload of the event recorded for a product change, the product as
+// it stands after the change, or before it when the product is purged.
+type ProductEvent struct {
+	ID          uuid.UUID  `json:"id"`
+	CategoryID  uuid.UUID  `json:"category_id"`
+	SupplierID  uuid.UUID  `json:"supplier_id"`
+	UnitID      uuid.UUID  `json:"unit_id"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description"`
+	BasePrice   float64    `json:"base_price"`
+	Stock       int        `json:"stock"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CreatedBy   string     `json:"created_by"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	UpdatedBy   *string    `json:"updated_by"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+	DeletedBy   *string    `json:"deleted_by"`
+	Version     int        `json:"version"`
+}
+
+func toProductEvent(product domain.Product) ProductEvent {
+	return ProductEvent{
+		ID:          product.ID,
+		CategoryID:  product.CategoryID,
+		SupplierID:  product.SupplierID,
+		UnitID:      product.UnitID,
+		Name:        product.Name,
+		Description: product.Description,
+		BasePrice:   product.BasePrice,
+		Stock:       product.Stock,
+		CreatedAt:   product.CreatedAt,
+		CreatedBy:   product.CreatedBy,
+		UpdatedAt:   product.UpdatedAt,
+		UpdatedBy:   product.UpdatedBy,
+		DeletedAt:   product.DeletedAt,
+		DeletedBy:   product.DeletedBy,
+		Version:     product.Version,
+	}
+}
+
+// StockEvent is the payload of the stock.changed event, recorded with each movement of
+// the stock ledger but the ones opening the stock of a new product.
+type StockEvent struct {
+	ProductID     uuid.UUID `json:"product_id"`
+	MovementID    uuid.UUID `json:"movement_id"`
+	MovementType  string    `json:"movement_type"`
+	Quantity      int       `json:"quantity"`
+	PreviousStock int       `json:"previous_stock"`
+	Stock         int       `json:"stock"`
+	Reason        *string   `json:"reason"`
+	ReferenceID   *string   `json:"reference_id"`
+	Version       int       `json:"version"`
+	ChangedAt     time.Time `json:"changed_at"`
+	ChangedBy     string    `json:"changed_by"`
+}
+
+// toStockChangedEvent returns the event of a movement, product being the product as it
+// stands after the movement.
+func toStockChangedEvent(movement domain.StockMovement, product domain.Product) outboxEvent {
+	return outboxEvent{
+		productID: product.ID,
+		eventType: constant.StockEventChanged,
+		payload: StockEvent{
+			ProductID:     product.ID,
+			MovementID:    movement.ID,
+			MovementType:  movement.Type,
+			Quantity:      movement.Quantity,
+			PreviousStock: movement.StockAfter - movement.Quantity,
+			Stock:         movement.StockAfter,
+			Reason:        movement.Reason,
+			ReferenceID:   movement.ReferenceID,
+			Version:       product.Version,
+			ChangedAt:     movement.CreatedAt,
+			ChangedBy:     movement.Actor,
+		},
+	}
+}
+
+// toOpeningStockMovement returns the movement opening the stock of a new product.
+func toOpeningStockMovement(product domain.Product) domain.StockMovement {
+	reason := constant.StockMovementReasonInitialStock
+
+	return domain.StockMovement{
+		ProductID:  product.ID,
+		Type:       constant.StockMovementAdjustment,
+		Quantity:   product.Stock,
+		StockAfter: product.Stock,
+		Reason:     &reason,
+		Actor:      product.CreatedBy,
+		CreatedAt:  product.CreatedAt,
+	}
+}
+
+// toUpdateStockMovement returns the movement of an update setting the stock of a product
+// to another value, product being the product as it stands after the update.
+func toUpdateStockMovement(previousStock int, product domain.Product) domain.StockMovement {
+	reason := constant.StockMovementReasonProductUpdate
+	movement := domain.StockMovement{
+		ProductID: product.ID,
+		Type:      constant.StockMovementAdjustment,
+		Quantity:  product.Stock - previousStock,
+		Reason:    &reason,
+	}
+
+	if product.UpdatedAt != nil {
+		movement.CreatedAt = *product.UpdatedAt
+	}
+
+	if product.UpdatedBy != nil {
+		movement.Actor = *product.UpdatedBy
+	}
+
+	return movement
+}
+
+// outboxEvent is an event waiting to be recorded in the outbox along with its payload.
+type outboxEvent struct {
+	productID uuid.UUID
+	eventType string
+	payload   any
+}
+
+type StockMovement struct {
+	ID          uuid.UUID `db:"id"`
+	ProductID   uuid.UUID `db:"product_id"`
+	Type        string    `db:"movement_type"`
+	Quantity    int       `db:"quantity"`
+	StockAfter  int       `db:"stock_after"`
+	Reason      *string   `db:"reason"`
+	ReferenceID *string   `db:"reference_id"`
+	Actor       string    `db:"actor"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (m StockMovement) Validate() bool {
+	if m.ID == uuid.Nil || m.ProductID == uuid.Nil {
+		return false
+	}
+
+	if m.Type == "" || m.Quantity == 0 || m.StockAfter < 0 {
+		return false
+	}
+
+	if m.Actor == "" || m.CreatedAt.IsZero() {
+		return false
+	}
+
+	return true
+}
+
+func (m StockMovement) ToModel() domain.StockMovement {
+	return domain.StockMovement{
+		ID:          m.ID,
+		ProductID:   m.ProductID,
+		Type:        m.Type,
+		Quantity:    m.Quantity,
+		StockAfter:  m.StockAfter,
+		Reason:      m.Reason,
+		ReferenceID: m.ReferenceID,
+		Actor:       m.Actor,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+type StockMovements []StockMovement
+
+func (m StockMovements) Validate() bool {
+	for _, movement := range m {
+		if !movement.Validate() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m StockMovements) ToModel() domain.StockMovements {
+	movements := make(domain.StockMovements, 0, len(m))
+
+	for _, movement := range m {
+		movements = append(movements, movement.ToModel())
+	}
+
+	return movements
+}
+
 type Products []Product
 
 func (p Products) Validate() bool {
@@ -152,3 +439,60 @@ func (p Products) ToModel() domain.Products {
 
 	return products
 }
+
+func (p ProductSuggestions) ToModel() domain.ProductSuggestions {
+	suggestions := make(domain.ProductSuggestions, 0, len(p))
+
+	for _, suggestion := range p {
+		suggestions = append(suggestions, domain.ProductSuggestion{
+			ID:   suggestion.ID,
+			Name: suggestion.Name,
+		})
+	}
+
+	return suggestions
+}
+
+func (f FacetCounts) ToModel() []domain.FacetCount {
+	counts := make([]domain.FacetCount, 0, len(f))
+
+	for _, count := range f {
+		counts = append(counts, domain.FacetCount{
+			ID:    count.ID,
+			Name:  count.Name,
+			Count: count.Count,
+		})
+	}
+
+	return counts
+}
+
+// ToModel lays the counts out over every bucket of bounds, empty ones included. Following
+// width_bucket, bucket i holds the prices from bounds[i-1] to bounds[i], bucket 0 the prices
+// below bounds[0] and is only listed when some product falls in it.
+func (b PriceBucketCounts) ToModel(bounds []float64) []domain.PriceBucketCount {
+	counts := make(map[int]int, len(b))
+	for _, count := range b {
+		counts[count.Bucket] = count.Count
+	}
+
+	var buckets []domain.PriceBucketCount
+	if counts[0] > 0 {
+		buckets = append(buckets, domain.PriceBucketCount{Max: &bounds[0], Count: counts[0]})
+	}
+
+	for i := range bounds {
+		bucket := domain.PriceBucketCount{
+			Min:   &bounds[i],
+			Count: counts[i+1],
+		}
+
+		if i+1 < len(bounds) {
+			bucket.Max = &bounds[i+1]
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets
+}
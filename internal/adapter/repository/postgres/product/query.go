@@ -12,11 +12,205 @@ var (
 			base_price, 
 			stock, 
 			created_at, 
+			created_by,
+			version
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	// queryCreateProducts is followed by one row of placeholders per product, in the
+	// order of its columns.
+	queryCreateProducts = `
+		INSERT INTO products (
+			id, 
+			category_id, 
+			supplier_id, 
+			unit_id, 
+			name, 
+			description, 
+			base_price, 
+			stock, 
+			created_at, 
+			created_by,
+			version
+		)
+		VALUES
+	`
+
+	// queryCreateProductEvents is followed by one row of placeholders per event, in the
+	// order of its columns.
+	queryCreateProductEvents = `
+		INSERT INTO product_events (
+			event_id, 
+			product_id, 
+			event_type, 
+			payload
+		)
+		VALUES
+	`
+
+	// queryCreateStockMovements is followed by one row of placeholders per movement, in
+	// the order of its columns.
+	queryCreateStockMovements = `
+		INSERT INTO stock_movements (
+			id, 
+			product_id, 
+			movement_type, 
+			quantity, 
+			stock_after, 
+			reason, 
+			reference_id, 
+			actor, 
+			created_at
+		)
+		VALUES
+	`
+
+	queryCountStockMovement = `
+		SELECT COUNT(*)
+		FROM stock_movements
+		WHERE product_id = ?
+	`
+
+	queryGetListStockMovement = `
+		SELECT
+			id,
+			product_id,
+			movement_type,
+			quantity,
+			stock_after,
+			reason,
+			reference_id,
+			actor,
+			created_at
+		FROM stock_movements
+		WHERE product_id = ?
+	`
+
+	// queryReturningProduct follows the writes of a product, which return the product as it
+	// stands after the write for the event of the change.
+	queryReturningProduct = `
+		RETURNING
+			id,
+			category_id,
+			supplier_id,
+			unit_id,
+			name,
+			description,
+			base_price,
+			stock,
+			created_at,
+			created_by,
+			updated_at,
+			updated_by,
+			deleted_at,
+			deleted_by,
+			version
+	`
+
+	queryGetProductStockForUpdate = `
+		SELECT stock
+		FROM products
+		WHERE 
+			id = $1 AND 
+			deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	queryMoveProductStock = `
+		UPDATE products
+		SET
+			stock = stock + $2,
+			updated_at = $3,
+			updated_by = $4,
+			version = version + 1
+		WHERE 
+			id = $1 AND 
+			deleted_at IS NULL
+	` + queryReturningProduct
+
+	queryUpdateProduct = `
+		UPDATE products
+		SET
+			category_id = $2,
+			supplier_id = $3,
+			unit_id = $4,
+			name = $5,
+			description = $6,
+			base_price = $7,
+			stock = $8,
+			updated_at = $9,
+			updated_by = $10,
+			version = version + 1
+		WHERE 
+			id = $1 AND 
+			version = $11 AND 
+			deleted_at IS NULL
+	` + queryReturningProduct
+
+	queryDeleteProduct = `
+		UPDATE products
+		SET
+			deleted_at = $2,
+			deleted_by = $3,
+			version = version + 1
+		WHERE 
+			id = $1 AND 
+			version = $4 AND 
+			deleted_at IS NULL
+	` + queryReturningProduct
+
+	queryRestoreProduct = `
+		UPDATE products
+		SET
+			deleted_at = NULL,
+			deleted_by = NULL,
+			updated_at = $2,
+			updated_by = $3,
+			version = version + 1
+		WHERE 
+			id = $1 AND 
+			version = $4 AND 
+			deleted_at IS NOT NULL
+	` + queryReturningProduct
+
+	queryCreateProductDiscount = `
+		INSERT INTO product_discounts (
+			product_id, 
+			discount_percent, 
+			discount_start_date, 
+			discount_end_date, 
+			max_purchase_qty, 
+			created_at, 
 			created_by
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	queryUpdateProductDiscount = `
+		UPDATE product_discounts
+		SET
+			discount_percent = $2,
+			discount_start_date = $3,
+			discount_end_date = $4,
+			max_purchase_qty = $5,
+			updated_at = $6,
+			updated_by = $7
+		WHERE product_id = $1
+	`
+
+	queryDeleteProductDiscount = `
+		DELETE FROM product_discounts
+		WHERE product_id = $1
 	`
 
+	queryPurgeProduct = `
+		DELETE FROM products
+		WHERE 
+			id = $1 AND 
+			version = $2
+	` + queryReturningProduct
+
 	queryListProduct = `
 		SELECT
 			p.id,
@@ -30,22 +224,209 @@ var (
 			p.created_at,
 			p.created_by,
 			p.updated_at,
-			p.updated_by
-		FROM products p		
+			p.updated_by,
+			p.deleted_at,
+			p.deleted_by,
+			p.version,
+			d.discount_percent,
+			d.discount_start_date,
+			d.discount_end_date,
+			d.max_purchase_qty,
+			c.name AS category_name,
+			sp.name AS supplier_name,
+			u.unit_name AS unit_name
+		FROM products p
+		JOIN categories c on p.category_id = c.id
+		LEFT JOIN suppliers sp on p.supplier_id = sp.id
+		JOIN units u on p.unit_id = u.id
+		LEFT JOIN product_discounts d on d.product_id = p.id
 	`
 
 	queryGetListProduct = queryListProduct + `
+		WHERE 1=1
+	`
+
+	// querySearchProduct lists products along with their full-text search rank and
+	// highlights. Its first placeholder is the search query.
+	querySearchProduct = `
+		SELECT
+			p.id,
+			p.category_id,
+			p.supplier_id,
+			p.unit_id,
+			p.name,
+			p.description,
+			p.base_price,
+			p.stock,
+			p.created_at,
+			p.created_by,
+			p.updated_at,
+			p.updated_by,
+			p.deleted_at,
+			p.deleted_by,
+			p.version,
+			d.discount_percent,
+			d.discount_start_date,
+			d.discount_end_date,
+			d.max_purchase_qty,
+			c.name AS category_name,
+			sp.name AS supplier_name,
+			u.unit_name AS unit_name,
+			r.rank AS search_rank,
+			ts_headline('simple', p.name, s.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS name_highlight,
+			ts_headline('simple', COALESCE(p.description, ''), s.query, 'StartSel=<mark>, StopSel=</mark>') AS description_highlight
+		FROM products p
+		CROSS JOIN websearch_to_tsquery('simple', ?) s(query)
+		CROSS JOIN LATERAL (SELECT ts_rank(p.search_vector, s.query) AS rank) r
+		JOIN categories c on p.category_id = c.id
+		LEFT JOIN suppliers sp on p.supplier_id = sp.id
+		JOIN units u on p.unit_id = u.id
+		LEFT JOIN product_discounts d on d.product_id = p.id
+		WHERE 1=1
+	`
+
+	// queryFuzzySearchProduct lists products along with the similarity of their name to
+	// a search query, which is its first placeholder. Names are not highlighted, as there
+	// are no exact words to mark.
+	queryFuzzySearchProduct = `
+		SELECT
+			p.id,
+			p.category_id,
+			p.supplier_id,
+			p.unit_id,
+			p.name,
+			p.description,
+			p.base_price,
+			p.stock,
+			p.created_at,
+			p.created_by,
+			p.updated_at,
+			p.updated_by,
+			p.deleted_at,
+			p.deleted_by,
+			p.version,
+			d.discount_percent,
+			d.discount_start_date,
+			d.discount_end_date,
+			d.max_purchase_qty,
+			c.name AS category_name,
+			sp.name AS supplier_name,
+			u.unit_name AS unit_name,
+			r.rank AS search_rank
+		FROM products p
+		CROSS JOIN LATERAL (SELECT word_similarity(?, p.name) AS rank) r
+		JOIN categories c on p.category_id = c.id
+		LEFT JOIN suppliers sp on p.supplier_id = sp.id
+		JOIN units u on p.unit_id = u.id
+		LEFT JOIN product_discounts d on d.product_id = p.id
+		WHERE 1=1
+	`
+
+	// querySuggestProduct lists the products whose name starts with or is similar to a
+	// prefix. Its first placeholder is the prefix followed by a wildcard, the second the
+	// prefix itself.
+	querySuggestProduct = `
+		SELECT
+			p.id,
+			p.name
+		FROM products p
+		JOIN categories c on p.category_id = c.id
+		WHERE (p.name ILIKE ? OR ? <% p.name)
+	`
+
+	queryCountProductByCategory = `
+		SELECT
+			c.id,
+			c.name,
+			COUNT(*) AS count
+		FROM products p
+		JOIN categories c on p.category_id = c.id
+		WHERE 1=1
+	`
+
+	queryCountProductBySupplier = `
+		SELECT
+			sp.id,
+			sp.name,
+			COUNT(*) AS count
+		FROM products p
+		JOIN categories c on p.category_id = c.id
+		JOIN suppliers sp on p.supplier_id = sp.id
+		WHERE 1=1
+	`
+
+	queryCountProductByUnit = `
+		SELECT
+			u.id,
+			u.unit_name AS name,
+			COUNT(*) AS count
+		FROM products p
+		JOIN categories c on p.category_id = c.id
+		JOIN units u on p.unit_id = u.id
+		WHERE 1=1
+	`
+
+	// queryCountProductByPriceBucket counts products by the index width_bucket gives their
+	// price among the bucket bounds, which are its first placeholder.
+	queryCountProductByPriceBucket = `
+		SELECT
+			width_bucket(p.base_price, ?::numeric[]) AS bucket,
+			COUNT(*) AS count
+		FROM products p
+		JOIN categories c on p.category_id = c.id
+		WHERE 1=1
+	`
+
+	queryCountProduct = `
+		SELECT COUNT(*)
+		FROM products p
 		JOIN categories c on p.category_id = c.id
 		WHERE 1=1
 	`
 
 	queryGetProductByID = queryListProduct + `
-		WHERE p.id = $1
+		WHERE 
+			p.id = $1 AND 
+			p.deleted_at IS NULL
+	`
+
+	queryGetDeletedProductByID = queryListProduct + `
+		WHERE 
+			p.id = $1 AND 
+			p.deleted_at IS NOT NULL
+	`
+
+	queryGetListProductByIDs = queryListProduct + `
+		WHERE 
+			p.id = ANY($1::uuid[]) AND 
+			p.deleted_at IS NULL
+	`
+
+	// queryGetExistingCategoryIDs, queryGetExistingSupplierIDs and queryGetExistingUnitIDs
+	// keep the IDs of an array that exist in their table.
+	queryGetExistingCategoryIDs = `SELECT id FROM categories WHERE id = ANY($1::uuid[])`
+	queryGetExistingSupplierIDs = `SELECT id FROM suppliers WHERE id = ANY($1::uuid[])`
+	queryGetExistingUnitIDs     = `SELECT id FROM units WHERE id = ANY($1::uuid[])`
+
+	// queryGetListProductByNames reads the products having any of the category and name
+	// pairs given as two arrays of the same length.
+	queryGetListProductByNames = queryListProduct + `
+		WHERE 
+			(p.category_id, p.name) IN (SELECT * FROM unnest($1::uuid[], $2::text[])) AND 
+			p.deleted_at IS NULL
+	`
+
+	queryGetListProductBySupplierID = queryListProduct + `
+		WHERE 
+			p.supplier_id = $1 AND 
+			p.deleted_at IS NULL
+		ORDER BY p.name ASC
 	`
 
 	queryGetProductByName = queryListProduct + `
 		WHERE 
 			p.category_id = $1 AND 
-			p.name = $2
+			p.name = $2 AND 
+			p.deleted_at IS NULL
 	`
 )
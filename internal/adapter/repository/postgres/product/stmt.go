@@ -10,43 +10,43 @@ func (repo *ProductRepository) prepareStatements() {
 	repo.statement = StatementList{}
 }
 
-func (repo *ProductRepository) prepareCreateProduct() {
+func (repo *ProductRepository) prepareListProduct() {
 	var (
 		err  error
 		stmt *sqlx.Stmt
 		db   = repo.db.Db
 	)
 
-	if stmt, err = db.Preparex(queryCreateProduct); err != nil {
-		log.Panic("[prepareCreateProduct] error:", err)
+	if stmt, err = db.Preparex(queryListProduct); err != nil {
+		log.Panic("[prepareListProduct] error:", err)
 	}
-	repo.statement.CreateProduct = stmt
+	repo.statement.ListProduct = stmt
 }
 
-func (repo *ProductRepository) prepareListProduct() {
+func (repo *ProductRepository) prepareGetProductByID() {
 	var (
 		err  error
 		stmt *sqlx.Stmt
 		db   = repo.db.Db
 	)
 
-	if stmt, err = db.Preparex(queryListProduct); err != nil {
-		log.Panic("[prepareListProduct] error:", err)
+	if stmt, err = db.Preparex(queryGetProductByID); err != nil {
+		log.Panic("[prepareGetProductByID] error:", err)
 	}
-	repo.statement.ListProduct = stmt
+	repo.statement.GetProductByID = stmt
 }
 
-func (repo *ProductRepository) prepareGetProductByID() {
+func (repo *ProductRepository) prepareGetDeletedProductByID() {
 	var (
 		err  error
 		stmt *sqlx.Stmt
 		db   = repo.db.Db
 	)
 
-	if stmt, err = db.Preparex(queryGetProductByID); err != nil {
-		log.Panic("[prepareGetProductByID] error:", err)
+	if stmt, err = db.Preparex(queryGetDeletedProductByID); err != nil {
+		log.Panic("[prepareGetDeletedProductByID] error:", err)
 	}
-	repo.statement.GetProductByID = stmt
+	repo.statement.GetDeletedProductByID = stmt
 }
 
 func (repo *ProductRepository) prepareGetProductByName() {
@@ -61,3 +61,81 @@ func (repo *ProductRepository) prepareGetProductByName() {
 	}
 	repo.statement.GetProductByName = stmt
 }
+
+func (repo *ProductRepository) prepareGetListProductByIDs() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetListProductByIDs); err != nil {
+		log.Panic("[prepareGetListProductByIDs] error:", err)
+	}
+	repo.statement.GetListProductByIDs = stmt
+}
+
+func (repo *ProductRepository) prepareGetListProductByNames() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetListProductByNames); err != nil {
+		log.Panic("[prepareGetListProductByNames] error:", err)
+	}
+	repo.statement.GetListProductByNames = stmt
+}
+
+func (repo *ProductRepository) prepareGetListProductBySupplierID() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetListProductBySupplierID); err != nil {
+		log.Panic("[prepareGetListProductBySupplierID] error:", err)
+	}
+	repo.statement.GetListProductBySupplierID = stmt
+}
+
+func (repo *ProductRepository) prepareCreateProductDiscount() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryCreateProductDiscount); err != nil {
+		log.Panic("[prepareCreateProductDiscount] error:", err)
+	}
+	repo.statement.CreateProductDiscount = stmt
+}
+
+func (repo *ProductRepository) prepareUpdateProductDiscount() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryUpdateProductDiscount); err != nil {
+		log.Panic("[prepareUpdateProductDiscount] error:", err)
+	}
+	repo.statement.UpdateProductDiscount = stmt
+}
+
+func (repo *ProductRepository) prepareDeleteProductDiscount() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryDeleteProductDiscount); err != nil {
+		log.Panic("[prepareDeleteProductDiscount] error:", err)
+	}
+	repo.statement.DeleteProductDiscount = stmt
+}
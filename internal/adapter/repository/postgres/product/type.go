@@ -15,13 +15,24 @@ type (
 	}
 
 	StatementList struct {
-		CreateProduct    *sqlx.Stmt
-		ListProduct      *sqlx.Stmt
-		GetProductByID   *sqlx.Stmt
-		GetProductByName *sqlx.Stmt
+		ListProduct                *sqlx.Stmt
+		GetProductByID             *sqlx.Stmt
+		GetDeletedProductByID      *sqlx.Stmt
+		GetProductByName           *sqlx.Stmt
+		GetListProductByIDs        *sqlx.Stmt
+		GetListProductByNames      *sqlx.Stmt
+		GetListProductBySupplierID *sqlx.Stmt
+		CreateProductDiscount      *sqlx.Stmt
+		UpdateProductDiscount      *sqlx.Stmt
+		DeleteProductDiscount      *sqlx.Stmt
 	}
 
 	InitAttribute struct {
 		DB DB
 	}
+
+	// productRows implements port.ProductRows over the rows of a query.
+	productRows struct {
+		rows *sqlx.Rows
+	}
 )
@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/supplier/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
+	"github.com/gunawanpras/be-product-service/pkg/util/uuidutil"
+)
+
+// CreateSupplier creates a new supplier in the system and assigns a new ID to it.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplier: domain.Supplier containing the details of the supplier to be created.
+//
+// Returns:
+// - res: uuid.UUID representing the ID of the newly created supplier.
+// - err: error if an error occurs during the creation process.
+func (repo *SupplierRepository) CreateSupplier(ctx context.Context, supplier domain.Supplier) (res uuid.UUID, err error) {
+	supplier.ID = uuidutil.UUIDHelper.New()
+
+	repo.prepareCreateSupplier()
+	_, err = repo.statement.CreateSupplier.ExecContext(ctx, supplier.ID, supplier.Name, newContactInfo(supplier.ContactInfo), supplier.CreatedAt, supplier.CreatedBy)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return supplier.ID, nil
+}
+
+// GetListSupplier retrieves a list of suppliers ordered by name, optionally filtered by
+// a partial, case insensitive match on the name.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplierName: The name of the supplier to filter by (can be a partial match).
+//
+// Returns:
+// - res: domain.Suppliers representing the list of suppliers that match the criteria.
+// - err: error if an error occurs during the retrieval process.
+func (repo *SupplierRepository) GetListSupplier(ctx context.Context, supplierName string) (res domain.Suppliers, err error) {
+	var (
+		query     []string = []string{queryGetListSupplier}
+		args      []any
+		supplier  Supplier
+		suppliers Suppliers
+	)
+
+	if supplierName != "" {
+		query = append(query, "AND LOWER(s.name) LIKE LOWER(?)")
+		args = append(args, "%"+supplierName+"%")
+	}
+
+	query = append(query, "ORDER BY s.name ASC")
+
+	finalQuery := strings.Join(query, " ")
+	finalQuery = repo.db.Db.Rebind(finalQuery)
+
+	rows, err := repo.db.Db.QueryxContext(ctx, finalQuery, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		supplier = Supplier{}
+		err = rows.StructScan(&supplier)
+		if err != nil {
+			return res, err
+		}
+
+		suppliers = append(suppliers, supplier)
+	}
+
+	if err = rows.Err(); err != nil {
+		return res, err
+	}
+
+	if !suppliers.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return suppliers.ToModel(), nil
+}
+
+// GetSupplierByID retrieves a supplier by ID from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplierID: The ID of the supplier to retrieve.
+//
+// Returns:
+// - res: domain.Supplier representing the supplier with the provided ID.
+// - err: error if an error occurs during the retrieval process.
+func (repo *SupplierRepository) GetSupplierByID(ctx context.Context, supplierID uuid.UUID) (res domain.Supplier, err error) {
+	var supplier Supplier
+
+	repo.prepareGetSupplierByID()
+	err = repo.statement.GetSupplierByID.QueryRowxContext(ctx, supplierID).StructScan(&supplier)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+
+	if !supplier.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return supplier.ToModel(), nil
+}
+
+// GetSupplierByName retrieves a supplier by its exact name from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplierName: The name of the supplier to retrieve.
+//
+// Returns:
+// - res: domain.Supplier representing the supplier with the provided name.
+// - err: error if an error occurs during the retrieval process.
+func (repo *SupplierRepository) GetSupplierByName(ctx context.Context, supplierName string) (res domain.Supplier, err error) {
+	var supplier Supplier
+
+	repo.prepareGetSupplierByName()
+	err = repo.statement.GetSupplierByName.QueryRowxContext(ctx, supplierName).StructScan(&supplier)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+
+	if !supplier.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return supplier.ToModel(), nil
+}
+
+// UpdateSupplier overwrites the name and contact details of an existing supplier, including
+// its UpdatedAt and UpdatedBy audit columns.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplier: domain.Supplier containing the ID of the supplier and its new details.
+//
+// Returns:
+// - err: error if no supplier matches the ID or an error occurs during the update process.
+func (repo *SupplierRepository) UpdateSupplier(ctx context.Context, supplier domain.Supplier) (err error) {
+	repo.prepareUpdateSupplier()
+	result, err := repo.statement.UpdateSupplier.ExecContext(ctx, supplier.ID, supplier.Name, newContactInfo(supplier.ContactInfo), supplier.UpdatedAt, supplier.UpdatedBy)
+	if err != nil {
+		return err
+	}
+
+	return dbutil.CheckRowsAffected(result)
+}
+
+// DeleteSupplier permanently removes a supplier.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplierID: The ID of the supplier to remove.
+//
+// Returns:
+// - err: error if the supplier is missing, still referenced or cannot be removed.
+func (repo *SupplierRepository) DeleteSupplier(ctx context.Context, supplierID uuid.UUID) (err error) {
+	repo.prepareDeleteSupplier()
+	result, err := repo.statement.DeleteSupplier.ExecContext(ctx, supplierID)
+	if err != nil {
+		if dbutil.IsForeignKeyViolation(err) {
+			return dbutil.ErrDataStillReferenced
+		}
+
+		return err
+	}
+
+	return dbutil.CheckRowsAffected(result)
+}
+
+// CountProductBySupplierID counts the products, soft-deleted ones included, that
+// reference a supplier.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplierID: The ID of the supplier.
+//
+// Returns:
+// - res: int representing the number of products in the supplier.
+// - err: error if an error occurs during the retrieval process.
+func (repo *SupplierRepository) CountProductBySupplierID(ctx context.Context, supplierID uuid.UUID) (res int, err error) {
+	repo.prepareCountProductBySupplierID()
+	err = repo.statement.CountProductBySupplierID.QueryRowxContext(ctx, supplierID).Scan(&res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
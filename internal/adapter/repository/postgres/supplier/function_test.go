@@ -0,0 +1,384 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	postgres "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/supplier"
+	"github.com/gunawanpras/be-product-service/internal/core/supplier/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
+	"github.com/gunawanpras/be-product-service/pkg/util/uuidutil"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	expectedQueryAddSupplier = `
+		INSERT INTO suppliers (
+			id, 
+			name, 
+			contact_info, 
+			created_at, 
+			created_by
+		)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	expectedQueryUpdateSupplier = `
+		UPDATE suppliers
+		SET
+			name = $2,
+			contact_info = $3,
+			updated_at = $4,
+			updated_by = $5
+		WHERE id = $1
+	`
+
+	expectedQueryDeleteSupplier = `
+		DELETE FROM suppliers
+		WHERE id = $1
+	`
+
+	expectedQueryCountProductBySupplierID = `
+		SELECT COUNT(1)
+		FROM products p
+		WHERE p.supplier_id = $1
+	`
+
+	expectedQueryGetSupplierByID = `
+		SELECT
+			s.id,
+			s.name,
+			s.contact_info,
+			s.created_at,
+			s.created_by,
+			s.updated_at,
+			s.updated_by
+		FROM suppliers s
+		WHERE s.id = $1
+	`
+)
+
+var (
+	ctx                     context.Context = context.Background()
+	supplierID                              = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971429")
+	supplierName                            = "Toko Sayuran Segar"
+	supplierContactInfo                     = domain.ContactInfo{Email: "sales@example.com", Phone: "+62 812-3456-7890", Address: "Jl. Sayuran No.1, Jakarta"}
+	supplierContactInfoJSON                 = []byte(`{"email":"sales@example.com","phone":"+62 812-3456-7890","address":"Jl. Sayuran No.1, Jakarta"}`)
+	supplierCreatedAt                       = time.Now()
+	supplierCreatedBy                       = "SYSTEM"
+	supplierUpdatedAt                       = time.Now()
+	supplierUpdatedBy                       = "SYSTEM"
+	supplierColumns                         = []string{"id", "name", "contact_info", "created_at", "created_by", "updated_at", "updated_by"}
+)
+
+func newMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sqlx.NewDb(db, "sqlmock"), mock
+}
+
+func TestSupplierRepository_CreateSupplier(t *testing.T) {
+	uuidutil.UUIDHelper = mockUUIDHelper{id: supplierID}
+
+	supplier := domain.Supplier{
+		Name:        supplierName,
+		ContactInfo: &supplierContactInfo,
+		CreatedAt:   supplierCreatedAt,
+		CreatedBy:   supplierCreatedBy,
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes uuid.UUID
+		wantErr bool
+	}{
+		{
+			name: "error when create supplier",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryAddSupplier)).
+					WithArgs(supplierID, supplierName, supplierContactInfoJSON, supplierCreatedAt, supplierCreatedBy).
+					WillReturnError(errors.New("error"))
+			},
+			wantRes: uuid.Nil,
+			wantErr: true,
+		},
+		{
+			name: "success create supplier",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryAddSupplier)).
+					WithArgs(supplierID, supplierName, supplierContactInfoJSON, supplierCreatedAt, supplierCreatedBy).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantRes: supplierID,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryAddSupplier))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.CreateSupplier(ctx, supplier)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SupplierRepository.CreateSupplier() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("SupplierRepository.CreateSupplier() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestSupplierRepository_GetSupplierByID(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.Supplier
+		wantErr error
+	}{
+		{
+			name: "error when supplier not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetSupplierByID)).
+					WithArgs(supplierID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantRes: domain.Supplier{},
+			wantErr: dbutil.ErrDataNotFound,
+		},
+		{
+			name: "error when there is malformed data",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetSupplierByID)).
+					WithArgs(supplierID).
+					WillReturnRows(sqlmock.NewRows(supplierColumns).
+						AddRow(supplierID, "", supplierContactInfoJSON, supplierCreatedAt, supplierCreatedBy, nil, nil))
+			},
+			wantRes: domain.Supplier{},
+			wantErr: dbutil.ErrMalformedData,
+		},
+		{
+			name: "success get supplier by id",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetSupplierByID)).
+					WithArgs(supplierID).
+					WillReturnRows(sqlmock.NewRows(supplierColumns).
+						AddRow(supplierID, supplierName, supplierContactInfoJSON, supplierCreatedAt, supplierCreatedBy, supplierUpdatedAt, supplierUpdatedBy))
+			},
+			wantRes: domain.Supplier{
+				ID:          supplierID,
+				Name:        supplierName,
+				ContactInfo: &supplierContactInfo,
+				CreatedAt:   supplierCreatedAt,
+				CreatedBy:   supplierCreatedBy,
+				UpdatedAt:   &supplierUpdatedAt,
+				UpdatedBy:   &supplierUpdatedBy,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryGetSupplierByID))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetSupplierByID(ctx, supplierID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SupplierRepository.GetSupplierByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("SupplierRepository.GetSupplierByID() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestSupplierRepository_UpdateSupplier(t *testing.T) {
+	supplier := domain.Supplier{
+		ID:          supplierID,
+		Name:        supplierName,
+		ContactInfo: &supplierContactInfo,
+		UpdatedAt:   &supplierUpdatedAt,
+		UpdatedBy:   &supplierUpdatedBy,
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error when update supplier",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryUpdateSupplier)).
+					WithArgs(supplierID, supplierName, supplierContactInfoJSON, &supplierUpdatedAt, &supplierUpdatedBy).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when supplier not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryUpdateSupplier)).
+					WithArgs(supplierID, supplierName, supplierContactInfoJSON, &supplierUpdatedAt, &supplierUpdatedBy).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success update supplier",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryUpdateSupplier)).
+					WithArgs(supplierID, supplierName, supplierContactInfoJSON, &supplierUpdatedAt, &supplierUpdatedBy).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryUpdateSupplier))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.UpdateSupplier(ctx, supplier)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SupplierRepository.UpdateSupplier() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSupplierRepository_DeleteSupplier(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "error when supplier is still referenced",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteSupplier)).
+					WithArgs(supplierID).
+					WillReturnError(&pq.Error{Code: "23503"})
+			},
+			wantErr: dbutil.ErrDataStillReferenced,
+		},
+		{
+			name: "error when supplier not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteSupplier)).
+					WithArgs(supplierID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: dbutil.ErrDataNotFound,
+		},
+		{
+			name: "success delete supplier",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteSupplier)).
+					WithArgs(supplierID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryDeleteSupplier))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.DeleteSupplier(ctx, supplierID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SupplierRepository.DeleteSupplier() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSupplierRepository_CountProductBySupplierID(t *testing.T) {
+	dbx, mock := newMockDB(t)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryCountProductBySupplierID))
+	mock.ExpectQuery(regexp.QuoteMeta(expectedQueryCountProductBySupplierID)).
+		WithArgs(supplierID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	repo := postgres.New(postgres.InitAttribute{
+		DB: postgres.DB{
+			Db: dbx,
+		},
+	})
+
+	gotRes, err := repo.CountProductBySupplierID(ctx, supplierID)
+	if err != nil {
+		t.Errorf("SupplierRepository.CountProductBySupplierID() error = %v", err)
+		return
+	}
+
+	if gotRes != 2 {
+		t.Errorf("SupplierRepository.CountProductBySupplierID() gotRes = %v, want %v", gotRes, 2)
+	}
+}
@@ -0,0 +1,29 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	postgres "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/supplier"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	mockUUIDHelper struct {
+		id uuid.UUID
+	}
+)
+
+func (m mockUUIDHelper) New() uuid.UUID {
+	return m.id
+}
+
+func TestNew(t *testing.T) {
+	assert.Panics(t, func() {
+		postgres.New(postgres.InitAttribute{
+			DB: postgres.DB{
+				Db: nil,
+			},
+		})
+	})
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/supplier/domain"
+)
+
+type (
+	Supplier struct {
+		ID          uuid.UUID    `db:"id"`
+		Name        string       `db:"name"`
+		ContactInfo *ContactInfo `db:"contact_info"`
+		CreatedAt   time.Time    `db:"created_at"`
+		CreatedBy   string       `db:"created_by"`
+		UpdatedAt   *time.Time   `db:"updated_at"`
+		UpdatedBy   *string      `db:"updated_by"`
+	}
+
+	// ContactInfo is the JSONB representation of the supplier contact details.
+	ContactInfo struct {
+		Email   string `json:"email,omitempty"`
+		Phone   string `json:"phone,omitempty"`
+		Address string `json:"address,omitempty"`
+	}
+)
+
+// Value implements driver.Valuer so that ContactInfo is stored as JSON.
+func (c ContactInfo) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+// Scan implements sql.Scanner so that ContactInfo can be read from a JSONB column.
+func (c *ContactInfo) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return errors.New("unsupported contact_info type")
+	}
+}
+
+func newContactInfo(contactInfo *domain.ContactInfo) *ContactInfo {
+	if contactInfo == nil {
+		return nil
+	}
+
+	return &ContactInfo{
+		Email:   contactInfo.Email,
+		Phone:   contactInfo.Phone,
+		Address: contactInfo.Address,
+	}
+}
+
+func (c *ContactInfo) ToModel() *domain.ContactInfo {
+	if c == nil {
+		return nil
+	}
+
+	return &domain.ContactInfo{
+		Email:   c.Email,
+		Phone:   c.Phone,
+		Address: c.Address,
+	}
+}
+
+func (s Supplier) Validate() bool {
+	if s.ID == uuid.Nil {
+		return false
+	}
+
+	if s.Name == "" {
+		return false
+	}
+
+	if s.CreatedAt.IsZero() {
+		return false
+	}
+
+	if s.UpdatedAt != nil && s.UpdatedAt.IsZero() {
+		return false
+	}
+
+	return true
+}
+
+func (s Supplier) ToModel() domain.Supplier {
+	return domain.Supplier{
+		ID:          s.ID,
+		Name:        s.Name,
+		ContactInfo: s.ContactInfo.ToModel(),
+		CreatedAt:   s.CreatedAt,
+		CreatedBy:   s.CreatedBy,
+		UpdatedAt:   s.UpdatedAt,
+		UpdatedBy:   s.UpdatedBy,
+	}
+}
+
+type Suppliers []Supplier
+
+func (s Suppliers) Validate() bool {
+	for _, supplier := range s {
+		if !supplier.Validate() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s Suppliers) ToModel() domain.Suppliers {
+	var suppliers domain.Suppliers
+
+	for _, supplier := range s {
+		suppliers = append(suppliers, supplier.ToModel())
+	}
+
+	return suppliers
+}
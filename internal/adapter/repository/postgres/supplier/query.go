@@ -0,0 +1,59 @@
+package postgres
+
+var (
+	queryCreateSupplier = `
+		INSERT INTO suppliers (
+			id, 
+			name, 
+			contact_info, 
+			created_at, 
+			created_by
+		)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	queryUpdateSupplier = `
+		UPDATE suppliers
+		SET
+			name = $2,
+			contact_info = $3,
+			updated_at = $4,
+			updated_by = $5
+		WHERE id = $1
+	`
+
+	queryDeleteSupplier = `
+		DELETE FROM suppliers
+		WHERE id = $1
+	`
+
+	queryCountProductBySupplierID = `
+		SELECT COUNT(1)
+		FROM products p
+		WHERE p.supplier_id = $1
+	`
+
+	queryListSupplier = `
+		SELECT
+			s.id,
+			s.name,
+			s.contact_info,
+			s.created_at,
+			s.created_by,
+			s.updated_at,
+			s.updated_by
+		FROM suppliers s
+	`
+
+	queryGetListSupplier = queryListSupplier + `
+		WHERE 1=1
+	`
+
+	queryGetSupplierByID = queryListSupplier + `
+		WHERE s.id = $1
+	`
+
+	queryGetSupplierByName = queryListSupplier + `
+		WHERE s.name = $1
+	`
+)
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func (repo *SupplierRepository) prepareStatements() {
+	repo.statement = StatementList{}
+}
+
+func (repo *SupplierRepository) prepareCreateSupplier() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryCreateSupplier); err != nil {
+		log.Panic("[prepareCreateSupplier] error:", err)
+	}
+	repo.statement.CreateSupplier = stmt
+}
+
+func (repo *SupplierRepository) prepareGetSupplierByID() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetSupplierByID); err != nil {
+		log.Panic("[prepareGetSupplierByID] error:", err)
+	}
+	repo.statement.GetSupplierByID = stmt
+}
+
+func (repo *SupplierRepository) prepareGetSupplierByName() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetSupplierByName); err != nil {
+		log.Panic("[prepareGetSupplierByName] error:", err)
+	}
+	repo.statement.GetSupplierByName = stmt
+}
+
+func (repo *SupplierRepository) prepareUpdateSupplier() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryUpdateSupplier); err != nil {
+		log.Panic("[prepareUpdateSupplier] error:", err)
+	}
+	repo.statement.UpdateSupplier = stmt
+}
+
+func (repo *SupplierRepository) prepareDeleteSupplier() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryDeleteSupplier); err != nil {
+		log.Panic("[prepareDeleteSupplier] error:", err)
+	}
+	repo.statement.DeleteSupplier = stmt
+}
+
+func (repo *SupplierRepository) prepareCountProductBySupplierID() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryCountProductBySupplierID); err != nil {
+		log.Panic("[prepareCountProductBySupplierID] error:", err)
+	}
+	repo.statement.CountProductBySupplierID = stmt
+}
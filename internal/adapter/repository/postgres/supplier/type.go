@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	SupplierRepository struct {
+		db        DB
+		statement StatementList
+	}
+
+	DB struct {
+		Db *sqlx.DB
+	}
+
+	StatementList struct {
+		CreateSupplier           *sqlx.Stmt
+		GetSupplierByID          *sqlx.Stmt
+		GetSupplierByName        *sqlx.Stmt
+		UpdateSupplier           *sqlx.Stmt
+		DeleteSupplier           *sqlx.Stmt
+		CountProductBySupplierID *sqlx.Stmt
+	}
+
+	InitAttribute struct {
+		DB DB
+	}
+)
@@ -0,0 +1,229 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/unit/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
+	"github.com/gunawanpras/be-product-service/pkg/util/uuidutil"
+)
+
+// CreateUnit creates a new unit in the system and assigns a new ID to it.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - unit: domain.Unit containing the details of the unit to be created.
+//
+// Returns:
+// - res: uuid.UUID representing the ID of the newly created unit.
+// - err: error if an error occurs during the creation process.
+func (repo *UnitRepository) CreateUnit(ctx context.Context, unit domain.Unit) (res uuid.UUID, err error) {
+	unit.ID = uuidutil.UUIDHelper.New()
+
+	repo.prepareCreateUnit()
+	_, err = repo.statement.CreateUnit.ExecContext(ctx, unit.ID, unit.Name, unit.Dimension, unit.BaseUnitID, unit.Factor)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return unit.ID, nil
+}
+
+// GetListUnit retrieves a list of units ordered by dimension and name, optionally
+// filtered by dimension.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - dimension: The dimension to filter by, or empty for every unit.
+//
+// Returns:
+// - res: domain.Units representing the list of units that match the criteria.
+// - err: error if an error occurs during the retrieval process.
+func (repo *UnitRepository) GetListUnit(ctx context.Context, dimension string) (res domain.Units, err error) {
+	var (
+		query []string = []string{queryGetListUnit}
+		args  []any
+		unit  Unit
+		units Units
+	)
+
+	if dimension != "" {
+		query = append(query, "AND u.dimension = ?")
+		args = append(args, dimension)
+	}
+
+	query = append(query, "ORDER BY u.dimension ASC, u.unit_name ASC")
+
+	finalQuery := strings.Join(query, " ")
+	finalQuery = repo.db.Db.Rebind(finalQuery)
+
+	rows, err := repo.db.Db.QueryxContext(ctx, finalQuery, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		unit = Unit{}
+		err = rows.StructScan(&unit)
+		if err != nil {
+			return res, err
+		}
+
+		units = append(units, unit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return res, err
+	}
+
+	if !units.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return units.ToModel(), nil
+}
+
+// GetUnitByID retrieves a unit by ID from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - unitID: The ID of the unit to retrieve.
+//
+// Returns:
+// - res: domain.Unit representing the unit with the provided ID.
+// - err: error if an error occurs during the retrieval process.
+func (repo *UnitRepository) GetUnitByID(ctx context.Context, unitID uuid.UUID) (res domain.Unit, err error) {
+	var unit Unit
+
+	repo.prepareGetUnitByID()
+	err = repo.statement.GetUnitByID.QueryRowxContext(ctx, unitID).StructScan(&unit)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+
+	if !unit.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return unit.ToModel(), nil
+}
+
+// GetUnitByName retrieves a unit by its exact name from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - unitName: The name of the unit to retrieve.
+//
+// Returns:
+// - res: domain.Unit representing the unit with the provided name.
+// - err: error if an error occurs during the retrieval process.
+func (repo *UnitRepository) GetUnitByName(ctx context.Context, unitName string) (res domain.Unit, err error) {
+	var unit Unit
+
+	repo.prepareGetUnitByName()
+	err = repo.statement.GetUnitByName.QueryRowxContext(ctx, unitName).StructScan(&unit)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+
+	if !unit.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return unit.ToModel(), nil
+}
+
+// UpdateUnit overwrites the name and conversion of an existing unit.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - unit: domain.Unit containing the ID of the unit and its new details.
+//
+// Returns:
+// - err: error if no unit matches the ID or an error occurs during the update process.
+func (repo *UnitRepository) UpdateUnit(ctx context.Context, unit domain.Unit) (err error) {
+	repo.prepareUpdateUnit()
+	result, err := repo.statement.UpdateUnit.ExecContext(ctx, unit.ID, unit.Name, unit.Dimension, unit.BaseUnitID, unit.Factor)
+	if err != nil {
+		return err
+	}
+
+	return dbutil.CheckRowsAffected(result)
+}
+
+// DeleteUnit permanently removes a unit.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - unitID: The ID of the unit to remove.
+//
+// Returns:
+// - err: error if the unit is missing, still referenced or cannot be removed.
+func (repo *UnitRepository) DeleteUnit(ctx context.Context, unitID uuid.UUID) (err error) {
+	repo.prepareDeleteUnit()
+	result, err := repo.statement.DeleteUnit.ExecContext(ctx, unitID)
+	if err != nil {
+		if dbutil.IsForeignKeyViolation(err) {
+			return dbutil.ErrDataStillReferenced
+		}
+
+		return err
+	}
+
+	return dbutil.CheckRowsAffected(result)
+}
+
+// CountProductByUnitID counts the products, soft-deleted ones included, that
+// reference a unit.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - unitID: The ID of the unit.
+//
+// Returns:
+// - res: int representing the number of products in the unit.
+// - err: error if an error occurs during the retrieval process.
+func (repo *UnitRepository) CountProductByUnitID(ctx context.Context, unitID uuid.UUID) (res int, err error) {
+	repo.prepareCountProductByUnitID()
+	err = repo.statement.CountProductByUnitID.QueryRowxContext(ctx, unitID).Scan(&res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+// CountUnitByBaseUnitID counts the units that are expressed in the given base unit.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - unitID: The ID of the base unit.
+//
+// Returns:
+// - res: int representing the number of units derived from the base unit.
+// - err: error if an error occurs during the retrieval process.
+func (repo *UnitRepository) CountUnitByBaseUnitID(ctx context.Context, unitID uuid.UUID) (res int, err error) {
+	repo.prepareCountUnitByBaseUnitID()
+	err = repo.statement.CountUnitByBaseUnitID.QueryRowxContext(ctx, unitID).Scan(&res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
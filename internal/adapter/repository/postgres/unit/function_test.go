@@ -0,0 +1,398 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	postgres "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/unit"
+	"github.com/gunawanpras/be-product-service/internal/core/unit/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
+	"github.com/gunawanpras/be-product-service/pkg/util/uuidutil"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	expectedQueryAddUnit = `
+		INSERT INTO units (
+			id, 
+			unit_name, 
+			dimension, 
+			base_unit_id, 
+			factor
+		)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	expectedQueryUpdateUnit = `
+		UPDATE units
+		SET
+			unit_name = $2,
+			dimension = $3,
+			base_unit_id = $4,
+			factor = $5
+		WHERE id = $1
+	`
+
+	expectedQueryDeleteUnit = `
+		DELETE FROM units
+		WHERE id = $1
+	`
+
+	expectedQueryCountUnitByBaseUnitID = `
+		SELECT COUNT(1)
+		FROM units u
+		WHERE u.base_unit_id = $1
+	`
+
+	expectedQueryGetUnit = `
+		SELECT
+			u.id,
+			u.unit_name,
+			u.dimension,
+			u.base_unit_id,
+			u.factor
+		FROM units u
+	`
+
+	expectedQueryGetListUnit = expectedQueryGetUnit + `
+		WHERE 1=1
+		AND u.dimension = ?
+		ORDER BY u.dimension ASC, u.unit_name ASC
+	`
+
+	expectedQueryGetUnitByID = expectedQueryGetUnit + `
+		WHERE u.id = $1
+	`
+)
+
+var (
+	ctx           context.Context = context.Background()
+	unitID                        = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971432")
+	baseUnitID                    = uuid.MustParse("e5ec5a4e-509a-4260-9d16-845032971433")
+	unitName                      = "g"
+	unitDimension                 = domain.DimensionMass
+	unitFactor                    = 0.001
+	unitColumns                   = []string{"id", "unit_name", "dimension", "base_unit_id", "factor"}
+)
+
+func newMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sqlx.NewDb(db, "sqlmock"), mock
+}
+
+func TestUnitRepository_CreateUnit(t *testing.T) {
+	uuidutil.UUIDHelper = mockUUIDHelper{id: unitID}
+
+	unit := domain.Unit{
+		Name:       unitName,
+		Dimension:  unitDimension,
+		BaseUnitID: &baseUnitID,
+		Factor:     unitFactor,
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes uuid.UUID
+		wantErr bool
+	}{
+		{
+			name: "error when create unit",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryAddUnit)).
+					WithArgs(unitID, unitName, unitDimension, &baseUnitID, unitFactor).
+					WillReturnError(errors.New("error"))
+			},
+			wantRes: uuid.Nil,
+			wantErr: true,
+		},
+		{
+			name: "success create unit",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryAddUnit)).
+					WithArgs(unitID, unitName, unitDimension, &baseUnitID, unitFactor).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantRes: unitID,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryAddUnit))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.CreateUnit(ctx, unit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnitRepository.CreateUnit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("UnitRepository.CreateUnit() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestUnitRepository_GetListUnit(t *testing.T) {
+	dbx, mock := newMockDB(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListUnit)).
+		WithArgs(unitDimension).
+		WillReturnRows(sqlmock.NewRows(unitColumns).
+			AddRow(baseUnitID, "kg", unitDimension, nil, 1).
+			AddRow(unitID, unitName, unitDimension, baseUnitID, unitFactor))
+
+	repo := postgres.New(postgres.InitAttribute{
+		DB: postgres.DB{
+			Db: dbx,
+		},
+	})
+
+	wantRes := domain.Units{
+		{ID: baseUnitID, Name: "kg", Dimension: unitDimension, Factor: 1},
+		{ID: unitID, Name: unitName, Dimension: unitDimension, BaseUnitID: &baseUnitID, Factor: unitFactor},
+	}
+
+	gotRes, err := repo.GetListUnit(ctx, unitDimension)
+	if err != nil {
+		t.Errorf("UnitRepository.GetListUnit() error = %v", err)
+		return
+	}
+
+	if !reflect.DeepEqual(gotRes, wantRes) {
+		t.Errorf("UnitRepository.GetListUnit() gotRes = %v, want %v", gotRes, wantRes)
+	}
+}
+
+func TestUnitRepository_GetUnitByID(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.Unit
+		wantErr error
+	}{
+		{
+			name: "error when unit not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetUnitByID)).
+					WithArgs(unitID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantRes: domain.Unit{},
+			wantErr: dbutil.ErrDataNotFound,
+		},
+		{
+			name: "error when there is malformed data",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetUnitByID)).
+					WithArgs(unitID).
+					WillReturnRows(sqlmock.NewRows(unitColumns).
+						AddRow(unitID, unitName, unitDimension, baseUnitID, 0))
+			},
+			wantRes: domain.Unit{},
+			wantErr: dbutil.ErrMalformedData,
+		},
+		{
+			name: "success get unit by id",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetUnitByID)).
+					WithArgs(unitID).
+					WillReturnRows(sqlmock.NewRows(unitColumns).
+						AddRow(unitID, unitName, unitDimension, baseUnitID, unitFactor))
+			},
+			wantRes: domain.Unit{
+				ID:         unitID,
+				Name:       unitName,
+				Dimension:  unitDimension,
+				BaseUnitID: &baseUnitID,
+				Factor:     unitFactor,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryGetUnitByID))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetUnitByID(ctx, unitID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UnitRepository.GetUnitByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("UnitRepository.GetUnitByID() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestUnitRepository_UpdateUnit(t *testing.T) {
+	unit := domain.Unit{
+		ID:         unitID,
+		Name:       unitName,
+		Dimension:  unitDimension,
+		BaseUnitID: &baseUnitID,
+		Factor:     unitFactor,
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error when unit not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryUpdateUnit)).
+					WithArgs(unitID, unitName, unitDimension, &baseUnitID, unitFactor).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success update unit",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryUpdateUnit)).
+					WithArgs(unitID, unitName, unitDimension, &baseUnitID, unitFactor).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryUpdateUnit))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.UpdateUnit(ctx, unit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnitRepository.UpdateUnit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnitRepository_DeleteUnit(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "error when unit is still referenced",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteUnit)).
+					WithArgs(unitID).
+					WillReturnError(&pq.Error{Code: "23503"})
+			},
+			wantErr: dbutil.ErrDataStillReferenced,
+		},
+		{
+			name: "success delete unit",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteUnit)).
+					WithArgs(unitID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryDeleteUnit))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.DeleteUnit(ctx, unitID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UnitRepository.DeleteUnit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnitRepository_CountUnitByBaseUnitID(t *testing.T) {
+	dbx, mock := newMockDB(t)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryCountUnitByBaseUnitID))
+	mock.ExpectQuery(regexp.QuoteMeta(expectedQueryCountUnitByBaseUnitID)).
+		WithArgs(baseUnitID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	repo := postgres.New(postgres.InitAttribute{
+		DB: postgres.DB{
+			Db: dbx,
+		},
+	})
+
+	gotRes, err := repo.CountUnitByBaseUnitID(ctx, baseUnitID)
+	if err != nil {
+		t.Errorf("UnitRepository.CountUnitByBaseUnitID() error = %v", err)
+		return
+	}
+
+	if gotRes != 3 {
+		t.Errorf("UnitRepository.CountUnitByBaseUnitID() gotRes = %v, want %v", gotRes, 3)
+	}
+}
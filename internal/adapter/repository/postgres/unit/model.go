@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/unit/domain"
+)
+
+type Unit struct {
+	ID         uuid.UUID  `db:"id"`
+	Name       string     `db:"unit_name"`
+	Dimension  string     `db:"dimension"`
+	BaseUnitID *uuid.UUID `db:"base_unit_id"`
+	Factor     float64    `db:"factor"`
+}
+
+func (u Unit) Validate() bool {
+	if u.ID == uuid.Nil {
+		return false
+	}
+
+	if u.Name == "" {
+		return false
+	}
+
+	if u.Dimension == "" {
+		return false
+	}
+
+	if u.Factor <= 0 {
+		return false
+	}
+
+	return true
+}
+
+func (u Unit) ToModel() domain.Unit {
+	return domain.Unit{
+		ID:         u.ID,
+		Name:       u.Name,
+		Dimension:  u.Dimension,
+		BaseUnitID: u.BaseUnitID,
+		Factor:     u.Factor,
+	}
+}
+
+type Units []Unit
+
+func (u Units) Validate() bool {
+	for _, unit := range u {
+		if !unit.Validate() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (u Units) ToModel() domain.Units {
+	var units domain.Units
+
+	for _, unit := range u {
+		units = append(units, unit.ToModel())
+	}
+
+	return units
+}
@@ -0,0 +1,63 @@
+package postgres
+
+var (
+	queryCreateUnit = `
+		INSERT INTO units (
+			id, 
+			unit_name, 
+			dimension, 
+			base_unit_id, 
+			factor
+		)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	queryUpdateUnit = `
+		UPDATE units
+		SET
+			unit_name = $2,
+			dimension = $3,
+			base_unit_id = $4,
+			factor = $5
+		WHERE id = $1
+	`
+
+	queryDeleteUnit = `
+		DELETE FROM units
+		WHERE id = $1
+	`
+
+	queryCountProductByUnitID = `
+		SELECT COUNT(1)
+		FROM products p
+		WHERE p.unit_id = $1
+	`
+
+	queryCountUnitByBaseUnitID = `
+		SELECT COUNT(1)
+		FROM units u
+		WHERE u.base_unit_id = $1
+	`
+
+	queryListUnit = `
+		SELECT
+			u.id,
+			u.unit_name,
+			u.dimension,
+			u.base_unit_id,
+			u.factor
+		FROM units u
+	`
+
+	queryGetListUnit = queryListUnit + `
+		WHERE 1=1
+	`
+
+	queryGetUnitByID = queryListUnit + `
+		WHERE u.id = $1
+	`
+
+	queryGetUnitByName = queryListUnit + `
+		WHERE u.unit_name = $1
+	`
+)
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func (repo *UnitRepository) prepareStatements() {
+	repo.statement = StatementList{}
+}
+
+func (repo *UnitRepository) prepareCreateUnit() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryCreateUnit); err != nil {
+		log.Panic("[prepareCreateUnit] error:", err)
+	}
+	repo.statement.CreateUnit = stmt
+}
+
+func (repo *UnitRepository) prepareGetUnitByID() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetUnitByID); err != nil {
+		log.Panic("[prepareGetUnitByID] error:", err)
+	}
+	repo.statement.GetUnitByID = stmt
+}
+
+func (repo *UnitRepository) prepareGetUnitByName() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetUnitByName); err != nil {
+		log.Panic("[prepareGetUnitByName] error:", err)
+	}
+	repo.statement.GetUnitByName = stmt
+}
+
+func (repo *UnitRepository) prepareUpdateUnit() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryUpdateUnit); err != nil {
+		log.Panic("[prepareUpdateUnit] error:", err)
+	}
+	repo.statement.UpdateUnit = stmt
+}
+
+func (repo *UnitRepository) prepareDeleteUnit() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryDeleteUnit); err != nil {
+		log.Panic("[prepareDeleteUnit] error:", err)
+	}
+	repo.statement.DeleteUnit = stmt
+}
+
+func (repo *UnitRepository) prepareCountProductByUnitID() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryCountProductByUnitID); err != nil {
+		log.Panic("[prepareCountProductByUnitID] error:", err)
+	}
+	repo.statement.CountProductByUnitID = stmt
+}
+
+func (repo *UnitRepository) prepareCountUnitByBaseUnitID() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryCountUnitByBaseUnitID); err != nil {
+		log.Panic("[prepareCountUnitByBaseUnitID] error:", err)
+	}
+	repo.statement.CountUnitByBaseUnitID = stmt
+}
@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	UnitRepository struct {
+		db        DB
+		statement StatementList
+	}
+
+	DB struct {
+		Db *sqlx.DB
+	}
+
+	StatementList struct {
+		CreateUnit            *sqlx.Stmt
+		GetUnitByID           *sqlx.Stmt
+		GetUnitByName         *sqlx.Stmt
+		UpdateUnit            *sqlx.Stmt
+		DeleteUnit            *sqlx.Stmt
+		CountProductByUnitID  *sqlx.Stmt
+		CountUnitByBaseUnitID *sqlx.Stmt
+	}
+
+	InitAttribute struct {
+		DB DB
+	}
+)
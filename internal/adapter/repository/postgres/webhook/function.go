@@ -0,0 +1,294 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
+	"github.com/gunawanpras/be-product-service/pkg/util/uuidutil"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// CreateWebhook creates a new webhook and assigns a new ID to it.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - webhook: domain.Webhook containing the URL, secret and event types of the webhook.
+//
+// Returns:
+// - res: uuid.UUID representing the ID of the newly created webhook.
+// - err: error if an error occurs during the creation process.
+func (repo *WebhookRepository) CreateWebhook(ctx context.Context, webhook domain.Webhook) (res uuid.UUID, err error) {
+	webhook.ID = uuidutil.UUIDHelper.New()
+
+	repo.prepareCreateWebhook()
+	_, err = repo.statement.CreateWebhook.ExecContext(ctx, webhook.ID, webhook.URL, webhook.Secret, pq.StringArray(webhook.EventTypes), webhook.CreatedAt, webhook.CreatedBy)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return webhook.ID, nil
+}
+
+// GetListWebhook retrieves every webhook, oldest first.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+//
+// Returns:
+// - res: domain.Webhooks representing the list of webhooks.
+// - err: error if an error occurs during the retrieval process.
+func (repo *WebhookRepository) GetListWebhook(ctx context.Context) (res domain.Webhooks, err error) {
+	repo.prepareGetListWebhook()
+	return repo.selectWebhooks(ctx, repo.statement.GetListWebhook)
+}
+
+// GetWebhookByID retrieves a webhook by ID.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - webhookID: The ID of the webhook to retrieve.
+//
+// Returns:
+// - res: domain.Webhook representing the webhook with the provided ID.
+// - err: error if an error occurs during the retrieval process.
+func (repo *WebhookRepository) GetWebhookByID(ctx context.Context, webhookID uuid.UUID) (res domain.Webhook, err error) {
+	var webhook Webhook
+
+	repo.prepareGetWebhookByID()
+	err = repo.statement.GetWebhookByID.QueryRowxContext(ctx, webhookID).StructScan(&webhook)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, dbutil.ErrDataNotFound
+		}
+
+		return res, err
+	}
+
+	if !webhook.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return webhook.ToModel(), nil
+}
+
+// DeleteWebhook permanently removes a webhook, its deliveries are removed along with it.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - webhookID: The ID of the webhook to remove.
+//
+// Returns:
+// - err: error if no webhook matches the ID or an error occurs during the removal process.
+func (repo *WebhookRepository) DeleteWebhook(ctx context.Context, webhookID uuid.UUID) (err error) {
+	repo.prepareDeleteWebhook()
+	result, err := repo.statement.DeleteWebhook.ExecContext(ctx, webhookID)
+	if err != nil {
+		return err
+	}
+
+	return dbutil.CheckRowsAffected(result)
+}
+
+// GetListWebhookByEventType retrieves the webhooks subscribed to an event type.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - eventType: The type of event, such as product.created.
+//
+// Returns:
+// - res: domain.Webhooks representing the webhooks subscribed to the event type.
+// - err: error if an error occurs during the retrieval process.
+func (repo *WebhookRepository) GetListWebhookByEventType(ctx context.Context, eventType string) (res domain.Webhooks, err error) {
+	repo.prepareGetListWebhookByEventType()
+	return repo.selectWebhooks(ctx, repo.statement.GetListWebhookByEventType, eventType)
+}
+
+// CreateDeliveries queues deliveries in a single statement, assigning a new ID to each.
+// A delivery of an event already queued for the same webhook is skipped.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - deliveries: domain.Deliveries containing the webhook, event and payload of each delivery.
+//
+// Returns:
+// - err: error if an error occurs during the creation process.
+func (repo *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries domain.Deliveries) (err error) {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	var (
+		rows []string
+		args []any
+	)
+
+	for _, delivery := range deliveries {
+		rows = append(rows, "(?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args, uuidutil.UUIDHelper.New(), delivery.WebhookID, delivery.EventID, delivery.EventType, string(delivery.Payload), delivery.Status, delivery.NextAttemptAt, delivery.CreatedAt)
+	}
+
+	finalQuery := queryCreateDeliveries + strings.Join(rows, ", ") + queryCreateDeliveriesConflict
+	finalQuery = repo.db.Db.Rebind(finalQuery)
+
+	_, err = repo.db.Db.ExecContext(ctx, finalQuery, args...)
+	return err
+}
+
+// ClaimDueDeliveries claims the pending deliveries due for an attempt, oldest due first,
+// by moving their next attempt to the end of a lease. Deliveries claimed by another
+// dispatcher at the same time are skipped.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - now: The time the next attempts of the deliveries are compared to.
+// - leaseUntil: The time until which the claimed deliveries are not due again.
+// - limit: The maximum number of deliveries to claim.
+//
+// Returns:
+// - res: domain.Deliveries representing the claimed deliveries, along with their webhook.
+// - err: error if an error occurs during the claim.
+func (repo *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) (res domain.Deliveries, err error) {
+	repo.prepareClaimDueDeliveries()
+	rows, err := repo.statement.ClaimDueDeliveries.QueryxContext(ctx, now, leaseUntil, limit, constant.WebhookDeliveryPending)
+	if err != nil {
+		return res, err
+	}
+
+	return scanDeliveries(rows)
+}
+
+// MarkDeliveryAttempt records the outcome of an attempt to send a delivery, counting it
+// among the attempts of the delivery.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - attempt: domain.DeliveryAttempt containing the new status of the delivery and the
+// response of its webhook.
+//
+// Returns:
+// - err: error if no delivery matches the ID or an error occurs during the update process.
+func (repo *WebhookRepository) MarkDeliveryAttempt(ctx context.Context, attempt domain.DeliveryAttempt) (err error) {
+	repo.prepareMarkDeliveryAttempt()
+	result, err := repo.statement.MarkDeliveryAttempt.ExecContext(ctx, attempt.DeliveryID, attempt.Status, attempt.NextAttemptAt, attempt.AttemptedAt, attempt.StatusCode, attempt.Error, attempt.DeliveredAt)
+	if err != nil {
+		return err
+	}
+
+	return dbutil.CheckRowsAffected(result)
+}
+
+// GetListDeliveryByWebhookID retrieves a page of the deliveries of a webhook, newest
+// first, along with the number of deliveries matching the filter.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - webhookID: The ID of the webhook.
+// - filter: domain.DeliveryFilter selecting the status and page of the deliveries.
+//
+// Returns:
+// - res: domain.DeliveryPage representing the page of deliveries and their total.
+// - err: error if an error occurs during the retrieval process.
+func (repo *WebhookRepository) GetListDeliveryByWebhookID(ctx context.Context, webhookID uuid.UUID, filter domain.DeliveryFilter) (res domain.DeliveryPage, err error) {
+	var (
+		conditions []string
+		args       = []any{webhookID}
+	)
+
+	if filter.Status != "" {
+		conditions = append(conditions, "AND d.status = ?")
+		args = append(args, filter.Status)
+	}
+
+	countQuery := strings.Join(append([]string{queryCountDelivery}, conditions...), " ")
+	countQuery = repo.db.Db.Rebind(countQuery)
+
+	if err = repo.db.Db.GetContext(ctx, &res.Total, countQuery, args...); err != nil {
+		return res, err
+	}
+
+	query := append([]string{queryGetListDelivery}, conditions...)
+	query = append(query, "ORDER BY d.created_at DESC, d.id DESC", "LIMIT ? OFFSET ?")
+	args = append(args, filter.Limit, filter.Offset())
+
+	finalQuery := strings.Join(query, " ")
+	finalQuery = repo.db.Db.Rebind(finalQuery)
+
+	rows, err := repo.db.Db.QueryxContext(ctx, finalQuery, args...)
+	if err != nil {
+		return res, err
+	}
+
+	res.Deliveries, err = scanDeliveries(rows)
+	return res, err
+}
+
+// selectWebhooks runs a prepared query of webhooks.
+func (repo *WebhookRepository) selectWebhooks(ctx context.Context, stmt *sqlx.Stmt, args ...any) (res domain.Webhooks, err error) {
+	var (
+		webhook  Webhook
+		webhooks Webhooks
+	)
+
+	rows, err := stmt.QueryxContext(ctx, args...)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		webhook = Webhook{}
+		err = rows.StructScan(&webhook)
+		if err != nil {
+			return res, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return res, err
+	}
+
+	if !webhooks.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return webhooks.ToModel(), nil
+}
+
+// scanDeliveries reads the deliveries of a query and closes its rows.
+func scanDeliveries(rows *sqlx.Rows) (res domain.Deliveries, err error) {
+	var (
+		delivery   Delivery
+		deliveries Deliveries
+	)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		delivery = Delivery{}
+		err = rows.StructScan(&delivery)
+		if err != nil {
+			return res, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return res, err
+	}
+
+	if !deliveries.Validate() {
+		return res, dbutil.ErrMalformedData
+	}
+
+	return deliveries.ToModel(), nil
+}
@@ -0,0 +1,719 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	postgres "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/webhook"
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/domain"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
+	"github.com/gunawanpras/be-product-service/pkg/util/uuidutil"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	expectedQueryCreateWebhook = `
+		INSERT INTO webhooks (
+			id,
+			url,
+			secret,
+			event_types,
+			created_at,
+			created_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	expectedQueryDeleteWebhook = `
+		DELETE FROM webhooks
+		WHERE id = $1
+	`
+
+	expectedQueryGetWebhookByID = `
+		SELECT
+			w.id,
+			w.url,
+			w.secret,
+			w.event_types,
+			w.created_at,
+			w.created_by
+		FROM webhooks w
+
+		WHERE w.id = $1
+	`
+
+	expectedQueryGetListWebhookByEventType = `
+		FROM webhooks w
+
+		WHERE w.event_types @> ARRAY[$1]::VARCHAR[]
+		ORDER BY w.created_at ASC, w.id ASC
+	`
+
+	expectedQueryCreateDeliveries = `
+		INSERT INTO webhook_deliveries (
+			id,
+			webhook_id,
+			event_id,
+			event_type,
+			payload,
+			status,
+			next_attempt_at,
+			created_at
+		)
+		VALUES
+	(?, ?, ?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`
+
+	expectedQueryClaimDueDeliveries = `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = $2
+		FROM webhooks w
+		WHERE
+			w.id = d.webhook_id AND
+			d.id IN (
+				SELECT id
+				FROM webhook_deliveries
+				WHERE
+					status = $4 AND
+					next_attempt_at <= $1
+				ORDER BY next_attempt_at ASC
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING
+			d.id,
+			d.webhook_id,
+			d.event_id,
+			d.event_type,
+			d.payload,
+			d.status,
+			d.attempts,
+			d.next_attempt_at,
+			d.last_attempt_at,
+			d.last_status_code,
+			d.last_error,
+			d.delivered_at,
+			d.created_at,
+			w.url AS webhook_url,
+			w.secret AS webhook_secret
+	`
+
+	expectedQueryMarkDeliveryAttempt = `
+		UPDATE webhook_deliveries
+		SET
+			status = $2,
+			attempts = attempts + 1,
+			next_attempt_at = $3,
+			last_attempt_at = $4,
+			last_status_code = $5,
+			last_error = $6,
+			delivered_at = $7
+		WHERE id = $1
+	`
+
+	expectedQueryCountDelivery = `
+		SELECT COUNT(1)
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = ?
+	 AND d.status = ?`
+
+	expectedQueryGetListDelivery = `
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = ?
+	 AND d.status = ? ORDER BY d.created_at DESC, d.id DESC LIMIT ? OFFSET ?`
+)
+
+var (
+	ctx               context.Context = context.Background()
+	webhookID                         = uuid.MustParse("3f6b2a10-8c4d-4e2f-9a71-5d0c1b2e3f40")
+	deliveryID                        = uuid.MustParse("9c2e4b61-7a3f-4d10-8e5b-1f6a2c3d4e50")
+	eventID                           = uuid.MustParse("7b0f6a52-3c1e-4d8a-9f4b-2e6c8d1a5b01")
+	webhookURL                        = "https://partner.example.com/hooks"
+	webhookSecret                     = "s3cr3t"
+	webhookEventTypes                 = []string{constant.ProductEventCreated, constant.StockEventChanged}
+	webhookCreatedAt                  = time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	deliveryPayload                   = []byte(`{"id":"7b0f6a52-3c1e-4d8a-9f4b-2e6c8d1a5b01","type":"product.created"}`)
+	now                               = time.Date(2025, 3, 1, 8, 5, 0, 0, time.UTC)
+
+	webhookColumns  = []string{"id", "url", "secret", "event_types", "created_at", "created_by"}
+	deliveryColumns = []string{"id", "webhook_id", "event_id", "event_type", "payload", "status", "attempts", "next_attempt_at", "last_attempt_at", "last_status_code", "last_error", "delivered_at", "created_at"}
+)
+
+func newMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sqlx.NewDb(db, "sqlmock"), mock
+}
+
+func TestWebhookRepository_CreateWebhook(t *testing.T) {
+	uuidutil.UUIDHelper = mockUUIDHelper{id: webhookID}
+
+	webhook := domain.Webhook{
+		URL:        webhookURL,
+		Secret:     webhookSecret,
+		EventTypes: webhookEventTypes,
+		CreatedAt:  webhookCreatedAt,
+		CreatedBy:  constant.SYSTEM,
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes uuid.UUID
+		wantErr bool
+	}{
+		{
+			name: "error when create webhook",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateWebhook)).
+					WithArgs(webhookID, webhookURL, webhookSecret, pq.StringArray(webhookEventTypes), webhookCreatedAt, constant.SYSTEM).
+					WillReturnError(errors.New("error"))
+			},
+			wantRes: uuid.Nil,
+			wantErr: true,
+		},
+		{
+			name: "success create webhook",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateWebhook)).
+					WithArgs(webhookID, webhookURL, webhookSecret, pq.StringArray(webhookEventTypes), webhookCreatedAt, constant.SYSTEM).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantRes: webhookID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryCreateWebhook))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.CreateWebhook(ctx, webhook)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WebhookRepository.CreateWebhook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("WebhookRepository.CreateWebhook() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_GetWebhookByID(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.Webhook
+		wantErr error
+	}{
+		{
+			name: "error when webhook not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetWebhookByID)).
+					WithArgs(webhookID).
+					WillReturnRows(sqlmock.NewRows(webhookColumns))
+			},
+			wantErr: dbutil.ErrDataNotFound,
+		},
+		{
+			name: "error when there is malformed data",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetWebhookByID)).
+					WithArgs(webhookID).
+					WillReturnRows(sqlmock.NewRows(webhookColumns).
+						AddRow(webhookID, webhookURL, "", "{product.created,stock.changed}", webhookCreatedAt, constant.SYSTEM))
+			},
+			wantErr: dbutil.ErrMalformedData,
+		},
+		{
+			name: "success get webhook",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetWebhookByID)).
+					WithArgs(webhookID).
+					WillReturnRows(sqlmock.NewRows(webhookColumns).
+						AddRow(webhookID, webhookURL, webhookSecret, "{product.created,stock.changed}", webhookCreatedAt, constant.SYSTEM))
+			},
+			wantRes: domain.Webhook{
+				ID:         webhookID,
+				URL:        webhookURL,
+				Secret:     webhookSecret,
+				EventTypes: webhookEventTypes,
+				CreatedAt:  webhookCreatedAt,
+				CreatedBy:  constant.SYSTEM,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryGetWebhookByID))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetWebhookByID(ctx, webhookID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("WebhookRepository.GetWebhookByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("WebhookRepository.GetWebhookByID() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_GetListWebhookByEventType(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.Webhooks
+		wantErr bool
+	}{
+		{
+			name: "error when get webhooks",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListWebhookByEventType)).
+					WithArgs(constant.StockEventChanged).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success get no webhook",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListWebhookByEventType)).
+					WithArgs(constant.StockEventChanged).
+					WillReturnRows(sqlmock.NewRows(webhookColumns))
+			},
+		},
+		{
+			name: "success get webhooks",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListWebhookByEventType)).
+					WithArgs(constant.StockEventChanged).
+					WillReturnRows(sqlmock.NewRows(webhookColumns).
+						AddRow(webhookID, webhookURL, webhookSecret, []byte("{product.created,stock.changed}"), webhookCreatedAt, constant.SYSTEM))
+			},
+			wantRes: domain.Webhooks{
+				{
+					ID:         webhookID,
+					URL:        webhookURL,
+					Secret:     webhookSecret,
+					EventTypes: webhookEventTypes,
+					CreatedAt:  webhookCreatedAt,
+					CreatedBy:  constant.SYSTEM,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryGetListWebhookByEventType))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetListWebhookByEventType(ctx, constant.StockEventChanged)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WebhookRepository.GetListWebhookByEventType() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("WebhookRepository.GetListWebhookByEventType() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_DeleteWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "error when webhook not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteWebhook)).
+					WithArgs(webhookID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: dbutil.ErrDataNotFound,
+		},
+		{
+			name: "success delete webhook",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryDeleteWebhook)).
+					WithArgs(webhookID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryDeleteWebhook))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.DeleteWebhook(ctx, webhookID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("WebhookRepository.DeleteWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_CreateDeliveries(t *testing.T) {
+	uuidutil.UUIDHelper = mockUUIDHelper{id: deliveryID}
+
+	otherWebhookID := uuid.MustParse("3f6b2a10-8c4d-4e2f-9a71-5d0c1b2e3f41")
+	deliveries := domain.Deliveries{
+		{WebhookID: webhookID, EventID: eventID, EventType: constant.ProductEventCreated, Payload: deliveryPayload, Status: constant.WebhookDeliveryPending, NextAttemptAt: now, CreatedAt: now},
+		{WebhookID: otherWebhookID, EventID: eventID, EventType: constant.ProductEventCreated, Payload: deliveryPayload, Status: constant.WebhookDeliveryPending, NextAttemptAt: now, CreatedAt: now},
+	}
+
+	tests := []struct {
+		name       string
+		deliveries domain.Deliveries
+		mockFn     func(mockdb sqlmock.Sqlmock)
+		wantErr    bool
+	}{
+		{
+			name: "no delivery to create",
+		},
+		{
+			name:       "error when create deliveries",
+			deliveries: deliveries,
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateDeliveries)).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:       "success create deliveries",
+			deliveries: deliveries,
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryCreateDeliveries)).
+					WithArgs(
+						deliveryID, webhookID, eventID, constant.ProductEventCreated, string(deliveryPayload), constant.WebhookDeliveryPending, now, now,
+						deliveryID, otherWebhookID, eventID, constant.ProductEventCreated, string(deliveryPayload), constant.WebhookDeliveryPending, now, now,
+					).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.CreateDeliveries(ctx, tt.deliveries)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WebhookRepository.CreateDeliveries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_ClaimDueDeliveries(t *testing.T) {
+	leaseUntil := now.Add(20 * time.Second)
+	lastAttemptAt := now.Add(-time.Minute)
+	lastStatusCode := 503
+	lastError := "webhook responded with status 503: busy"
+	columns := append(append([]string{}, deliveryColumns...), "webhook_url", "webhook_secret")
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.Deliveries
+		wantErr bool
+	}{
+		{
+			name: "error when claim deliveries",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryClaimDueDeliveries)).
+					WithArgs(now, leaseUntil, 50, constant.WebhookDeliveryPending).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success claim deliveries",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryClaimDueDeliveries)).
+					WithArgs(now, leaseUntil, 50, constant.WebhookDeliveryPending).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(deliveryID, webhookID, eventID, constant.ProductEventCreated, deliveryPayload, constant.WebhookDeliveryPending, 1, leaseUntil, lastAttemptAt, lastStatusCode, lastError, nil, webhookCreatedAt, webhookURL, webhookSecret))
+			},
+			wantRes: domain.Deliveries{
+				{
+					ID:             deliveryID,
+					WebhookID:      webhookID,
+					EventID:        eventID,
+					EventType:      constant.ProductEventCreated,
+					Payload:        deliveryPayload,
+					Status:         constant.WebhookDeliveryPending,
+					Attempts:       1,
+					NextAttemptAt:  leaseUntil,
+					LastAttemptAt:  &lastAttemptAt,
+					LastStatusCode: &lastStatusCode,
+					LastError:      &lastError,
+					CreatedAt:      webhookCreatedAt,
+					Webhook: &domain.Webhook{
+						ID:     webhookID,
+						URL:    webhookURL,
+						Secret: webhookSecret,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryClaimDueDeliveries))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.ClaimDueDeliveries(ctx, now, leaseUntil, 50)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WebhookRepository.ClaimDueDeliveries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("WebhookRepository.ClaimDueDeliveries() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_MarkDeliveryAttempt(t *testing.T) {
+	statusCode := 204
+	attempt := domain.DeliveryAttempt{
+		DeliveryID:    deliveryID,
+		Status:        constant.WebhookDeliveryDelivered,
+		AttemptedAt:   now,
+		NextAttemptAt: now,
+		StatusCode:    &statusCode,
+		DeliveredAt:   &now,
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error when delivery not found",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryMarkDeliveryAttempt)).
+					WithArgs(deliveryID, constant.WebhookDeliveryDelivered, now, now, statusCode, nil, now).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success mark delivery attempt",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectExec(regexp.QuoteMeta(expectedQueryMarkDeliveryAttempt)).
+					WithArgs(deliveryID, constant.WebhookDeliveryDelivered, now, now, statusCode, nil, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(expectedQueryMarkDeliveryAttempt))
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			err := repo.MarkDeliveryAttempt(ctx, attempt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WebhookRepository.MarkDeliveryAttempt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_GetListDeliveryByWebhookID(t *testing.T) {
+	filter := domain.DeliveryFilter{Status: constant.WebhookDeliveryDead, Page: 2, Limit: 20}
+	lastError := "webhook responded with status 500: oops"
+
+	tests := []struct {
+		name    string
+		mockFn  func(mockdb sqlmock.Sqlmock)
+		wantRes domain.DeliveryPage
+		wantErr bool
+	}{
+		{
+			name: "error when count deliveries",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountDelivery)).
+					WithArgs(webhookID, constant.WebhookDeliveryDead).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success get deliveries",
+			mockFn: func(mockdb sqlmock.Sqlmock) {
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryCountDelivery)).
+					WithArgs(webhookID, constant.WebhookDeliveryDead).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(21))
+				mockdb.ExpectQuery(regexp.QuoteMeta(expectedQueryGetListDelivery)).
+					WithArgs(webhookID, constant.WebhookDeliveryDead, 20, 20).
+					WillReturnRows(sqlmock.NewRows(deliveryColumns).
+						AddRow(deliveryID, webhookID, eventID, constant.ProductEventCreated, deliveryPayload, constant.WebhookDeliveryDead, 8, now, now, nil, lastError, nil, webhookCreatedAt))
+			},
+			wantRes: domain.DeliveryPage{
+				Deliveries: domain.Deliveries{
+					{
+						ID:            deliveryID,
+						WebhookID:     webhookID,
+						EventID:       eventID,
+						EventType:     constant.ProductEventCreated,
+						Payload:       deliveryPayload,
+						Status:        constant.WebhookDeliveryDead,
+						Attempts:      8,
+						NextAttemptAt: now,
+						LastAttemptAt: &now,
+						LastError:     &lastError,
+						CreatedAt:     webhookCreatedAt,
+					},
+				},
+				Total: 21,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbx, mock := newMockDB(t)
+
+			if tt.mockFn != nil {
+				tt.mockFn(mock)
+			}
+
+			repo := postgres.New(postgres.InitAttribute{
+				DB: postgres.DB{
+					Db: dbx,
+				},
+			})
+
+			gotRes, err := repo.GetListDeliveryByWebhookID(ctx, webhookID, filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WebhookRepository.GetListDeliveryByWebhookID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotRes, tt.wantRes) {
+				t.Errorf("WebhookRepository.GetListDeliveryByWebhookID() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/domain"
+	"github.com/lib/pq"
+)
+
+type (
+	Webhook struct {
+		ID         uuid.UUID      `db:"id"`
+		URL        string         `db:"url"`
+		Secret     string         `db:"secret"`
+		EventTypes pq.StringArray `db:"event_types"`
+		CreatedAt  time.Time      `db:"created_at"`
+		CreatedBy  string         `db:"created_by"`
+	}
+
+	Webhooks []Webhook
+
+	// Delivery is a queued delivery, along with the URL and secret of its webhook when
+	// it is claimed for dispatch.
+	Delivery struct {
+		ID             uuid.UUID  `db:"id"`
+		WebhookID      uuid.UUID  `db:"webhook_id"`
+		EventID        uuid.UUID  `db:"event_id"`
+		EventType      string     `db:"event_type"`
+		Payload        []byte     `db:"payload"`
+		Status         string     `db:"status"`
+		Attempts       int        `db:"attempts"`
+		NextAttemptAt  time.Time  `db:"next_attempt_at"`
+		LastAttemptAt  *time.Time `db:"last_attempt_at"`
+		LastStatusCode *int       `db:"last_status_code"`
+		LastError      *string    `db:"last_error"`
+		DeliveredAt    *time.Time `db:"delivered_at"`
+		CreatedAt      time.Time  `db:"created_at"`
+		WebhookURL     *string    `db:"webhook_url"`
+		WebhookSecret  *string    `db:"webhook_secret"`
+	}
+
+	Deliveries []Delivery
+)
+
+func (w Webhook) Validate() bool {
+	if w.ID == uuid.Nil {
+		return false
+	}
+
+	if w.URL == "" || w.Secret == "" {
+		return false
+	}
+
+	return !w.CreatedAt.IsZero()
+}
+
+func (w Webhook) ToModel() domain.Webhook {
+	return domain.Webhook{
+		ID:         w.ID,
+		URL:        w.URL,
+		Secret:     w.Secret,
+		EventTypes: w.EventTypes,
+		CreatedAt:  w.CreatedAt,
+		CreatedBy:  w.CreatedBy,
+	}
+}
+
+func (w Webhooks) Validate() bool {
+	for _, webhook := range w {
+		if !webhook.Validate() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (w Webhooks) ToModel() domain.Webhooks {
+	var webhooks domain.Webhooks
+
+	for _, webhook := range w {
+		webhooks = append(webhooks, webhook.ToModel())
+	}
+
+	return webhooks
+}
+
+func (d Delivery) Validate() bool {
+	if d.ID == uuid.Nil || d.WebhookID == uuid.Nil || d.EventID == uuid.Nil {
+		return false
+	}
+
+	if d.EventType == "" || d.Status == "" {
+		return false
+	}
+
+	if (d.WebhookURL == nil) != (d.WebhookSecret == nil) {
+		return false
+	}
+
+	return len(d.Payload) > 0
+}
+
+func (d Delivery) ToModel() domain.Delivery {
+	delivery := domain.Delivery{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+
+	if d.WebhookURL != nil {
+		delivery.Webhook = &domain.Webhook{
+			ID:     d.WebhookID,
+			URL:    *d.WebhookURL,
+			Secret: *d.WebhookSecret,
+		}
+	}
+
+	return delivery
+}
+
+func (d Deliveries) Validate() bool {
+	for _, delivery := range d {
+		if !delivery.Validate() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d Deliveries) ToModel() domain.Deliveries {
+	var deliveries domain.Deliveries
+
+	for _, delivery := range d {
+		deliveries = append(deliveries, delivery.ToModel())
+	}
+
+	return deliveries
+}
@@ -0,0 +1,126 @@
+package postgres
+
+var (
+	queryCreateWebhook = `
+		INSERT INTO webhooks (
+			id,
+			url,
+			secret,
+			event_types,
+			created_at,
+			created_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	queryDeleteWebhook = `
+		DELETE FROM webhooks
+		WHERE id = $1
+	`
+
+	queryListWebhook = `
+		SELECT
+			w.id,
+			w.url,
+			w.secret,
+			w.event_types,
+			w.created_at,
+			w.created_by
+		FROM webhooks w
+	`
+
+	queryGetListWebhook = queryListWebhook + `
+		ORDER BY w.created_at ASC, w.id ASC
+	`
+
+	queryGetWebhookByID = queryListWebhook + `
+		WHERE w.id = $1
+	`
+
+	queryGetListWebhookByEventType = queryListWebhook + `
+		WHERE w.event_types @> ARRAY[$1]::VARCHAR[]
+		ORDER BY w.created_at ASC, w.id ASC
+	`
+
+	queryCreateDeliveries = `
+		INSERT INTO webhook_deliveries (
+			id,
+			webhook_id,
+			event_id,
+			event_type,
+			payload,
+			status,
+			next_attempt_at,
+			created_at
+		)
+		VALUES
+	`
+
+	queryCreateDeliveriesConflict = `
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`
+
+	queryDeliveryColumns = `
+			d.id,
+			d.webhook_id,
+			d.event_id,
+			d.event_type,
+			d.payload,
+			d.status,
+			d.attempts,
+			d.next_attempt_at,
+			d.last_attempt_at,
+			d.last_status_code,
+			d.last_error,
+			d.delivered_at,
+			d.created_at`
+
+	// queryClaimDueDeliveries moves the next attempt of the due deliveries it returns to
+	// the end of their lease. Locked deliveries are skipped, they are being claimed by
+	// another dispatcher.
+	queryClaimDueDeliveries = `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = $2
+		FROM webhooks w
+		WHERE
+			w.id = d.webhook_id AND
+			d.id IN (
+				SELECT id
+				FROM webhook_deliveries
+				WHERE
+					status = $4 AND
+					next_attempt_at <= $1
+				ORDER BY next_attempt_at ASC
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING` + queryDeliveryColumns + `,
+			w.url AS webhook_url,
+			w.secret AS webhook_secret
+	`
+
+	queryMarkDeliveryAttempt = `
+		UPDATE webhook_deliveries
+		SET
+			status = $2,
+			attempts = attempts + 1,
+			next_attempt_at = $3,
+			last_attempt_at = $4,
+			last_status_code = $5,
+			last_error = $6,
+			delivered_at = $7
+		WHERE id = $1
+	`
+
+	queryGetListDelivery = `
+		SELECT` + queryDeliveryColumns + `
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = ?
+	`
+
+	queryCountDelivery = `
+		SELECT COUNT(1)
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = ?
+	`
+)
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func (repo *WebhookRepository) prepareStatements() {
+	repo.statement = StatementList{}
+}
+
+func (repo *WebhookRepository) prepareCreateWebhook() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryCreateWebhook); err != nil {
+		log.Panic("[prepareCreateWebhook] error:", err)
+	}
+	repo.statement.CreateWebhook = stmt
+}
+
+func (repo *WebhookRepository) prepareGetListWebhook() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetListWebhook); err != nil {
+		log.Panic("[prepareGetListWebhook] error:", err)
+	}
+	repo.statement.GetListWebhook = stmt
+}
+
+func (repo *WebhookRepository) prepareGetWebhookByID() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetWebhookByID); err != nil {
+		log.Panic("[prepareGetWebhookByID] error:", err)
+	}
+	repo.statement.GetWebhookByID = stmt
+}
+
+func (repo *WebhookRepository) prepareDeleteWebhook() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryDeleteWebhook); err != nil {
+		log.Panic("[prepareDeleteWebhook] error:", err)
+	}
+	repo.statement.DeleteWebhook = stmt
+}
+
+func (repo *WebhookRepository) prepareGetListWebhookByEventType() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryGetListWebhookByEventType); err != nil {
+		log.Panic("[prepareGetListWebhookByEventType] error:", err)
+	}
+	repo.statement.GetListWebhookByEventType = stmt
+}
+
+func (repo *WebhookRepository) prepareClaimDueDeliveries() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryClaimDueDeliveries); err != nil {
+		log.Panic("[prepareClaimDueDeliveries] error:", err)
+	}
+	repo.statement.ClaimDueDeliveries = stmt
+}
+
+func (repo *WebhookRepository) prepareMarkDeliveryAttempt() {
+	var (
+		err  error
+		stmt *sqlx.Stmt
+		db   = repo.db.Db
+	)
+
+	if stmt, err = db.Preparex(queryMarkDeliveryAttempt); err != nil {
+		log.Panic("[prepareMarkDeliveryAttempt] error:", err)
+	}
+	repo.statement.MarkDeliveryAttempt = stmt
+}
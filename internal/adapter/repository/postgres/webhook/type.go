@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	WebhookRepository struct {
+		db        DB
+		statement StatementList
+	}
+
+	DB struct {
+		Db *sqlx.DB
+	}
+
+	StatementList struct {
+		CreateWebhook             *sqlx.Stmt
+		GetListWebhook            *sqlx.Stmt
+		GetWebhookByID            *sqlx.Stmt
+		DeleteWebhook             *sqlx.Stmt
+		GetListWebhookByEventType *sqlx.Stmt
+		ClaimDueDeliveries        *sqlx.Stmt
+		MarkDeliveryAttempt       *sqlx.Stmt
+	}
+
+	InitAttribute struct {
+		DB DB
+	}
+)
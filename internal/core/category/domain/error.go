@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+var (
+	ErrCategoryNotFound     = apperror.NotFound(constant.CodeCategoryNotFound, constant.CategoryNotFound)
+	ErrCategoryAlreadyExist = apperror.Conflict(constant.CodeCategoryAlreadyExist, constant.CategoryAlreadyExist)
+	ErrCategoryInUse        = apperror.Conflict(constant.CodeCategoryInUse, constant.CategoryInUse)
+)
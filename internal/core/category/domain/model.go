@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Category struct {
+	ID          uuid.UUID
+	Name        string
+	Description *string
+	CreatedAt   time.Time
+	CreatedBy   string
+	UpdatedAt   *time.Time
+	UpdatedBy   *string
+}
+
+type Categories []Category
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/category/domain"
+)
+
+type Repository interface {
+	CreateCategory(ctx context.Context, category domain.Category) (res uuid.UUID, err error)
+	GetListCategory(ctx context.Context, categoryName string) (res domain.Categories, err error)
+	GetCategoryByID(ctx context.Context, categoryID uuid.UUID) (res domain.Category, err error)
+	GetCategoryByName(ctx context.Context, categoryName string) (res domain.Category, err error)
+	UpdateCategory(ctx context.Context, category domain.Category) (err error)
+	DeleteCategory(ctx context.Context, categoryID uuid.UUID) (err error)
+	CountProductByCategoryID(ctx context.Context, categoryID uuid.UUID) (res int, err error)
+}
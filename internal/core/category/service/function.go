@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/category/domain"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/timeutil"
+)
+
+// CreateCategory creates a new category in the system. It first checks if a category with
+// the same name already exists. If not, it proceeds to create the category and assigns a
+// new ID to it.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - category: domain.Category containing the details of the category to be created.
+//
+// Returns:
+// - res: domain.Category representing the newly created category.
+// - err: error if an error occurs during the creation process.
+func (service *CategoryService) CreateCategory(ctx context.Context, category domain.Category) (res domain.Category, err error) {
+	err = service.checkCategoryNameAvailable(ctx, uuid.Nil, category.Name)
+	if err != nil {
+		return res, err
+	}
+
+	now := timeutil.TimeHelper.Now()
+	newCategory := domain.Category{
+		Name:        category.Name,
+		Description: category.Description,
+		CreatedAt:   now,
+		CreatedBy:   constant.SYSTEM,
+	}
+
+	categoryID, err := service.repo.CategoryRepo.CreateCategory(ctx, newCategory)
+	if err != nil {
+		return res, err
+	}
+
+	newCategory.ID = categoryID
+
+	return newCategory, nil
+}
+
+// GetListCategory retrieves a list of categories, optionally filtered by name.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - categoryName: The name of the category to filter by (can be a partial match).
+//
+// Returns:
+// - res: domain.Categories representing the list of categories that match the criteria.
+// - err: error if an error occurs during the retrieval process.
+func (service *CategoryService) GetListCategory(ctx context.Context, categoryName string) (res domain.Categories, err error) {
+	res, err = service.repo.CategoryRepo.GetListCategory(ctx, categoryName)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// GetCategoryByID retrieves a category by ID from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - categoryID: The ID of the category to retrieve.
+//
+// Returns:
+// - res: domain.Category representing the category with the provided ID.
+// - err: error if an error occurs during the retrieval process.
+func (service *CategoryService) GetCategoryByID(ctx context.Context, categoryID uuid.UUID) (res domain.Category, err error) {
+	res, err = service.repo.CategoryRepo.GetCategoryByID(ctx, categoryID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrCategoryNotFound
+		}
+
+		return res, err
+	}
+
+	return res, nil
+}
+
+// GetCategoryByName retrieves a category by its exact name from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - categoryName: The name of the category to retrieve.
+//
+// Returns:
+// - res: domain.Category representing the category with the provided name.
+// - err: error if an error occurs during the retrieval process.
+func (service *CategoryService) GetCategoryByName(ctx context.Context, categoryName string) (res domain.Category, err error) {
+	res, err = service.repo.CategoryRepo.GetCategoryByName(ctx, categoryName)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrCategoryNotFound
+		}
+
+		return res, err
+	}
+
+	return res, nil
+}
+
+// UpdateCategory replaces the name and description of an existing category. The new name
+// must not be used by another category.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - category: domain.Category containing the ID of the category and its new details.
+//
+// Returns:
+// - res: domain.Category representing the updated category.
+// - err: error if an error occurs during the update process.
+func (service *CategoryService) UpdateCategory(ctx context.Context, category domain.Category) (res domain.Category, err error) {
+	existing, err := service.GetCategoryByID(ctx, category.ID)
+	if err != nil {
+		return res, err
+	}
+
+	err = service.checkCategoryNameAvailable(ctx, existing.ID, category.Name)
+	if err != nil {
+		return res, err
+	}
+
+	now := timeutil.TimeHelper.Now()
+	updatedBy := constant.SYSTEM
+	updatedCategory := domain.Category{
+		ID:          existing.ID,
+		Name:        category.Name,
+		Description: category.Description,
+		CreatedAt:   existing.CreatedAt,
+		CreatedBy:   existing.CreatedBy,
+		UpdatedAt:   &now,
+		UpdatedBy:   &updatedBy,
+	}
+
+	err = service.repo.CategoryRepo.UpdateCategory(ctx, updatedCategory)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrCategoryNotFound
+		}
+
+		return res, err
+	}
+
+	return updatedCategory, nil
+}
+
+// DeleteCategory removes a category. The deletion is refused while any product, including
+// soft-deleted ones, still references the category.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - categoryID: The ID of the category to delete.
+//
+// Returns:
+// - err: error if an error occurs during the deletion process.
+func (service *CategoryService) DeleteCategory(ctx context.Context, categoryID uuid.UUID) (err error) {
+	total, err := service.repo.CategoryRepo.CountProductByCategoryID(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+
+	if total > 0 {
+		return domain.ErrCategoryInUse
+	}
+
+	err = service.repo.CategoryRepo.DeleteCategory(ctx, categoryID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return domain.ErrCategoryNotFound
+		}
+
+		// a product got added to the category in the meantime
+		if errors.Is(err, apperror.ErrConflict) {
+			return domain.ErrCategoryInUse
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// checkCategoryNameAvailable makes sure no other category already uses the given name.
+// categoryID is the category being saved, or uuid.Nil for a new one.
+func (service *CategoryService) checkCategoryNameAvailable(ctx context.Context, categoryID uuid.UUID, categoryName string) error {
+	result, err := service.repo.CategoryRepo.GetCategoryByName(ctx, categoryName)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return err
+		}
+	}
+
+	if result.ID != uuid.Nil && result.ID != categoryID {
+		return domain.ErrCategoryAlreadyExist
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"fmt"
+	"log"
+)
+
+func New(attr InitAttribute) *CategoryService {
+	if err := attr.validate(); err != nil {
+		log.Panic(err)
+	}
+
+	return &CategoryService{
+		repo:   attr.Repo,
+		config: attr.Config,
+	}
+}
+
+func (attr InitAttribute) validate() error {
+	if !attr.Repo.validate() {
+		return fmt.Errorf("missing category repo : %+v", attr.Repo.CategoryRepo)
+	}
+
+	return nil
+}
+
+func (repo RepoAttribute) validate() bool {
+	return repo.CategoryRepo != nil
+}
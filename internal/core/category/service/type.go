@@ -0,0 +1,26 @@
+package service
+
+import (
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/gunawanpras/be-product-service/internal/core/category/port"
+)
+
+type (
+	RepoAttribute struct {
+		CategoryRepo port.Repository
+	}
+
+	ConfigAttribute struct {
+		Config *config.Config
+	}
+
+	CategoryService struct {
+		repo   RepoAttribute
+		config ConfigAttribute
+	}
+
+	InitAttribute struct {
+		Repo   RepoAttribute
+		Config ConfigAttribute
+	}
+)
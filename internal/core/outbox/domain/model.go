@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is a product change recorded in the outbox. Sequence orders the events, the
+// events of a product are published in the order of their sequence.
+type Event struct {
+	ID        uuid.UUID
+	Sequence  int64
+	ProductID uuid.UUID
+	Type      string
+	Payload   []byte
+	CreatedAt time.Time
+	Attempts  int
+}
+
+type Events []Event
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/domain"
+)
+
+// EventPublisher hands the events of the outbox over to a broker. Publish returns once
+// the broker has accepted the event, an event may be published more than once.
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.Event) (err error)
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/domain"
+)
+
+type Repository interface {
+	// WithRelayLock runs fn while holding the lock of the relay, so that a single
+	// instance relays the outbox at a time. It reports false without running fn when
+	// another instance holds the lock.
+	WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (locked bool, err error)
+	GetListPendingEvent(ctx context.Context, now time.Time, limit int) (res domain.Events, err error)
+	MarkEventPublished(ctx context.Context, eventID uuid.UUID, publishedAt time.Time) (err error)
+	MarkEventFailed(ctx context.Context, eventID uuid.UUID, nextAttemptAt time.Time, lastError string) (err error)
+}
@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+)
+
+type Service interface {
+	RunRelay(ctx context.Context)
+	RelayEvents(ctx context.Context) (res int, err error)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/timeutil"
+)
+
+// RunRelay relays the events of the outbox until ctx is done. It polls the outbox every
+// poll interval, and right away again as long as full batches of events get published.
+//
+// Parameters:
+// - ctx: Context whose cancellation stops the relay.
+func (s *OutboxService) RunRelay(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval())
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		published, err := s.RelayEvents(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[RunRelay] error: %v", err)
+		}
+
+		if err == nil && published == s.batchSize() {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayEvents publishes a batch of the pending events of the outbox, in the order they
+// were recorded. An event that fails to publish is retried after a delay which doubles
+// with each attempt, the later events of its product are held back until then so that
+// the events of a product are always published in order. Since an event is only marked
+// as published once the publisher has accepted it, events are published at least once.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+//
+// Returns:
+// - res: The number of events published.
+// - err: error if the outbox could not be read or updated.
+func (s *OutboxService) RelayEvents(ctx context.Context) (res int, err error) {
+	_, err = s.repo.OutboxRepo.WithRelayLock(ctx, func(ctx context.Context) error {
+		events, err := s.repo.OutboxRepo.GetListPendingEvent(ctx, timeutil.TimeHelper.Now(), s.batchSize())
+		if err != nil {
+			return err
+		}
+
+		failed := make(map[uuid.UUID]bool)
+
+		for _, event := range events {
+			if failed[event.ProductID] {
+				continue
+			}
+
+			if errPublish := s.publisher.EventPublisher.Publish(ctx, event); errPublish != nil {
+				failed[event.ProductID] = true
+
+				nextAttemptAt := timeutil.TimeHelper.Now().Add(s.retryDelay(event.Attempts))
+				if err = s.repo.OutboxRepo.MarkEventFailed(ctx, event.ID, nextAttemptAt, errPublish.Error()); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if err = s.repo.OutboxRepo.MarkEventPublished(ctx, event.ID, timeutil.TimeHelper.Now()); err != nil {
+				return err
+			}
+
+			res++
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+// retryDelay is the delay before the next attempt of an event which failed after the
+// given number of previous attempts, within the bounds of the outbox config.
+func (s *OutboxService) retryDelay(attempts int) time.Duration {
+	conf := s.config.Config.Outbox
+
+	base := time.Duration(conf.RetryBaseDelayInMillisecond) * time.Millisecond
+	if base <= 0 {
+		base = constant.OutboxRetryBaseDelay * time.Millisecond
+	}
+
+	maxDelay := time.Duration(conf.RetryMaxDelayInMillisecond) * time.Millisecond
+	if maxDelay <= 0 {
+		maxDelay = constant.OutboxRetryMaxDelay * time.Millisecond
+	}
+
+	return timeutil.Backoff(attempts, base, maxDelay)
+}
+
+func (s *OutboxService) pollInterval() time.Duration {
+	interval := s.config.Config.Outbox.PollIntervalInMillisecond
+	if interval <= 0 {
+		interval = constant.OutboxPollInterval
+	}
+
+	return time.Duration(interval) * time.Millisecond
+}
+
+func (s *OutboxService) batchSize() int {
+	size := s.config.Config.Outbox.BatchSize
+	if size <= 0 {
+		size = constant.OutboxBatchSize
+	}
+
+	return size
+}
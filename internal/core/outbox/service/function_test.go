@@ -0,0 +1,182 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/gunawanpras/be-product-service/internal/adapter/publisher/memory"
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/domain"
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/service"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubRepository is an outbox held in memory, which lists its pending events the way
+// the postgres outbox does.
+type stubRepository struct {
+	events        domain.Events
+	published     map[uuid.UUID]bool
+	nextAttemptAt map[uuid.UUID]time.Time
+	lockHeld      bool
+}
+
+func newStubRepository(events ...domain.Event) *stubRepository {
+	return &stubRepository{
+		events:        events,
+		published:     make(map[uuid.UUID]bool),
+		nextAttemptAt: make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (r *stubRepository) WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	if r.lockHeld {
+		return false, nil
+	}
+
+	return true, fn(ctx)
+}
+
+func (r *stubRepository) GetListPendingEvent(ctx context.Context, now time.Time, limit int) (res domain.Events, err error) {
+	waiting := make(map[uuid.UUID]bool)
+	for _, event := range r.events {
+		if !r.published[event.ID] && r.nextAttemptAt[event.ID].After(now) {
+			waiting[event.ProductID] = true
+		}
+	}
+
+	for _, event := range r.events {
+		if len(res) < limit && !r.published[event.ID] && !waiting[event.ProductID] {
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+func (r *stubRepository) MarkEventPublished(ctx context.Context, eventID uuid.UUID, publishedAt time.Time) error {
+	r.published[eventID] = true
+	return nil
+}
+
+func (r *stubRepository) MarkEventFailed(ctx context.Context, eventID uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	for i := range r.events {
+		if r.events[i].ID == eventID {
+			r.events[i].Attempts++
+		}
+	}
+
+	r.nextAttemptAt[eventID] = nextAttemptAt
+	return nil
+}
+
+func newEvent(sequence int64, productID uuid.UUID, eventType string) domain.Event {
+	return domain.Event{
+		ID:        uuid.New(),
+		Sequence:  sequence,
+		ProductID: productID,
+		Type:      eventType,
+		Payload:   []byte(`{}`),
+	}
+}
+
+func newService(repo *stubRepository, publisher *memory.EventPublisher) *service.OutboxService {
+	conf := &config.Config{
+		Outbox: config.OutboxConfig{
+			BatchSize:                   10,
+			RetryBaseDelayInMillisecond: 1000,
+			RetryMaxDelayInMillisecond:  5000,
+		},
+	}
+
+	return service.New(service.InitAttribute{
+		Repo:      service.RepoAttribute{OutboxRepo: repo},
+		Publisher: service.PublisherAttribute{EventPublisher: publisher},
+		Config:    service.ConfigAttribute{Config: conf},
+	})
+}
+
+func TestOutboxService_RelayEvents(t *testing.T) {
+	ctx := context.Background()
+	kangkung, bayam := uuid.New(), uuid.New()
+
+	created := newEvent(1, kangkung, "product.created")
+	updated := newEvent(2, kangkung, "product.updated")
+	other := newEvent(3, bayam, "product.created")
+
+	t.Run("publishes the pending events in order", func(t *testing.T) {
+		repo := newStubRepository(created, updated, other)
+		publisher := memory.NewEventPublisher()
+
+		res, err := newService(repo, publisher).RelayEvents(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, res)
+		assert.Equal(t, domain.Events{created, updated, other}, publisher.Events())
+
+		res, err = newService(repo, publisher).RelayEvents(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, res)
+	})
+
+	t.Run("holds back the events of a product until its failed event is retried", func(t *testing.T) {
+		repo := newStubRepository(created, updated)
+		publisher := memory.NewEventPublisher()
+		outbox := newService(repo, publisher)
+
+		publisher.FailWith(errors.New("nats: timeout"))
+		before := time.Now()
+
+		res, err := outbox.RelayEvents(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, res)
+		assert.WithinRange(t, repo.nextAttemptAt[created.ID], before.Add(time.Second), time.Now().Add(time.Second))
+		assert.NotContains(t, repo.nextAttemptAt, updated.ID)
+
+		publisher.FailWith(nil)
+
+		res, err = outbox.RelayEvents(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, res)
+
+		repo.nextAttemptAt[created.ID] = time.Now()
+
+		res, err = outbox.RelayEvents(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, res)
+		assert.Equal(t, []uuid.UUID{created.ID, updated.ID}, []uuid.UUID{publisher.Events()[0].ID, publisher.Events()[1].ID})
+	})
+
+	t.Run("doubles the retry delay up to the max delay", func(t *testing.T) {
+		failed := created
+		failed.Attempts = 2
+
+		repo := newStubRepository(failed)
+		publisher := memory.NewEventPublisher()
+		publisher.FailWith(errors.New("nats: timeout"))
+
+		before := time.Now()
+		_, err := newService(repo, publisher).RelayEvents(ctx)
+		assert.NoError(t, err)
+		assert.WithinRange(t, repo.nextAttemptAt[created.ID], before.Add(4*time.Second), time.Now().Add(4*time.Second))
+
+		repo.events[0].Attempts = 5
+		repo.nextAttemptAt[created.ID] = time.Now()
+		before = time.Now()
+		_, err = newService(repo, publisher).RelayEvents(ctx)
+		assert.NoError(t, err)
+		assert.WithinRange(t, repo.nextAttemptAt[created.ID], before.Add(5*time.Second), time.Now().Add(5*time.Second))
+	})
+
+	t.Run("leaves the outbox to the instance holding the lock", func(t *testing.T) {
+		repo := newStubRepository(created)
+		repo.lockHeld = true
+		publisher := memory.NewEventPublisher()
+
+		res, err := newService(repo, publisher).RelayEvents(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, res)
+		assert.Empty(t, publisher.Events())
+	})
+}
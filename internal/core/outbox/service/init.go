@@ -0,0 +1,42 @@
+package service
+
+import (
+	"fmt"
+	"log"
+)
+
+func New(attr InitAttribute) *OutboxService {
+	if err := attr.validate(); err != nil {
+		log.Panic(err)
+	}
+
+	return &OutboxService{
+		repo:      attr.Repo,
+		publisher: attr.Publisher,
+		config:    attr.Config,
+	}
+}
+
+func (attr InitAttribute) validate() error {
+	if !attr.Repo.validate() {
+		return fmt.Errorf("missing outbox repo : %+v", attr.Repo.OutboxRepo)
+	}
+
+	if !attr.Publisher.validate() {
+		return fmt.Errorf("missing event publisher : %+v", attr.Publisher.EventPublisher)
+	}
+
+	if attr.Config.Config == nil {
+		return fmt.Errorf("missing config : %+v", attr.Config.Config)
+	}
+
+	return nil
+}
+
+func (repo RepoAttribute) validate() bool {
+	return repo.OutboxRepo != nil
+}
+
+func (publisher PublisherAttribute) validate() bool {
+	return publisher.EventPublisher != nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/port"
+)
+
+type (
+	RepoAttribute struct {
+		OutboxRepo port.Repository
+	}
+
+	PublisherAttribute struct {
+		EventPublisher port.EventPublisher
+	}
+
+	ConfigAttribute struct {
+		Config *config.Config
+	}
+
+	OutboxService struct {
+		repo      RepoAttribute
+		publisher PublisherAttribute
+		config    ConfigAttribute
+	}
+
+	InitAttribute struct {
+		Repo      RepoAttribute
+		Publisher PublisherAttribute
+		Config    ConfigAttribute
+	}
+)
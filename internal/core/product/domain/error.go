@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+var (
+	ErrProductNotFound              = apperror.NotFound(constant.CodeProductNotFound, constant.ProductNotFound)
+	ErrProductAlreadyExist          = apperror.Conflict(constant.CodeProductAlreadyExist, constant.ProductAlreadyExist)
+	ErrProductPreconditionFailed    = apperror.PreconditionFailed(constant.CodeProductPreconditionFailed, constant.ProductPreconditionFailed)
+	ErrProductDiscountNotFound      = apperror.NotFound(constant.CodeProductDiscountNotFound, constant.ProductDiscountNotFound)
+	ErrProductDiscountAlreadyExist  = apperror.Conflict(constant.CodeProductDiscountAlreadyExist, constant.ProductDiscountAlreadyExist)
+	ErrProductDiscountInvalidPeriod = apperror.Unprocessable(constant.CodeProductDiscountInvalidPeriod, constant.ProductDiscountInvalidPeriod)
+	ErrProductPageSizeTooLarge      = apperror.Validation(constant.CodeProductPageSizeTooLarge, constant.ProductPageSizeTooLarge)
+	ErrProductInvalidFilterRange    = apperror.Validation(constant.CodeProductInvalidFilterRange, constant.ProductInvalidFilterRange)
+	ErrProductInvalidCursor         = apperror.Validation(constant.CodeProductInvalidCursor, constant.ProductInvalidCursor)
+	ErrProductSortRequiresQuery     = apperror.Validation(constant.CodeProductSortRequiresQuery, constant.ProductSortRequiresQuery)
+	ErrProductInvalidFields         = apperror.Validation(constant.CodeProductInvalidFields, constant.ProductInvalidFields)
+	ErrProductInvalidInclude        = apperror.Validation(constant.CodeProductInvalidInclude, constant.ProductInvalidInclude)
+	ErrProductBatchTooLarge         = apperror.Validation(constant.CodeProductBatchTooLarge, constant.ProductBatchTooLarge)
+	ErrProductBulkTooLarge          = apperror.Validation(constant.CodeProductBulkTooLarge, constant.ProductBulkTooLarge)
+	ErrProductBulkItemInvalid       = apperror.Validation(constant.CodeProductBulkItemInvalid, constant.ProductBulkItemInvalid)
+	ErrProductBulkAborted           = apperror.Unprocessable(constant.CodeProductBulkAborted, constant.ProductBulkAborted)
+	ErrProductReferenceNotFound     = apperror.Unprocessable(constant.CodeProductReferenceNotFound, constant.ProductReferenceNotFound)
+	ErrProductInsufficientStock     = apperror.Unprocessable(constant.CodeProductInsufficientStock, constant.ProductInsufficientStock)
+
+	ErrStockMovementAlreadyExist    = apperror.Conflict(constant.CodeStockMovementAlreadyExist, constant.StockMovementAlreadyExist)
+	ErrStockMovementInvalidQuantity = apperror.Validation(constant.CodeStockMovementInvalidQuantity, constant.StockMovementInvalidQuantity)
+
+	ErrProductImportUnsupportedFormat = apperror.Validation(constant.CodeProductImportUnsupportedFormat, constant.ProductImportUnsupportedFormat)
+	ErrProductImportInvalidHeader     = apperror.Validation(constant.CodeProductImportInvalidHeader, constant.ProductImportInvalidHeader)
+	ErrProductImportMalformed         = apperror.Validation(constant.CodeProductImportMalformed, constant.ProductImportMalformed)
+	ErrProductImportChunkFailed       = apperror.Internal(constant.CodeProductImportChunkFailed, constant.ProductImportChunkFailed)
+)
@@ -1,9 +1,14 @@
 package domain
 
 import (
+	"math"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/pageutil"
 )
 
 type Product struct {
@@ -19,6 +24,442 @@ type Product struct {
 	CreatedBy   string
 	UpdatedAt   *time.Time
 	UpdatedBy   *string
+	DeletedAt   *time.Time
+	DeletedBy   *string
+	Version     int
+	Discount    *ProductDiscount
+	Match       *ProductMatch
+	Category    *ProductRelation
+	Supplier    *ProductRelation
+	Unit        *ProductRelation
+}
+
+// ProductRelation is a record a product refers to, such as its category, read along
+// with the product so that clients get its name without another request.
+type ProductRelation struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// ProductSuggestion is a product name completing what a user is typing.
+type ProductSuggestion struct {
+	ID   uuid.UUID
+	Name string
+}
+
+type ProductSuggestions []ProductSuggestion
+
+// FacetCount is the number of products sharing a category, supplier or unit.
+type FacetCount struct {
+	ID    uuid.UUID
+	Name  string
+	Count int
+}
+
+// PriceBucketCount is the number of products priced from Min, included, to Max, excluded.
+// Min is nil for the bucket below the lowest bound and Max for the one above the highest.
+type PriceBucketCount struct {
+	Min   *float64
+	Max   *float64
+	Count int
+}
+
+// ProductFacets counts the products matching a filter by category, supplier, unit and
+// price bucket. Each facet ignores the criteria of its own dimension, so that it also
+// counts the values a client may switch to.
+type ProductFacets struct {
+	Categories   []FacetCount
+	Suppliers    []FacetCount
+	Units        []FacetCount
+	PriceBuckets []PriceBucketCount
+}
+
+// ProductMatch tells how well a product matches a full-text search. The highlights are
+// snippets of the name and description with the matching words marked.
+type ProductMatch struct {
+	Rank                 float64
+	NameHighlight        string
+	DescriptionHighlight string
 }
 
 type Products []Product
+
+// ProductBulkItem is a product of a bulk creation along with its outcome: its Status, see
+// constant.ProductBulkCreated and the like, and Err telling why it was not created.
+// Products failing validation come in with Err already set.
+type ProductBulkItem struct {
+	Product Product
+	Status  string
+	Err     error
+}
+
+// ProductImport sums up the import of a catalog file. Rows only holds the rows that were
+// not created, or that would not be on a dry run, in the order of the file.
+type ProductImport struct {
+	DryRun  bool
+	Total   int
+	Created int
+	Valid   int
+	Failed  int
+	Rows    []ProductImportRow
+}
+
+// ProductImportRow is the outcome of a row of a catalog file: its Status, as for a
+// ProductBulkItem, Err telling why it was not created and the columns failing validation.
+type ProductImportRow struct {
+	Line        int
+	Name        string
+	Status      string
+	Err         error
+	FieldErrors []ProductFieldError
+}
+
+// ProductFieldError is a failed validation of a field, Code naming the failed rule.
+type ProductFieldError struct {
+	Field string
+	Code  string
+	Param string
+}
+
+// ProductBulkOptions tells how a bulk of products is created: Atomic creates every
+// product or none, and DryRun checks the products without creating any of them.
+type ProductBulkOptions struct {
+	Atomic bool
+	DryRun bool
+}
+
+// ProductReferences holds, among the categories, suppliers and units referenced by some
+// products, the ones that exist.
+type ProductReferences struct {
+	CategoryIDs map[uuid.UUID]bool
+	SupplierIDs map[uuid.UUID]bool
+	UnitIDs     map[uuid.UUID]bool
+}
+
+// Resolve reports whether the category, the supplier and the unit of product all exist.
+func (r ProductReferences) Resolve(product Product) bool {
+	return r.CategoryIDs[product.CategoryID] && r.SupplierIDs[product.SupplierID] && r.UnitIDs[product.UnitID]
+}
+
+// ProductBatch holds the products read by their IDs, in the order they were asked for,
+// and the IDs no active product has.
+type ProductBatch struct {
+	Products Products
+	Missing  []uuid.UUID
+}
+
+// ProductFilter selects the products of a list. Criteria left to their zero value are
+// not applied, and multiple IDs of the same kind match any of them. Query is a full-text
+// search on the name and description, in web search syntax. Fuzzy makes Query match the
+// names similar to it instead, to tolerate typos.
+type ProductFilter struct {
+	Query          string
+	Fuzzy          bool
+	ProductName    string
+	CategoryType   string
+	CategoryIDs    []uuid.UUID
+	SupplierIDs    []uuid.UUID
+	UnitIDs        []uuid.UUID
+	MinPrice       *float64
+	MaxPrice       *float64
+	MinStock       *int
+	MaxStock       *int
+	InStock        bool
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	IncludeDeleted bool
+}
+
+// Validate makes sure every range of the filter has its lower bound below its upper bound.
+func (f ProductFilter) Validate() error {
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		return ErrProductInvalidFilterRange
+	}
+
+	if f.MinStock != nil && f.MaxStock != nil && *f.MinStock > *f.MaxStock {
+		return ErrProductInvalidFilterRange
+	}
+
+	if f.CreatedFrom != nil && f.CreatedTo != nil && f.CreatedFrom.After(*f.CreatedTo) {
+		return ErrProductInvalidFilterRange
+	}
+
+	return nil
+}
+
+// ProductSort orders a product list by each of its keys in turn. Products equal on every
+// key are ordered by their ID, so the order is always the same.
+type ProductSort struct {
+	Keys []pageutil.SortKey
+}
+
+// ProductCursor is the position right after a product in a sorted list: the values of the
+// sort keys of that product, nil where it has none, and its ID to break ties between
+// equal values. Sort holds the keys the cursor was issued for.
+type ProductCursor struct {
+	Sort   []pageutil.SortKey
+	Values []*string
+	ID     uuid.UUID
+}
+
+// Matches reports whether the cursor was issued for a list sorted by sort.
+func (c ProductCursor) Matches(sort ProductSort) bool {
+	return slices.Equal(c.Sort, sort.Keys) && len(c.Values) == len(sort.Keys)
+}
+
+// Validate checks that every value of the cursor is one NewProductCursor could have issued
+// for its sort key: nil only for a nullable field, otherwise parsable as that field's type.
+func (c ProductCursor) Validate() error {
+	if len(c.Values) != len(c.Sort) {
+		return ErrProductInvalidCursor
+	}
+
+	for i, key := range c.Sort {
+		value := c.Values[i]
+		if value == nil {
+			if !pageutil.ItemExists(constant.NullableProductSort, key.Field) {
+				return ErrProductInvalidCursor
+			}
+
+			continue
+		}
+
+		var err error
+
+		switch key.Field {
+		case constant.ProductSortBasePrice, constant.ProductSortRelevance:
+			_, err = strconv.ParseFloat(*value, 64)
+		case constant.ProductSortProductName:
+		case constant.ProductSortStock:
+			_, err = strconv.Atoi(*value)
+		case constant.ProductSortCreatedAt, constant.ProductSortUpdatedAt:
+			_, err = time.Parse(time.RFC3339Nano, *value)
+		default:
+			return ErrProductInvalidCursor
+		}
+
+		if err != nil {
+			return ErrProductInvalidCursor
+		}
+	}
+
+	return nil
+}
+
+// ListPage selects a page of a product list, either the page following the cursor After
+// or, for offset pagination, the page with the given Number starting at 1. A zero Limit
+// means no limit.
+type ListPage struct {
+	Limit  int
+	Number int
+	After  *ProductCursor
+}
+
+// Offset returns the number of products preceding a numbered page.
+func (p ListPage) Offset() int {
+	if p.Number < 1 {
+		return 0
+	}
+
+	return (p.Number - 1) * p.Limit
+}
+
+// ProductPage is a page of a product list. Next is nil on the last page and for numbered
+// pages, which get the number of products matching the list filters in Total instead.
+type ProductPage struct {
+	Products Products
+	Next     *ProductCursor
+	Total    int
+}
+
+// NewProductCursor returns the cursor following product in a list sorted by sort.
+func NewProductCursor(product Product, sort ProductSort) ProductCursor {
+	cursor := ProductCursor{
+		Sort: sort.Keys,
+		ID:   product.ID,
+	}
+
+	for _, key := range sort.Keys {
+		var value *string
+
+		switch key.Field {
+		case constant.ProductSortBasePrice:
+			value = ptr(strconv.FormatFloat(product.BasePrice, 'f', -1, 64))
+		case constant.ProductSortProductName:
+			value = ptr(product.Name)
+		case constant.ProductSortStock:
+			value = ptr(strconv.Itoa(product.Stock))
+		case constant.ProductSortUpdatedAt:
+			if product.UpdatedAt != nil {
+				value = ptr(product.UpdatedAt.Format(time.RFC3339Nano))
+			}
+		case constant.ProductSortRelevance:
+			// relevance is sorted on the negated rank, best matches first
+			var rank float64
+			if product.Match != nil {
+				rank = product.Match.Rank
+			}
+
+			value = ptr(strconv.FormatFloat(-rank, 'f', -1, 64))
+		default:
+			value = ptr(product.CreatedAt.Format(time.RFC3339Nano))
+		}
+
+		cursor.Values = append(cursor.Values, value)
+	}
+
+	return cursor
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// ProductDiscount is the discount window of a product. Both dates are inclusive
+// and only their calendar date is relevant.
+type ProductDiscount struct {
+	ProductID       uuid.UUID
+	DiscountPercent float64
+	StartDate       time.Time
+	EndDate         time.Time
+	MaxPurchaseQty  *int
+	CreatedAt       time.Time
+	CreatedBy       string
+	UpdatedAt       *time.Time
+	UpdatedBy       *string
+}
+
+// IsActive reports whether the discount window contains the date of now.
+func (d ProductDiscount) IsActive(now time.Time) bool {
+	today := truncateDate(now)
+
+	return !today.Before(truncateDate(d.StartDate)) && !today.After(truncateDate(d.EndDate))
+}
+
+// EffectivePrice returns the price a customer pays at the given time, and whether a
+// discount was applied to reach it. Prices are rounded to two decimals.
+func (p Product) EffectivePrice(now time.Time) (price float64, discounted bool) {
+	if p.Discount == nil || !p.Discount.IsActive(now) {
+		return p.BasePrice, false
+	}
+
+	price = p.BasePrice * (100 - p.Discount.DiscountPercent) / 100
+
+	return math.Round(price*100) / 100, true
+}
+
+// truncateDate drops the clock part of t, keeping its calendar date.
+func truncateDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// StockMovement is an entry of the stock ledger of a product. Quantity is the change of
+// stock made by the movement, negative when the stock goes down, and StockAfter the stock
+// of the product right after it. ReferenceID points to the record behind the movement,
+// such as an order, and is unique per product and type of movement.
+type StockMovement struct {
+	ID          uuid.UUID
+	ProductID   uuid.UUID
+	Type        string
+	Quantity    int
+	StockAfter  int
+	Reason      *string
+	ReferenceID *string
+	Actor       string
+	CreatedAt   time.Time
+}
+
+type StockMovements []StockMovement
+
+// SignQuantity turns the quantity of a movement as given by a client into the change of
+// stock it makes. Receipts, sales and returns are given the number of items moved, which
+// must be positive, and sales take them from the stock. Adjustments and transfers are
+// given the change itself, negative when the stock goes down.
+func (m StockMovement) SignQuantity() (StockMovement, error) {
+	switch m.Type {
+	case constant.StockMovementReceipt, constant.StockMovementSale, constant.StockMovementReturn:
+		if m.Quantity <= 0 {
+			return m, ErrStockMovementInvalidQuantity
+		}
+
+		if m.Type == constant.StockMovementSale {
+			m.Quantity = -m.Quantity
+		}
+	default:
+		if m.Quantity == 0 {
+			return m, ErrStockMovementInvalidQuantity
+		}
+	}
+
+	return m, nil
+}
+
+// StockMovementFilter selects the movements of a stock history, every type of movement
+// when Type is empty.
+type StockMovementFilter struct {
+	Type string
+}
+
+// StockHistoryPage is a page of the stock history of a product, newest movement first,
+// along with the number of movements matching the filter.
+type StockHistoryPage struct {
+	Movements StockMovements
+	Total     int
+}
+
+// ProductVersions holds the versions a client expects a product to be at, one per entity
+// tag of its If-Match header. No version skips the check.
+type ProductVersions []int
+
+// Match tells whether version is one of the expected versions, or none was expected.
+func (v ProductVersions) Match(version int) bool {
+	return len(v) == 0 || slices.Contains(v, version)
+}
+
+// ProductPatch holds a JSON merge-patch document for a product. Nil fields are
+// left untouched, while RemoveDescription clears the nullable description.
+type ProductPatch struct {
+	CategoryID        *uuid.UUID
+	SupplierID        *uuid.UUID
+	UnitID            *uuid.UUID
+	Name              *string
+	Description       *string
+	RemoveDescription bool
+	BasePrice         *float64
+	Stock             *int
+}
+
+// ApplyPatch returns a copy of the product with the patch merged into it.
+func (p Product) ApplyPatch(patch ProductPatch) Product {
+	if patch.CategoryID != nil {
+		p.CategoryID = *patch.CategoryID
+	}
+
+	if patch.SupplierID != nil {
+		p.SupplierID = *patch.SupplierID
+	}
+
+	if patch.UnitID != nil {
+		p.UnitID = *patch.UnitID
+	}
+
+	if patch.Name != nil {
+		p.Name = *patch.Name
+	}
+
+	if patch.RemoveDescription {
+		p.Description = nil
+	} else if patch.Description != nil {
+		p.Description = patch.Description
+	}
+
+	if patch.BasePrice != nil {
+		p.BasePrice = *patch.BasePrice
+	}
+
+	if patch.Stock != nil {
+		p.Stock = *patch.Stock
+	}
+
+	return p
+}
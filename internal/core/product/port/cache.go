@@ -3,10 +3,17 @@ package port
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
 )
 
 type Cache interface {
-	SetListProductCache(ctx context.Context, productName, categoryType, sort, direction string, products domain.Products) (err error)
-	GetListProductCache(ctx context.Context, productName, categoryType, sort, direction string) (res domain.Products, err error)
+	SetListProductCache(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort, page domain.ListPage, productPage domain.ProductPage) (err error)
+	GetListProductCache(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort, page domain.ListPage) (res domain.ProductPage, err error)
+	SetProductFacetsCache(ctx context.Context, filter domain.ProductFilter, priceBounds []float64, facets domain.ProductFacets) (err error)
+	GetProductFacetsCache(ctx context.Context, filter domain.ProductFilter, priceBounds []float64) (res domain.ProductFacets, err error)
+	SetProductCache(ctx context.Context, products domain.Products) (err error)
+	GetProductCache(ctx context.Context, productIDs []uuid.UUID) (res domain.Products, err error)
+	DeleteProductCache(ctx context.Context, productID uuid.UUID) (err error)
+	InvalidateListProductCache(ctx context.Context) (err error)
 }
@@ -9,7 +9,36 @@ import (
 
 type Repository interface {
 	CreateProduct(ctx context.Context, product domain.Product) (res uuid.UUID, err error)
-	GetListProduct(ctx context.Context, productName, categoryType, sort, direction string) (res domain.Products, err error)
+	CreateProducts(ctx context.Context, products domain.Products) (res []uuid.UUID, err error)
+	GetListProduct(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort, page domain.ListPage) (res domain.ProductPage, err error)
+	ExportProduct(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort) (res ProductRows, err error)
+	CountProduct(ctx context.Context, filter domain.ProductFilter) (total int, err error)
+	GetProductFacets(ctx context.Context, filter domain.ProductFilter, priceBounds []float64) (res domain.ProductFacets, err error)
+	SuggestProduct(ctx context.Context, prefix string, filter domain.ProductFilter, limit int) (res domain.ProductSuggestions, err error)
 	GetProductByID(ctx context.Context, productID uuid.UUID) (res domain.Product, err error)
+	GetDeletedProductByID(ctx context.Context, productID uuid.UUID) (res domain.Product, err error)
+	GetListProductByIDs(ctx context.Context, productIDs []uuid.UUID) (res domain.Products, err error)
+	GetListProductByNames(ctx context.Context, products domain.Products) (res domain.Products, err error)
+	GetProductReferences(ctx context.Context, products domain.Products) (res domain.ProductReferences, err error)
+	GetListProductBySupplierID(ctx context.Context, supplierID uuid.UUID) (res domain.Products, err error)
 	GetProductByName(ctx context.Context, categoryID uuid.UUID, productName string) (res domain.Product, err error)
+	UpdateProduct(ctx context.Context, product domain.Product) (err error)
+	DeleteProduct(ctx context.Context, product domain.Product) (err error)
+	RestoreProduct(ctx context.Context, product domain.Product) (err error)
+	PurgeProduct(ctx context.Context, product domain.Product) (err error)
+	CreateProductDiscount(ctx context.Context, discount domain.ProductDiscount) (err error)
+	UpdateProductDiscount(ctx context.Context, discount domain.ProductDiscount) (err error)
+	DeleteProductDiscount(ctx context.Context, productID uuid.UUID) (err error)
+	CreateStockMovement(ctx context.Context, movement domain.StockMovement) (res domain.StockMovement, err error)
+	GetListStockMovement(ctx context.Context, productID uuid.UUID, filter domain.StockMovementFilter, page domain.ListPage) (res domain.StockHistoryPage, err error)
+}
+
+// ProductRows reads products one at a time from the database, like sql.Rows: Next moves
+// to the next product, read by Product, and Err tells why Next returned false, if not
+// because every product was read.
+type ProductRows interface {
+	Next() bool
+	Product() (res domain.Product, err error)
+	Err() error
+	Close() error
 }
@@ -9,6 +9,22 @@ import (
 
 type Service interface {
 	CreateProduct(ctx context.Context, product domain.Product) (res domain.Product, err error)
-	GetListProduct(ctx context.Context, productName, categoryType, sort, direction string) (res domain.Products, err error)
+	CreateProducts(ctx context.Context, items []domain.ProductBulkItem, opts domain.ProductBulkOptions) (res []domain.ProductBulkItem, err error)
+	GetListProduct(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort, page domain.ListPage) (res domain.ProductPage, err error)
+	ExportProduct(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort) (res ProductRows, err error)
+	GetProductFacets(ctx context.Context, filter domain.ProductFilter, priceBounds []float64) (res domain.ProductFacets, err error)
+	SuggestProduct(ctx context.Context, prefix string, filter domain.ProductFilter, limit int) (res domain.ProductSuggestions, err error)
 	GetProductByID(ctx context.Context, productID uuid.UUID) (res domain.Product, err error)
+	GetProductsByIDs(ctx context.Context, productIDs []uuid.UUID) (res domain.ProductBatch, err error)
+	GetListProductBySupplierID(ctx context.Context, supplierID uuid.UUID) (res domain.Products, err error)
+	UpdateProduct(ctx context.Context, product domain.Product, versions domain.ProductVersions) (res domain.Product, err error)
+	PatchProduct(ctx context.Context, productID uuid.UUID, versions domain.ProductVersions, patch domain.ProductPatch) (res domain.Product, err error)
+	DeleteProduct(ctx context.Context, productID uuid.UUID, versions domain.ProductVersions) (err error)
+	RestoreProduct(ctx context.Context, productID uuid.UUID, versions domain.ProductVersions) (res domain.Product, err error)
+	PurgeProduct(ctx context.Context, productID uuid.UUID, versions domain.ProductVersions) (err error)
+	CreateProductDiscount(ctx context.Context, discount domain.ProductDiscount) (res domain.Product, err error)
+	UpdateProductDiscount(ctx context.Context, discount domain.ProductDiscount) (res domain.Product, err error)
+	DeleteProductDiscount(ctx context.Context, productID uuid.UUID) (err error)
+	CreateStockMovement(ctx context.Context, movement domain.StockMovement) (res domain.StockMovement, err error)
+	GetStockHistory(ctx context.Context, productID uuid.UUID, filter domain.StockMovementFilter, page domain.ListPage) (res domain.StockHistoryPage, err error)
 }
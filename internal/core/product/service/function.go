@@ -3,10 +3,14 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/internal/core/product/port"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
 	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/pageutil"
 	"github.com/gunawanpras/be-product-service/pkg/util/timeutil"
 )
 
@@ -22,15 +26,9 @@ import (
 // - res: domain.Product representing the newly created product.
 // - err: error if an error occurs during the creation process.
 func (service *ProductService) CreateProduct(ctx context.Context, product domain.Product) (res domain.Product, err error) {
-	result, err := service.repo.ProductRepo.GetProductByName(ctx, product.CategoryID, product.Name)
+	err = service.checkProductNameAvailable(ctx, uuid.Nil, product.CategoryID, product.Name)
 	if err != nil {
-		if err.Error() != constant.DataNotFound {
-			return res, err
-		}
-	}
-
-	if result.ID != uuid.Nil {
-		return res, errors.New(constant.ProductAlreadyExist)
+		return res, err
 	}
 
 	now := timeutil.TimeHelper.Now()
@@ -44,50 +42,287 @@ func (service *ProductService) CreateProduct(ctx context.Context, product domain
 		Stock:       product.Stock,
 		CreatedAt:   now,
 		CreatedBy:   constant.SYSTEM,
+		Version:     constant.ProductInitialVersion,
 	}
 
 	productID, err := service.repo.ProductRepo.CreateProduct(ctx, newProduct)
 	if err != nil {
+		// the name got taken since it was checked
+		if errors.Is(err, apperror.ErrConflict) {
+			return res, domain.ErrProductAlreadyExist
+		}
+
+		if errors.Is(err, apperror.ErrUnprocessable) {
+			return res, domain.ErrProductReferenceNotFound
+		}
+
 		return res, err
 	}
 
 	newProduct.ID = productID
+	service.evictProductLists(ctx)
 
 	return newProduct, nil
 }
 
-// GetListProduct retrieves a list of products filtered by product name, category type,
-// and sorted by a specified field and direction. It first attempts to fetch the data
-// from the cache. If the data is not found in the cache, it retrieves the data from
-// the database and updates the cache with the retrieved data.
+// CreateProducts creates a bulk of products at once. Like CreateProduct, a product may
+// not share its name with another product of its category: the duplicates within the
+// bulk, after the first product of a name, and the names already taken are found in a
+// single query. The products referring to a category, supplier or unit that does not
+// exist are invalid. The other products are created together, or one at a time when a
+// name got taken or a reference removed meanwhile, so that only the products involved
+// fail. In atomic mode, no product is created as soon as one of them cannot be. A dry run
+// stops before creating anything, reporting the products that would be created as valid.
 //
 // Parameters:
 // - ctx: Context for controlling the lifetime of the request.
-// - productName: The name of the product to filter by (can be a partial match).
-// - categoryType: The category type to filter the products.
-// - sort: The field to sort the products by.
-// - direction: The direction to sort the products (asc or desc).
+// - items: []domain.ProductBulkItem containing the products to create, with Err set on the ones that failed validation.
+// - opts: domain.ProductBulkOptions telling whether to create every product or none, and whether to only check them.
 //
 // Returns:
-// - res: domain.Products representing the list of products that match the criteria.
+// - res: []domain.ProductBulkItem holding the outcome of every product, in the order of items.
+// - err: error if an error occurs during the creation process.
+func (service *ProductService) CreateProducts(ctx context.Context, items []domain.ProductBulkItem, opts domain.ProductBulkOptions) (res []domain.ProductBulkItem, err error) {
+	type productName struct {
+		categoryID uuid.UUID
+		name       string
+	}
+
+	res = slices.Clone(items)
+
+	// duplicates within the bulk, the first product of a name is kept
+	var (
+		pending  []int
+		products domain.Products
+	)
+
+	seen := make(map[productName]bool, len(res))
+	for i, item := range res {
+		if item.Err != nil {
+			res[i].Status = constant.ProductBulkInvalid
+			continue
+		}
+
+		key := productName{item.Product.CategoryID, item.Product.Name}
+		if seen[key] {
+			res[i].Status = constant.ProductBulkDuplicate
+			res[i].Err = domain.ErrProductAlreadyExist
+			continue
+		}
+
+		seen[key] = true
+		pending = append(pending, i)
+		products = append(products, item.Product)
+	}
+
+	// names already taken by existing products
+	if len(products) > 0 {
+		existing, err := service.repo.ProductRepo.GetListProductByNames(ctx, products)
+		if err != nil {
+			return nil, err
+		}
+
+		taken := make(map[productName]bool, len(existing))
+		for _, product := range existing {
+			taken[productName{product.CategoryID, product.Name}] = true
+		}
+
+		pending = slices.DeleteFunc(pending, func(i int) bool {
+			if !taken[productName{res[i].Product.CategoryID, res[i].Product.Name}] {
+				return false
+			}
+
+			res[i].Status = constant.ProductBulkDuplicate
+			res[i].Err = domain.ErrProductAlreadyExist
+
+			return true
+		})
+
+		// categories, suppliers and units that do not exist
+		references, err := service.repo.ProductRepo.GetProductReferences(ctx, products)
+		if err != nil {
+			return nil, err
+		}
+
+		pending = slices.DeleteFunc(pending, func(i int) bool {
+			if references.Resolve(res[i].Product) {
+				return false
+			}
+
+			res[i].Status = constant.ProductBulkInvalid
+			res[i].Err = domain.ErrProductReferenceNotFound
+
+			return true
+		})
+	}
+
+	// all or nothing
+	if opts.Atomic && len(pending) < len(res) {
+		for _, i := range pending {
+			res[i].Status = constant.ProductBulkSkipped
+			res[i].Err = domain.ErrProductBulkAborted
+		}
+
+		return res, nil
+	}
+
+	if len(pending) == 0 {
+		return res, nil
+	}
+
+	if opts.DryRun {
+		for _, i := range pending {
+			res[i].Status = constant.ProductBulkValid
+		}
+
+		return res, nil
+	}
+
+	now := timeutil.TimeHelper.Now()
+	newProducts := make(domain.Products, 0, len(pending))
+	for _, i := range pending {
+		product := res[i].Product
+		newProducts = append(newProducts, domain.Product{
+			CategoryID:  product.CategoryID,
+			SupplierID:  product.SupplierID,
+			UnitID:      product.UnitID,
+			Name:        product.Name,
+			Description: product.Description,
+			BasePrice:   product.BasePrice,
+			Stock:       product.Stock,
+			CreatedAt:   now,
+			CreatedBy:   constant.SYSTEM,
+			Version:     constant.ProductInitialVersion,
+		})
+	}
+
+	productIDs, err := service.repo.ProductRepo.CreateProducts(ctx, newProducts)
+	if err != nil {
+		// a name got taken or a reference removed since they were checked
+		raced := errors.Is(err, apperror.ErrConflict) || errors.Is(err, apperror.ErrUnprocessable)
+		if !raced || opts.Atomic {
+			return nil, bulkCreateError(err)
+		}
+
+		// only the products involved fail, the others are created one at a time
+		return service.createProductsOneByOne(ctx, res, pending, newProducts)
+	}
+
+	for j, i := range pending {
+		newProducts[j].ID = productIDs[j]
+		res[i].Product = newProducts[j]
+		res[i].Status = constant.ProductBulkCreated
+	}
+
+	service.evictProductLists(ctx)
+
+	return res, nil
+}
+
+// createProductsOneByOne creates the products of a bulk one at a time, once creating
+// them together failed because of some of them, marking the ones that cannot be created.
+func (service *ProductService) createProductsOneByOne(ctx context.Context, res []domain.ProductBulkItem, pending []int, newProducts domain.Products) ([]domain.ProductBulkItem, error) {
+	created := false
+	for j, i := range pending {
+		productID, err := service.repo.ProductRepo.CreateProduct(ctx, newProducts[j])
+		switch {
+		case errors.Is(err, apperror.ErrConflict):
+			res[i].Status = constant.ProductBulkDuplicate
+			res[i].Err = domain.ErrProductAlreadyExist
+		case errors.Is(err, apperror.ErrUnprocessable):
+			res[i].Status = constant.ProductBulkInvalid
+			res[i].Err = domain.ErrProductReferenceNotFound
+		case err != nil:
+			if created {
+				service.evictProductLists(ctx)
+			}
+
+			return nil, err
+		default:
+			newProducts[j].ID = productID
+			res[i].Product = newProducts[j]
+			res[i].Status = constant.ProductBulkCreated
+			created = true
+		}
+	}
+
+	if created {
+		service.evictProductLists(ctx)
+	}
+
+	return res, nil
+}
+
+// bulkCreateError tells why a bulk of products could not be created at all.
+func bulkCreateError(err error) error {
+	switch {
+	case errors.Is(err, apperror.ErrConflict):
+		return domain.ErrProductAlreadyExist
+	case errors.Is(err, apperror.ErrUnprocessable):
+		return domain.ErrProductReferenceNotFound
+	default:
+		return err
+	}
+}
+
+// GetListProduct retrieves a page of the products matching a filter, sorted by one or
+// more fields. It first attempts to fetch the page from the cache. If the page is not
+// found in the cache, it retrieves the page from the database and updates the cache with
+// the retrieved data. A cursor is only valid for the sort keys it was issued for, while
+// numbered pages also get the total number of matching products. A fuzzy search only
+// matches names by similarity when the full-text search finds no product at all.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - filter: The criteria the products must match.
+// - sort: The keys to sort the products by, created_at asc when empty.
+// - page: The size of the page, and either its number or the cursor to continue from.
+//
+// Returns:
+// - res: domain.ProductPage holding the products of the page and the cursor of the next one.
 // - err: error if an error occurs during the retrieval process.
-func (service *ProductService) GetListProduct(ctx context.Context, productName, categoryType, sort, direction string) (res domain.Products, err error) {
+func (service *ProductService) GetListProduct(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort, page domain.ListPage) (res domain.ProductPage, err error) {
+	if err = filter.Validate(); err != nil {
+		return res, err
+	}
+
+	if len(sort.Keys) == 0 {
+		sort.Keys = pageutil.ParseSort("", "", "", constant.ProductSortCreatedAt)
+	}
+
+	if page.After != nil && !page.After.Matches(sort) {
+		return res, domain.ErrProductInvalidCursor
+	}
+
 	// get from cache first
-	cache, err := service.cache.ProductCache.GetListProductCache(ctx, productName, categoryType, sort, direction)
-	if err == nil && len(cache) > 0 {
+	cache, err := service.cache.ProductCache.GetListProductCache(ctx, filter, sort, page)
+	if err == nil && len(cache.Products) > 0 {
 		return cache, nil
 	}
 
 	// if not found, get from database
-	res, err = service.repo.ProductRepo.GetListProduct(ctx, productName, categoryType, sort, direction)
+	search, err := service.resolveSearch(ctx, filter)
+	if err != nil {
+		return res, err
+	}
+
+	res, err = service.repo.ProductRepo.GetListProduct(ctx, search, sort, page)
 	if err != nil {
-		if err.Error() != constant.DataNotFound {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return res, err
+		}
+	}
+
+	// numbered pages come with the total number of products
+	if page.Number > 0 {
+		res.Total, err = service.repo.ProductRepo.CountProduct(ctx, search)
+		if err != nil {
 			return res, err
 		}
 	}
 
 	// set list product to cache
-	err = service.cache.ProductCache.SetListProductCache(ctx, productName, categoryType, sort, direction, res)
+	err = service.cache.ProductCache.SetListProductCache(ctx, filter, sort, page, res)
 	if err != nil {
 		return res, err
 	}
@@ -95,6 +330,93 @@ func (service *ProductService) GetListProduct(ctx context.Context, productName,
 	return res, nil
 }
 
+// ExportProduct opens a cursor over every product matching a filter, sorted by one or more
+// fields, for the whole catalog to be exported. Unlike GetListProduct, the products are
+// neither paged nor cached: they are read straight from the database as the cursor moves.
+// The filter is checked and a fuzzy search resolved the same way, so that an export holds
+// the products the list shows.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the cursor.
+// - filter: The criteria the products must match.
+// - sort: The keys to sort the products by.
+//
+// Returns:
+// - res: port.ProductRows reading the matching products, to be closed once read.
+// - err: error if an error occurs when opening the cursor.
+func (service *ProductService) ExportProduct(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort) (res port.ProductRows, err error) {
+	if err = filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	search, err := service.resolveSearch(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.repo.ProductRepo.ExportProduct(ctx, search, sort)
+}
+
+// GetProductFacets counts the products matching a filter by category, supplier, unit and
+// price bucket. Like GetListProduct, it first attempts to fetch the facets from the cache,
+// and a fuzzy search only matches names by similarity when the full-text search finds no
+// product, so that the facets always describe the listed products.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - filter: The criteria the products must match.
+// - priceBounds: The lower bounds of the price buckets, in ascending order.
+//
+// Returns:
+// - res: domain.ProductFacets holding the counts of every facet.
+// - err: error if an error occurs during the counting process.
+func (service *ProductService) GetProductFacets(ctx context.Context, filter domain.ProductFilter, priceBounds []float64) (res domain.ProductFacets, err error) {
+	if err = filter.Validate(); err != nil {
+		return res, err
+	}
+
+	// get from cache first
+	cache, err := service.cache.ProductCache.GetProductFacetsCache(ctx, filter, priceBounds)
+	if err == nil && len(cache.PriceBuckets) > 0 {
+		return cache, nil
+	}
+
+	// if not found, get from database
+	search, err := service.resolveSearch(ctx, filter)
+	if err != nil {
+		return res, err
+	}
+
+	res, err = service.repo.ProductRepo.GetProductFacets(ctx, search, priceBounds)
+	if err != nil {
+		return res, err
+	}
+
+	// set product facets to cache
+	err = service.cache.ProductCache.SetProductFacetsCache(ctx, filter, priceBounds, res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// SuggestProduct retrieves the names of the products starting with or similar to a prefix,
+// to complete what a user is typing despite typos.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - prefix: The beginning of the product name typed so far.
+// - filter: The criteria the products must match, such as their categories.
+// - limit: The maximum number of suggestions.
+//
+// Returns:
+// - res: domain.ProductSuggestions holding the ID and name of the suggested products.
+// - err: error if an error occurs during the retrieval process.
+func (service *ProductService) SuggestProduct(ctx context.Context, prefix string, filter domain.ProductFilter, limit int) (res domain.ProductSuggestions, err error) {
+	return service.repo.ProductRepo.SuggestProduct(ctx, prefix, filter, limit)
+}
+
 // GetProductByID retrieves a product by ID from the database.
 //
 // Parameters:
@@ -107,12 +429,601 @@ func (service *ProductService) GetListProduct(ctx context.Context, productName,
 func (service *ProductService) GetProductByID(ctx context.Context, productID uuid.UUID) (res domain.Product, err error) {
 	res, err = service.repo.ProductRepo.GetProductByID(ctx, productID)
 	if err != nil {
-		if err.Error() == constant.DataNotFound {
-			return res, errors.New(constant.ProductNotFound)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrProductNotFound
+		}
+
+		return res, err
+	}
+
+	return res, nil
+}
+
+// GetProductsByIDs retrieves a batch of products by their IDs. The products are first
+// read from the cache, each one on its own, and the others from the database in a single
+// query, after which they are cached. Duplicate IDs are only read once.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - productIDs: The IDs of the products to retrieve.
+//
+// Returns:
+// - res: domain.ProductBatch holding the products found, in the order of productIDs, and the IDs of the missing ones.
+// - err: error if an error occurs during the retrieval process.
+func (service *ProductService) GetProductsByIDs(ctx context.Context, productIDs []uuid.UUID) (res domain.ProductBatch, err error) {
+	var uniqueIDs []uuid.UUID
+	for _, productID := range productIDs {
+		if !slices.Contains(uniqueIDs, productID) {
+			uniqueIDs = append(uniqueIDs, productID)
+		}
+	}
+
+	found := make(map[uuid.UUID]domain.Product, len(uniqueIDs))
+
+	// get from cache first
+	cache, err := service.cache.ProductCache.GetProductCache(ctx, uniqueIDs)
+	if err == nil {
+		for _, product := range cache {
+			found[product.ID] = product
+		}
+	}
+
+	// get the others from database
+	var uncachedIDs []uuid.UUID
+	for _, productID := range uniqueIDs {
+		if _, ok := found[productID]; !ok {
+			uncachedIDs = append(uncachedIDs, productID)
+		}
+	}
+
+	if len(uncachedIDs) > 0 {
+		products, err := service.repo.ProductRepo.GetListProductByIDs(ctx, uncachedIDs)
+		if err != nil {
+			return res, err
+		}
+
+		for _, product := range products {
+			found[product.ID] = product
+		}
+
+		// set the products to cache
+		err = service.cache.ProductCache.SetProductCache(ctx, products)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	for _, productID := range uniqueIDs {
+		if product, ok := found[productID]; ok {
+			res.Products = append(res.Products, product)
+		} else {
+			res.Missing = append(res.Missing, productID)
+		}
+	}
+
+	return res, nil
+}
+
+// GetListProductBySupplierID retrieves the products of a supplier from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplierID: The ID of the supplier.
+//
+// Returns:
+// - res: domain.Products representing the products of the supplier.
+// - err: error if an error occurs during the retrieval process.
+func (service *ProductService) GetListProductBySupplierID(ctx context.Context, supplierID uuid.UUID) (res domain.Products, err error) {
+	return service.repo.ProductRepo.GetListProductBySupplierID(ctx, supplierID)
+}
+
+// UpdateProduct replaces every mutable field of an existing product. The same-category
+// name uniqueness rule of CreateProduct is enforced before the product is saved. When
+// versions are given, the update is refused unless one of them matches the stored version.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - product: domain.Product containing the ID and new details of the product.
+// - versions: The expected versions of the product, or none to skip the check.
+//
+// Returns:
+// - res: domain.Product representing the updated product.
+// - err: error if an error occurs during the update process.
+func (service *ProductService) UpdateProduct(ctx context.Context, product domain.Product, versions domain.ProductVersions) (res domain.Product, err error) {
+	existing, err := service.GetProductByID(ctx, product.ID)
+	if err != nil {
+		return res, err
+	}
+
+	return service.saveProduct(ctx, existing, product, versions)
+}
+
+// PatchProduct applies a JSON merge-patch to an existing product. Fields absent from
+// the patch keep their stored values.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - productID: The ID of the product to patch.
+// - versions: The expected versions of the product, or none to skip the check.
+// - patch: domain.ProductPatch containing the fields to change.
+//
+// Returns:
+// - res: domain.Product representing the patched product.
+// - err: error if an error occurs during the update process.
+func (service *ProductService) PatchProduct(ctx context.Context, productID uuid.UUID, versions domain.ProductVersions, patch domain.ProductPatch) (res domain.Product, err error) {
+	existing, err := service.GetProductByID(ctx, productID)
+	if err != nil {
+		return res, err
+	}
+
+	return service.saveProduct(ctx, existing, existing.ApplyPatch(patch), versions)
+}
+
+// DeleteProduct soft deletes a product. The product is kept in the database so that
+// other services can still resolve it, but it is hidden from every read by default.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - productID: The ID of the product to delete.
+// - versions: The expected versions of the product, or none to skip the check.
+//
+// Returns:
+// - err: error if an error occurs during the deletion process.
+func (service *ProductService) DeleteProduct(ctx context.Context, productID uuid.UUID, versions domain.ProductVersions) (err error) {
+	existing, err := service.GetProductByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	if err = checkProductVersion(existing, versions); err != nil {
+		return err
+	}
+
+	now := timeutil.TimeHelper.Now()
+	deletedBy := constant.SYSTEM
+
+	err = service.repo.ProductRepo.DeleteProduct(ctx, domain.Product{
+		ID:        productID,
+		DeletedAt: &now,
+		DeletedBy: &deletedBy,
+		Version:   existing.Version,
+	})
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return domain.ErrProductPreconditionFailed
+		}
+
+		return err
+	}
+
+	service.evictProduct(ctx, productID)
+
+	return nil
+}
+
+// RestoreProduct brings a soft-deleted product back and returns its current state. It is
+// refused while another product of its category holds its name.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - productID: The ID of the product to restore.
+// - versions: The expected versions of the product, or none to skip the check.
+//
+// Returns:
+// - res: domain.Product representing the restored product.
+// - err: error if an error occurs during the restore process.
+func (service *ProductService) RestoreProduct(ctx context.Context, productID uuid.UUID, versions domain.ProductVersions) (res domain.Product, err error) {
+	deleted, err := service.repo.ProductRepo.GetDeletedProductByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrProductNotFound
 		}
 
 		return res, err
 	}
 
+	if err = checkProductVersion(deleted, versions); err != nil {
+		return res, err
+	}
+
+	// another product may have taken the name while this one was deleted
+	err = service.checkProductNameAvailable(ctx, deleted.ID, deleted.CategoryID, deleted.Name)
+	if err != nil {
+		return res, err
+	}
+
+	now := timeutil.TimeHelper.Now()
+	updatedBy := constant.SYSTEM
+
+	err = service.repo.ProductRepo.RestoreProduct(ctx, domain.Product{
+		ID:        productID,
+		UpdatedAt: &now,
+		UpdatedBy: &updatedBy,
+		Version:   deleted.Version,
+	})
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrProductPreconditionFailed
+		}
+
+		if errors.Is(err, apperror.ErrConflict) {
+			return res, domain.ErrProductAlreadyExist
+		}
+
+		return res, err
+	}
+
+	// the restored product is back in the lists and facets
+	service.evictProduct(ctx, productID)
+
+	return service.GetProductByID(ctx, productID)
+}
+
+// PurgeProduct permanently removes a product and its discount, whether soft-deleted or
+// not. It is meant for administrators only, regular removals should go through
+// DeleteProduct.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - productID: The ID of the product to remove.
+// - versions: The expected versions of the product, or none to skip the check.
+//
+// Returns:
+// - err: error if an error occurs during the removal process.
+func (service *ProductService) PurgeProduct(ctx context.Context, productID uuid.UUID, versions domain.ProductVersions) (err error) {
+	existing, err := service.repo.ProductRepo.GetProductByID(ctx, productID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		existing, err = service.repo.ProductRepo.GetDeletedProductByID(ctx, productID)
+	}
+
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return domain.ErrProductNotFound
+		}
+
+		return err
+	}
+
+	if err = checkProductVersion(existing, versions); err != nil {
+		return err
+	}
+
+	err = service.repo.ProductRepo.PurgeProduct(ctx, domain.Product{
+		ID:      productID,
+		Version: existing.Version,
+	})
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return domain.ErrProductPreconditionFailed
+		}
+
+		return err
+	}
+
+	service.evictProduct(ctx, productID)
+
+	return nil
+}
+
+// CreateProductDiscount attaches a discount window to an existing product, which must
+// not have one yet.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - discount: domain.ProductDiscount containing the product ID and the discount details.
+//
+// Returns:
+// - res: domain.Product representing the product with its new discount.
+// - err: error if an error occurs during the creation process.
+func (service *ProductService) CreateProductDiscount(ctx context.Context, discount domain.ProductDiscount) (res domain.Product, err error) {
+	if discount.EndDate.Before(discount.StartDate) {
+		return res, domain.ErrProductDiscountInvalidPeriod
+	}
+
+	existing, err := service.GetProductByID(ctx, discount.ProductID)
+	if err != nil {
+		return res, err
+	}
+
+	if existing.Discount != nil {
+		return res, domain.ErrProductDiscountAlreadyExist
+	}
+
+	newDiscount := domain.ProductDiscount{
+		ProductID:       existing.ID,
+		DiscountPercent: discount.DiscountPercent,
+		StartDate:       discount.StartDate,
+		EndDate:         discount.EndDate,
+		MaxPurchaseQty:  discount.MaxPurchaseQty,
+		CreatedAt:       timeutil.TimeHelper.Now(),
+		CreatedBy:       constant.SYSTEM,
+	}
+
+	err = service.repo.ProductRepo.CreateProductDiscount(ctx, newDiscount)
+	if err != nil {
+		// another request created the discount in the meantime
+		if errors.Is(err, apperror.ErrConflict) {
+			return res, domain.ErrProductDiscountAlreadyExist
+		}
+
+		return res, err
+	}
+
+	service.evictProduct(ctx, existing.ID)
+
+	existing.Discount = &newDiscount
+
+	return existing, nil
+}
+
+// UpdateProductDiscount replaces the discount window of an existing product.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - discount: domain.ProductDiscount containing the product ID and the new discount details.
+//
+// Returns:
+// - res: domain.Product representing the product with its updated discount.
+// - err: error if an error occurs during the update process.
+func (service *ProductService) UpdateProductDiscount(ctx context.Context, discount domain.ProductDiscount) (res domain.Product, err error) {
+	if discount.EndDate.Before(discount.StartDate) {
+		return res, domain.ErrProductDiscountInvalidPeriod
+	}
+
+	existing, err := service.GetProductByID(ctx, discount.ProductID)
+	if err != nil {
+		return res, err
+	}
+
+	if existing.Discount == nil {
+		return res, domain.ErrProductDiscountNotFound
+	}
+
+	now := timeutil.TimeHelper.Now()
+	updatedBy := constant.SYSTEM
+	updatedDiscount := domain.ProductDiscount{
+		ProductID:       existing.ID,
+		DiscountPercent: discount.DiscountPercent,
+		StartDate:       discount.StartDate,
+		EndDate:         discount.EndDate,
+		MaxPurchaseQty:  discount.MaxPurchaseQty,
+		UpdatedAt:       &now,
+		UpdatedBy:       &updatedBy,
+	}
+
+	err = service.repo.ProductRepo.UpdateProductDiscount(ctx, updatedDiscount)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrProductDiscountNotFound
+		}
+
+		return res, err
+	}
+
+	service.evictProduct(ctx, existing.ID)
+
+	existing.Discount = &updatedDiscount
+
+	return existing, nil
+}
+
+// DeleteProductDiscount removes the discount window of an existing product.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - productID: The ID of the product.
+//
+// Returns:
+// - err: error if an error occurs during the removal process.
+func (service *ProductService) DeleteProductDiscount(ctx context.Context, productID uuid.UUID) (err error) {
+	if _, err = service.GetProductByID(ctx, productID); err != nil {
+		return err
+	}
+
+	err = service.repo.ProductRepo.DeleteProductDiscount(ctx, productID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return domain.ErrProductDiscountNotFound
+		}
+
+		return err
+	}
+
+	service.evictProduct(ctx, productID)
+
+	return nil
+}
+
+// CreateStockMovement records a movement in the stock ledger of an active product and
+// applies it to the stock of the product. The stock may not go below zero.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - movement: domain.StockMovement containing the product ID, the type and quantity of
+// the movement as given by the client, and its reason, reference and actor.
+//
+// Returns:
+// - res: domain.StockMovement representing the recorded movement, with the stock after it.
+// - err: error if an error occurs during the recording process.
+func (service *ProductService) CreateStockMovement(ctx context.Context, movement domain.StockMovement) (res domain.StockMovement, err error) {
+	movement, err = movement.SignQuantity()
+	if err != nil {
+		return res, err
+	}
+
+	newMovement := domain.StockMovement{
+		ProductID:   movement.ProductID,
+		Type:        movement.Type,
+		Quantity:    movement.Quantity,
+		Reason:      movement.Reason,
+		ReferenceID: movement.ReferenceID,
+		Actor:       movement.Actor,
+		CreatedAt:   timeutil.TimeHelper.Now(),
+	}
+
+	if newMovement.Actor == "" {
+		newMovement.Actor = constant.SYSTEM
+	}
+
+	res, err = service.repo.ProductRepo.CreateStockMovement(ctx, newMovement)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrProductNotFound
+		}
+
+		if errors.Is(err, apperror.ErrUnprocessable) {
+			return res, domain.ErrProductInsufficientStock
+		}
+
+		if errors.Is(err, apperror.ErrConflict) {
+			return res, domain.ErrStockMovementAlreadyExist
+		}
+
+		return res, err
+	}
+
+	service.evictProduct(ctx, movement.ProductID)
+
 	return res, nil
 }
+
+// GetStockHistory retrieves a page of the stock ledger of an active product, newest
+// movement first.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - productID: The ID of the product.
+// - filter: domain.StockMovementFilter selecting the type of the movements.
+// - page: domain.ListPage selecting the numbered page of movements.
+//
+// Returns:
+// - res: domain.StockHistoryPage representing the page of movements and their total.
+// - err: error if an error occurs during the retrieval process.
+func (service *ProductService) GetStockHistory(ctx context.Context, productID uuid.UUID, filter domain.StockMovementFilter, page domain.ListPage) (res domain.StockHistoryPage, err error) {
+	if _, err = service.GetProductByID(ctx, productID); err != nil {
+		return res, err
+	}
+
+	return service.repo.ProductRepo.GetListStockMovement(ctx, productID, filter, page)
+}
+
+// saveProduct persists the new state of an existing product, keeping its creation
+// audit fields and stamping the update audit fields. The stored row is only
+// overwritten if it still holds the version that was read as existing.
+func (service *ProductService) saveProduct(ctx context.Context, existing, product domain.Product, versions domain.ProductVersions) (res domain.Product, err error) {
+	if err = checkProductVersion(existing, versions); err != nil {
+		return res, err
+	}
+
+	err = service.checkProductNameAvailable(ctx, existing.ID, product.CategoryID, product.Name)
+	if err != nil {
+		return res, err
+	}
+
+	now := timeutil.TimeHelper.Now()
+	updatedBy := constant.SYSTEM
+	updatedProduct := domain.Product{
+		ID:          existing.ID,
+		CategoryID:  product.CategoryID,
+		SupplierID:  product.SupplierID,
+		UnitID:      product.UnitID,
+		Name:        product.Name,
+		Description: product.Description,
+		BasePrice:   product.BasePrice,
+		Stock:       product.Stock,
+		CreatedAt:   existing.CreatedAt,
+		CreatedBy:   existing.CreatedBy,
+		UpdatedAt:   &now,
+		UpdatedBy:   &updatedBy,
+		Version:     existing.Version,
+		Discount:    existing.Discount,
+	}
+
+	err = service.repo.ProductRepo.UpdateProduct(ctx, updatedProduct)
+	if err != nil {
+		// the product changed or got deleted since it was read
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrProductPreconditionFailed
+		}
+
+		if errors.Is(err, apperror.ErrConflict) {
+			return res, domain.ErrProductAlreadyExist
+		}
+
+		if errors.Is(err, apperror.ErrUnprocessable) {
+			return res, domain.ErrProductReferenceNotFound
+		}
+
+		return res, err
+	}
+
+	service.evictProduct(ctx, existing.ID)
+
+	updatedProduct.Version++
+
+	return updatedProduct, nil
+}
+
+// evictProduct drops the cached copy of a product that changed, along with the cached
+// pages of the product list and facets, so that reads do not serve it stale. A failed
+// eviction is not reported, the copy expires with its TTL.
+func (service *ProductService) evictProduct(ctx context.Context, productID uuid.UUID) {
+	_ = service.cache.ProductCache.DeleteProductCache(ctx, productID)
+	service.evictProductLists(ctx)
+}
+
+// evictProductLists drops the cached pages of the product list and facets, for writes
+// adding products to them.
+func (service *ProductService) evictProductLists(ctx context.Context) {
+	_ = service.cache.ProductCache.InvalidateListProductCache(ctx)
+}
+
+// checkProductVersion compares the versions expected by the client with the stored one.
+// No expected version means the client did not ask for the check.
+func checkProductVersion(existing domain.Product, versions domain.ProductVersions) error {
+	if !versions.Match(existing.Version) {
+		return domain.ErrProductPreconditionFailed
+	}
+
+	return nil
+}
+
+// resolveSearch decides how the query of a fuzzy search matches the products: by full
+// text when that finds any product, by name similarity otherwise. The decision is made on
+// the whole list rather than on a page, so that every page is read the same way.
+func (service *ProductService) resolveSearch(ctx context.Context, filter domain.ProductFilter) (res domain.ProductFilter, err error) {
+	if !filter.Fuzzy || filter.Query == "" {
+		return filter, nil
+	}
+
+	exact := filter
+	exact.Fuzzy = false
+
+	total, err := service.repo.ProductRepo.CountProduct(ctx, exact)
+	if err != nil {
+		return res, err
+	}
+
+	if total > 0 {
+		return exact, nil
+	}
+
+	return filter, nil
+}
+
+// checkProductNameAvailable makes sure no other product in the category already uses
+// the given name. productID is the product being saved, or uuid.Nil for a new one.
+func (service *ProductService) checkProductNameAvailable(ctx context.Context, productID, categoryID uuid.UUID, productName string) error {
+	result, err := service.repo.ProductRepo.GetProductByName(ctx, categoryID, productName)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return err
+		}
+	}
+
+	if result.ID != uuid.Nil && result.ID != productID {
+		return domain.ErrProductAlreadyExist
+	}
+
+	return nil
+}
@@ -1,9 +1,270 @@
 package service_test
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"testing"
 
-func TestProductService_CreateProduct(t *testing.T) {
-	type fields struct {
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/product/domain"
+	"github.com/gunawanpras/be-product-service/internal/core/product/port"
+	"github.com/gunawanpras/be-product-service/internal/core/product/service"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubRepository creates the products whose name is not taken and whose category exists,
+// failing the multi-row insert the way postgres does when one of them cannot be created.
+type stubRepository struct {
+	port.Repository
+	// taken holds the names of the existing products.
+	taken map[string]bool
+	// raced holds the names taken after they were checked.
+	raced map[string]bool
+	// removed holds the categories removed after they were checked.
+	removed map[uuid.UUID]bool
+	err     error
+	created []string
+}
+
+func (r *stubRepository) GetListProductByNames(ctx context.Context, products domain.Products) (res domain.Products, err error) {
+	for _, product := range products {
+		if r.taken[product.Name] {
+			res = append(res, product)
+		}
+	}
+
+	return res, nil
+}
+
+func (r *stubRepository) GetProductReferences(ctx context.Context, products domain.Products) (res domain.ProductReferences, err error) {
+	res = domain.ProductReferences{
+		CategoryIDs: map[uuid.UUID]bool{sayurID: true, buahID: true},
+		SupplierIDs: map[uuid.UUID]bool{taniID: true},
+		UnitIDs:     map[uuid.UUID]bool{ikatID: true},
+	}
+
+	return res, nil
+}
+
+func (r *stubRepository) CreateProducts(ctx context.Context, products domain.Products) (res []uuid.UUID, err error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	for _, product := range products {
+		if err := r.check(product); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, product := range products {
+		r.created = append(r.created, product.Name)
+		res = append(res, uuid.New())
+	}
+
+	return res, nil
+}
+
+func (r *stubRepository) CreateProduct(ctx context.Context, product domain.Product) (res uuid.UUID, err error) {
+	if err := r.check(product); err != nil {
+		return uuid.Nil, err
+	}
+
+	r.created = append(r.created, product.Name)
+
+	return uuid.New(), nil
+}
+
+func (r *stubRepository) check(product domain.Product) error {
+	if r.raced[product.Name] {
+		return dbutil.ErrDataAlreadyExist
 	}
 
+	if r.removed[product.CategoryID] {
+		return dbutil.ErrDataReferenceNotFound
+	}
+
+	return nil
+}
+
+type stubCache struct {
+	port.Cache
+	invalidated int
+}
+
+func (c *stubCache) InvalidateListProductCache(ctx context.Context) (err error) {
+	c.invalidated++
+	return nil
+}
+
+var (
+	sayurID = uuid.New()
+	buahID  = uuid.New()
+	taniID  = uuid.New()
+	ikatID  = uuid.New()
+)
+
+func newBulkItem(name string, categoryID uuid.UUID) domain.ProductBulkItem {
+	return domain.ProductBulkItem{
+		Product: domain.Product{
+			CategoryID: categoryID,
+			SupplierID: taniID,
+			UnitID:     ikatID,
+			Name:       name,
+			BasePrice:  5000,
+			Stock:      10,
+		},
+	}
+}
+
+func TestProductService_CreateProducts(t *testing.T) {
+	items := []domain.ProductBulkItem{
+		newBulkItem("Bayam", sayurID),
+		newBulkItem("Sawi", sayurID),
+		newBulkItem("Apel", buahID),
+	}
+
+	invalid := newBulkItem("Kangkung", sayurID)
+	invalid.Err = domain.ErrProductBulkItemInvalid
+
+	tests := []struct {
+		name            string
+		repo            *stubRepository
+		items           []domain.ProductBulkItem
+		opts            domain.ProductBulkOptions
+		wantStatuses    []string
+		wantErrs        []error
+		wantCreated     []string
+		wantInvalidated int
+		wantErr         error
+	}{
+		{
+			name:            "creates every product",
+			repo:            &stubRepository{},
+			items:           items,
+			wantStatuses:    []string{constant.ProductBulkCreated, constant.ProductBulkCreated, constant.ProductBulkCreated},
+			wantErrs:        []error{nil, nil, nil},
+			wantCreated:     []string{"Bayam", "Sawi", "Apel"},
+			wantInvalidated: 1,
+		},
+		{
+			name:            "duplicates within the bulk and names already taken are not created",
+			repo:            &stubRepository{taken: map[string]bool{"Apel": true}},
+			items:           append([]domain.ProductBulkItem{newBulkItem("Sawi", sayurID)}, items...),
+			wantStatuses:    []string{constant.ProductBulkCreated, constant.ProductBulkCreated, constant.ProductBulkDuplicate, constant.ProductBulkDuplicate},
+			wantErrs:        []error{nil, nil, domain.ErrProductAlreadyExist, domain.ErrProductAlreadyExist},
+			wantCreated:     []string{"Sawi", "Bayam"},
+			wantInvalidated: 1,
+		},
+		{
+			name:            "the same name in another category is not a duplicate",
+			repo:            &stubRepository{},
+			items:           []domain.ProductBulkItem{newBulkItem("Bayam", sayurID), newBulkItem("Bayam", buahID)},
+			wantStatuses:    []string{constant.ProductBulkCreated, constant.ProductBulkCreated},
+			wantErrs:        []error{nil, nil},
+			wantCreated:     []string{"Bayam", "Bayam"},
+			wantInvalidated: 1,
+		},
+		{
+			name:            "products failing validation or referring to a missing category are invalid",
+			repo:            &stubRepository{},
+			items:           append([]domain.ProductBulkItem{invalid, newBulkItem("Jeruk", uuid.New())}, items...),
+			wantStatuses:    []string{constant.ProductBulkInvalid, constant.ProductBulkInvalid, constant.ProductBulkCreated, constant.ProductBulkCreated, constant.ProductBulkCreated},
+			wantErrs:        []error{domain.ErrProductBulkItemInvalid, domain.ErrProductReferenceNotFound, nil, nil, nil},
+			wantCreated:     []string{"Bayam", "Sawi", "Apel"},
+			wantInvalidated: 1,
+		},
+		{
+			name:         "atomic bulk skips every product once one cannot be created",
+			repo:         &stubRepository{taken: map[string]bool{"Sawi": true}},
+			items:        items,
+			opts:         domain.ProductBulkOptions{Atomic: true},
+			wantStatuses: []string{constant.ProductBulkSkipped, constant.ProductBulkDuplicate, constant.ProductBulkSkipped},
+			wantErrs:     []error{domain.ErrProductBulkAborted, domain.ErrProductAlreadyExist, domain.ErrProductBulkAborted},
+		},
+		{
+			name:         "dry run creates nothing",
+			repo:         &stubRepository{taken: map[string]bool{"Sawi": true}},
+			items:        items,
+			opts:         domain.ProductBulkOptions{DryRun: true},
+			wantStatuses: []string{constant.ProductBulkValid, constant.ProductBulkDuplicate, constant.ProductBulkValid},
+			wantErrs:     []error{nil, domain.ErrProductAlreadyExist, nil},
+		},
+		{
+			name:            "a name taken meanwhile only fails its product",
+			repo:            &stubRepository{raced: map[string]bool{"Sawi": true}},
+			items:           items,
+			wantStatuses:    []string{constant.ProductBulkCreated, constant.ProductBulkDuplicate, constant.ProductBulkCreated},
+			wantErrs:        []error{nil, domain.ErrProductAlreadyExist, nil},
+			wantCreated:     []string{"Bayam", "Apel"},
+			wantInvalidated: 1,
+		},
+		{
+			name:            "a category removed meanwhile only fails its products",
+			repo:            &stubRepository{removed: map[uuid.UUID]bool{sayurID: true}},
+			items:           items,
+			wantStatuses:    []string{constant.ProductBulkInvalid, constant.ProductBulkInvalid, constant.ProductBulkCreated},
+			wantErrs:        []error{domain.ErrProductReferenceNotFound, domain.ErrProductReferenceNotFound, nil},
+			wantCreated:     []string{"Apel"},
+			wantInvalidated: 1,
+		},
+		{
+			name:    "a name taken meanwhile fails the atomic bulk",
+			repo:    &stubRepository{raced: map[string]bool{"Sawi": true}},
+			items:   items,
+			opts:    domain.ProductBulkOptions{Atomic: true},
+			wantErr: domain.ErrProductAlreadyExist,
+		},
+		{
+			name:    "database error",
+			repo:    &stubRepository{err: errors.New("connection reset")},
+			items:   items,
+			wantErr: errors.New("connection reset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := &stubCache{}
+			productService := service.New(service.InitAttribute{
+				Cache: service.CacheAttribute{ProductCache: cache},
+				Repo:  service.RepoAttribute{ProductRepo: tt.repo},
+			})
+
+			res, err := productService.CreateProducts(context.Background(), tt.items, tt.opts)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+				assert.Empty(t, tt.repo.created)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			var (
+				statuses []string
+				errs     []error
+			)
+			for i, item := range res {
+				statuses = append(statuses, item.Status)
+				errs = append(errs, item.Err)
+
+				if item.Status == constant.ProductBulkCreated {
+					assert.NotEqual(t, uuid.Nil, item.Product.ID)
+					assert.Equal(t, tt.items[i].Product.Name, item.Product.Name)
+					assert.Equal(t, constant.ProductInitialVersion, item.Product.Version)
+				}
+			}
+
+			assert.Equal(t, tt.wantStatuses, statuses)
+			assert.Equal(t, tt.wantErrs, errs)
+			assert.Equal(t, tt.wantCreated, tt.repo.created)
+			assert.Equal(t, tt.wantInvalidated, cache.invalidated)
+
+			for _, item := range tt.items {
+				assert.Empty(t, item.Status, "items are left untouched")
+			}
+		})
+	}
 }
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+var (
+	ErrSupplierNotFound     = apperror.NotFound(constant.CodeSupplierNotFound, constant.SupplierNotFound)
+	ErrSupplierAlreadyExist = apperror.Conflict(constant.CodeSupplierAlreadyExist, constant.SupplierAlreadyExist)
+	ErrSupplierInUse        = apperror.Conflict(constant.CodeSupplierInUse, constant.SupplierInUse)
+)
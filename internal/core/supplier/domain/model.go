@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Supplier struct {
+	ID          uuid.UUID
+	Name        string
+	ContactInfo *ContactInfo
+	CreatedAt   time.Time
+	CreatedBy   string
+	UpdatedAt   *time.Time
+	UpdatedBy   *string
+}
+
+type Suppliers []Supplier
+
+type ContactInfo struct {
+	Email   string
+	Phone   string
+	Address string
+}
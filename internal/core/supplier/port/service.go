@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/supplier/domain"
+)
+
+type Service interface {
+	CreateSupplier(ctx context.Context, supplier domain.Supplier) (res domain.Supplier, err error)
+	GetListSupplier(ctx context.Context, supplierName string) (res domain.Suppliers, err error)
+	GetSupplierByID(ctx context.Context, supplierID uuid.UUID) (res domain.Supplier, err error)
+	GetSupplierByName(ctx context.Context, supplierName string) (res domain.Supplier, err error)
+	UpdateSupplier(ctx context.Context, supplier domain.Supplier) (res domain.Supplier, err error)
+	DeleteSupplier(ctx context.Context, supplierID uuid.UUID) (err error)
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/supplier/domain"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/timeutil"
+)
+
+// CreateSupplier creates a new supplier in the system. It first checks if a supplier with
+// the same name already exists. If not, it proceeds to create the supplier and assigns a
+// new ID to it.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplier: domain.Supplier containing the details of the supplier to be created.
+//
+// Returns:
+// - res: domain.Supplier representing the newly created supplier.
+// - err: error if an error occurs during the creation process.
+func (service *SupplierService) CreateSupplier(ctx context.Context, supplier domain.Supplier) (res domain.Supplier, err error) {
+	err = service.checkSupplierNameAvailable(ctx, uuid.Nil, supplier.Name)
+	if err != nil {
+		return res, err
+	}
+
+	now := timeutil.TimeHelper.Now()
+	newSupplier := domain.Supplier{
+		Name:        supplier.Name,
+		ContactInfo: supplier.ContactInfo,
+		CreatedAt:   now,
+		CreatedBy:   constant.SYSTEM,
+	}
+
+	supplierID, err := service.repo.SupplierRepo.CreateSupplier(ctx, newSupplier)
+	if err != nil {
+		return res, err
+	}
+
+	newSupplier.ID = supplierID
+
+	return newSupplier, nil
+}
+
+// GetListSupplier retrieves a list of suppliers, optionally filtered by name.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplierName: The name of the supplier to filter by (can be a partial match).
+//
+// Returns:
+// - res: domain.Suppliers representing the list of suppliers that match the criteria.
+// - err: error if an error occurs during the retrieval process.
+func (service *SupplierService) GetListSupplier(ctx context.Context, supplierName string) (res domain.Suppliers, err error) {
+	res, err = service.repo.SupplierRepo.GetListSupplier(ctx, supplierName)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// GetSupplierByID retrieves a supplier by ID from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplierID: The ID of the supplier to retrieve.
+//
+// Returns:
+// - res: domain.Supplier representing the supplier with the provided ID.
+// - err: error if an error occurs during the retrieval process.
+func (service *SupplierService) GetSupplierByID(ctx context.Context, supplierID uuid.UUID) (res domain.Supplier, err error) {
+	res, err = service.repo.SupplierRepo.GetSupplierByID(ctx, supplierID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrSupplierNotFound
+		}
+
+		return res, err
+	}
+
+	return res, nil
+}
+
+// GetSupplierByName retrieves a supplier by its exact name from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplierName: The name of the supplier to retrieve.
+//
+// Returns:
+// - res: domain.Supplier representing the supplier with the provided name.
+// - err: error if an error occurs during the retrieval process.
+func (service *SupplierService) GetSupplierByName(ctx context.Context, supplierName string) (res domain.Supplier, err error) {
+	res, err = service.repo.SupplierRepo.GetSupplierByName(ctx, supplierName)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrSupplierNotFound
+		}
+
+		return res, err
+	}
+
+	return res, nil
+}
+
+// UpdateSupplier replaces the name and contact details of an existing supplier. The new name
+// must not be used by another supplier.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplier: domain.Supplier containing the ID of the supplier and its new details.
+//
+// Returns:
+// - res: domain.Supplier representing the updated supplier.
+// - err: error if an error occurs during the update process.
+func (service *SupplierService) UpdateSupplier(ctx context.Context, supplier domain.Supplier) (res domain.Supplier, err error) {
+	existing, err := service.GetSupplierByID(ctx, supplier.ID)
+	if err != nil {
+		return res, err
+	}
+
+	err = service.checkSupplierNameAvailable(ctx, existing.ID, supplier.Name)
+	if err != nil {
+		return res, err
+	}
+
+	now := timeutil.TimeHelper.Now()
+	updatedBy := constant.SYSTEM
+	updatedSupplier := domain.Supplier{
+		ID:          existing.ID,
+		Name:        supplier.Name,
+		ContactInfo: supplier.ContactInfo,
+		CreatedAt:   existing.CreatedAt,
+		CreatedBy:   existing.CreatedBy,
+		UpdatedAt:   &now,
+		UpdatedBy:   &updatedBy,
+	}
+
+	err = service.repo.SupplierRepo.UpdateSupplier(ctx, updatedSupplier)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrSupplierNotFound
+		}
+
+		return res, err
+	}
+
+	return updatedSupplier, nil
+}
+
+// DeleteSupplier removes a supplier. The deletion is refused while any product, including
+// soft-deleted ones, still references the supplier.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - supplierID: The ID of the supplier to delete.
+//
+// Returns:
+// - err: error if an error occurs during the deletion process.
+func (service *SupplierService) DeleteSupplier(ctx context.Context, supplierID uuid.UUID) (err error) {
+	total, err := service.repo.SupplierRepo.CountProductBySupplierID(ctx, supplierID)
+	if err != nil {
+		return err
+	}
+
+	if total > 0 {
+		return domain.ErrSupplierInUse
+	}
+
+	err = service.repo.SupplierRepo.DeleteSupplier(ctx, supplierID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return domain.ErrSupplierNotFound
+		}
+
+		// a product got added to the supplier in the meantime
+		if errors.Is(err, apperror.ErrConflict) {
+			return domain.ErrSupplierInUse
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// checkSupplierNameAvailable makes sure no other supplier already uses the given name.
+// supplierID is the supplier being saved, or uuid.Nil for a new one.
+func (service *SupplierService) checkSupplierNameAvailable(ctx context.Context, supplierID uuid.UUID, supplierName string) error {
+	result, err := service.repo.SupplierRepo.GetSupplierByName(ctx, supplierName)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return err
+		}
+	}
+
+	if result.ID != uuid.Nil && result.ID != supplierID {
+		return domain.ErrSupplierAlreadyExist
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"fmt"
+	"log"
+)
+
+func New(attr InitAttribute) *SupplierService {
+	if err := attr.validate(); err != nil {
+		log.Panic(err)
+	}
+
+	return &SupplierService{
+		repo:   attr.Repo,
+		config: attr.Config,
+	}
+}
+
+func (attr InitAttribute) validate() error {
+	if !attr.Repo.validate() {
+		return fmt.Errorf("missing supplier repo : %+v", attr.Repo.SupplierRepo)
+	}
+
+	return nil
+}
+
+func (repo RepoAttribute) validate() bool {
+	return repo.SupplierRepo != nil
+}
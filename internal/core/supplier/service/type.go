@@ -0,0 +1,26 @@
+package service
+
+import (
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/gunawanpras/be-product-service/internal/core/supplier/port"
+)
+
+type (
+	RepoAttribute struct {
+		SupplierRepo port.Repository
+	}
+
+	ConfigAttribute struct {
+		Config *config.Config
+	}
+
+	SupplierService struct {
+		repo   RepoAttribute
+		config ConfigAttribute
+	}
+
+	InitAttribute struct {
+		Repo   RepoAttribute
+		Config ConfigAttribute
+	}
+)
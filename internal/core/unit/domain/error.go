@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+var (
+	ErrUnitNotFound       = apperror.NotFound(constant.CodeUnitNotFound, constant.UnitNotFound)
+	ErrUnitAlreadyExist   = apperror.Conflict(constant.CodeUnitAlreadyExist, constant.UnitAlreadyExist)
+	ErrUnitInUse          = apperror.Conflict(constant.CodeUnitInUse, constant.UnitInUse)
+	ErrUnitInvalidBase    = apperror.Unprocessable(constant.CodeUnitInvalidBase, constant.UnitInvalidBase)
+	ErrUnitUnknown        = apperror.Validation(constant.CodeUnitUnknown, constant.UnitUnknown)
+	ErrUnitNotConvertible = apperror.Unprocessable(constant.CodeUnitNotConvertible, constant.UnitNotConvertible)
+)
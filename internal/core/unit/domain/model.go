@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DimensionMass   = "mass"
+	DimensionVolume = "volume"
+	DimensionLength = "length"
+	DimensionCount  = "count"
+)
+
+// Unit is a unit of measure. A unit without BaseUnitID is a base unit and has a
+// Factor of 1, any other unit equals Factor times its base unit, e.g. a gram is
+// 0.001 times a kilogram.
+type Unit struct {
+	ID         uuid.UUID
+	Name       string
+	Dimension  string
+	BaseUnitID *uuid.UUID
+	Factor     float64
+}
+
+type Units []Unit
+
+// IsBase reports whether the unit is the base unit of its dimension.
+func (u Unit) IsBase() bool {
+	return u.BaseUnitID == nil
+}
+
+// BaseID returns the ID of the base unit the unit is expressed in.
+func (u Unit) BaseID() uuid.UUID {
+	if u.BaseUnitID == nil {
+		return u.ID
+	}
+
+	return *u.BaseUnitID
+}
+
+// Conversion converts quantities and unit prices from one unit to another.
+type Conversion struct {
+	From  Unit
+	To    Unit
+	Ratio float64
+}
+
+// conversionPrecision is the number of decimals kept by converted values.
+const conversionPrecision = 1e6
+
+// NewConversion builds the conversion between two units sharing the same base unit.
+func NewConversion(from, to Unit) Conversion {
+	return Conversion{
+		From:  from,
+		To:    to,
+		Ratio: from.Factor / to.Factor,
+	}
+}
+
+// Quantity converts a quantity expressed in From into To.
+func (c Conversion) Quantity(quantity float64) float64 {
+	return math.Round(quantity*c.Ratio*conversionPrecision) / conversionPrecision
+}
+
+// Price converts a price per From into a price per To.
+func (c Conversion) Price(price float64) float64 {
+	return math.Round(price/c.Ratio*conversionPrecision) / conversionPrecision
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/unit/domain"
+)
+
+type Repository interface {
+	CreateUnit(ctx context.Context, unit domain.Unit) (res uuid.UUID, err error)
+	GetListUnit(ctx context.Context, dimension string) (res domain.Units, err error)
+	GetUnitByID(ctx context.Context, unitID uuid.UUID) (res domain.Unit, err error)
+	GetUnitByName(ctx context.Context, unitName string) (res domain.Unit, err error)
+	UpdateUnit(ctx context.Context, unit domain.Unit) (err error)
+	DeleteUnit(ctx context.Context, unitID uuid.UUID) (err error)
+	CountProductByUnitID(ctx context.Context, unitID uuid.UUID) (res int, err error)
+	CountUnitByBaseUnitID(ctx context.Context, unitID uuid.UUID) (res int, err error)
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/unit/domain"
+)
+
+type Service interface {
+	CreateUnit(ctx context.Context, unit domain.Unit) (res domain.Unit, err error)
+	GetListUnit(ctx context.Context, dimension string) (res domain.Units, err error)
+	GetUnitByID(ctx context.Context, unitID uuid.UUID) (res domain.Unit, err error)
+	GetUnitByName(ctx context.Context, unitName string) (res domain.Unit, err error)
+	UpdateUnit(ctx context.Context, unit domain.Unit) (res domain.Unit, err error)
+	DeleteUnit(ctx context.Context, unitID uuid.UUID) (err error)
+	GetConversion(ctx context.Context, fromUnitID uuid.UUID, toUnitName string) (res domain.Conversion, err error)
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/unit/domain"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+)
+
+// CreateUnit creates a new unit of measure. It first checks that no unit with the same
+// name exists and that the conversion to the base unit is consistent, then creates the
+// unit and assigns a new ID to it.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - unit: domain.Unit containing the details of the unit to be created.
+//
+// Returns:
+// - res: domain.Unit representing the newly created unit.
+// - err: error if an error occurs during the creation process.
+func (service *UnitService) CreateUnit(ctx context.Context, unit domain.Unit) (res domain.Unit, err error) {
+	err = service.checkUnitNameAvailable(ctx, uuid.Nil, unit.Name)
+	if err != nil {
+		return res, err
+	}
+
+	newUnit, err := service.checkUnitBase(ctx, unit)
+	if err != nil {
+		return res, err
+	}
+
+	unitID, err := service.repo.UnitRepo.CreateUnit(ctx, newUnit)
+	if err != nil {
+		return res, err
+	}
+
+	newUnit.ID = unitID
+
+	return newUnit, nil
+}
+
+// GetListUnit retrieves the list of units, optionally filtered by dimension.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - dimension: The dimension to filter by, or empty for every unit.
+//
+// Returns:
+// - res: domain.Units representing the list of units that match the criteria.
+// - err: error if an error occurs during the retrieval process.
+func (service *UnitService) GetListUnit(ctx context.Context, dimension string) (res domain.Units, err error) {
+	res, err = service.repo.UnitRepo.GetListUnit(ctx, dimension)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// GetUnitByID retrieves a unit by ID from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - unitID: The ID of the unit to retrieve.
+//
+// Returns:
+// - res: domain.Unit representing the unit with the provided ID.
+// - err: error if an error occurs during the retrieval process.
+func (service *UnitService) GetUnitByID(ctx context.Context, unitID uuid.UUID) (res domain.Unit, err error) {
+	res, err = service.repo.UnitRepo.GetUnitByID(ctx, unitID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrUnitNotFound
+		}
+
+		return res, err
+	}
+
+	return res, nil
+}
+
+// GetUnitByName retrieves a unit by its exact name from the database.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - unitName: The name of the unit to retrieve.
+//
+// Returns:
+// - res: domain.Unit representing the unit with the provided name.
+// - err: error if an error occurs during the retrieval process.
+func (service *UnitService) GetUnitByName(ctx context.Context, unitName string) (res domain.Unit, err error) {
+	res, err = service.repo.UnitRepo.GetUnitByName(ctx, unitName)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrUnitNotFound
+		}
+
+		return res, err
+	}
+
+	return res, nil
+}
+
+// UpdateUnit replaces the name and conversion of an existing unit. A unit other units
+// are expressed in must stay a base unit of the same dimension.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - unit: domain.Unit containing the ID of the unit and its new details.
+//
+// Returns:
+// - res: domain.Unit representing the updated unit.
+// - err: error if an error occurs during the update process.
+func (service *UnitService) UpdateUnit(ctx context.Context, unit domain.Unit) (res domain.Unit, err error) {
+	existing, err := service.GetUnitByID(ctx, unit.ID)
+	if err != nil {
+		return res, err
+	}
+
+	err = service.checkUnitNameAvailable(ctx, existing.ID, unit.Name)
+	if err != nil {
+		return res, err
+	}
+
+	if unit.BaseUnitID != nil && *unit.BaseUnitID == existing.ID {
+		return res, domain.ErrUnitInvalidBase
+	}
+
+	updatedUnit, err := service.checkUnitBase(ctx, unit)
+	if err != nil {
+		return res, err
+	}
+
+	if existing.IsBase() && (!updatedUnit.IsBase() || updatedUnit.Dimension != existing.Dimension) {
+		total, err := service.repo.UnitRepo.CountUnitByBaseUnitID(ctx, existing.ID)
+		if err != nil {
+			return res, err
+		}
+
+		if total > 0 {
+			return res, domain.ErrUnitInUse
+		}
+	}
+
+	updatedUnit.ID = existing.ID
+
+	err = service.repo.UnitRepo.UpdateUnit(ctx, updatedUnit)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrUnitNotFound
+		}
+
+		return res, err
+	}
+
+	return updatedUnit, nil
+}
+
+// DeleteUnit removes a unit. The deletion is refused while any product, including
+// soft-deleted ones, or any other unit still references the unit.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - unitID: The ID of the unit to delete.
+//
+// Returns:
+// - err: error if an error occurs during the deletion process.
+func (service *UnitService) DeleteUnit(ctx context.Context, unitID uuid.UUID) (err error) {
+	total, err := service.repo.UnitRepo.CountProductByUnitID(ctx, unitID)
+	if err != nil {
+		return err
+	}
+
+	if total > 0 {
+		return domain.ErrUnitInUse
+	}
+
+	err = service.repo.UnitRepo.DeleteUnit(ctx, unitID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return domain.ErrUnitNotFound
+		}
+
+		// a product or a unit got attached to the unit in the meantime
+		if errors.Is(err, apperror.ErrConflict) {
+			return domain.ErrUnitInUse
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// GetConversion resolves the conversion from a unit to the unit with the given name.
+// Both units must be expressed in the same base unit.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - fromUnitID: The ID of the unit values are expressed in.
+// - toUnitName: The name of the unit values should be converted to.
+//
+// Returns:
+// - res: domain.Conversion between the two units.
+// - err: error if a unit is unknown or the units are not convertible.
+func (service *UnitService) GetConversion(ctx context.Context, fromUnitID uuid.UUID, toUnitName string) (res domain.Conversion, err error) {
+	from, err := service.GetUnitByID(ctx, fromUnitID)
+	if err != nil {
+		return res, err
+	}
+
+	to, err := service.repo.UnitRepo.GetUnitByName(ctx, toUnitName)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrUnitUnknown
+		}
+
+		return res, err
+	}
+
+	if from.Dimension != to.Dimension || from.BaseID() != to.BaseID() {
+		return res, domain.ErrUnitNotConvertible
+	}
+
+	return domain.NewConversion(from, to), nil
+}
+
+// checkUnitNameAvailable makes sure no other unit already uses the given name.
+// unitID is the unit being saved, or uuid.Nil for a new one.
+func (service *UnitService) checkUnitNameAvailable(ctx context.Context, unitID uuid.UUID, unitName string) error {
+	result, err := service.repo.UnitRepo.GetUnitByName(ctx, unitName)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return err
+		}
+	}
+
+	if result.ID != uuid.Nil && result.ID != unitID {
+		return domain.ErrUnitAlreadyExist
+	}
+
+	return nil
+}
+
+// checkUnitBase makes sure a unit is either a base unit, whose factor is always 1, or
+// is expressed in an existing base unit of the same dimension.
+func (service *UnitService) checkUnitBase(ctx context.Context, unit domain.Unit) (res domain.Unit, err error) {
+	res = domain.Unit{
+		Name:       unit.Name,
+		Dimension:  unit.Dimension,
+		BaseUnitID: unit.BaseUnitID,
+		Factor:     unit.Factor,
+	}
+
+	if unit.IsBase() {
+		res.Factor = 1
+		return res, nil
+	}
+
+	base, err := service.repo.UnitRepo.GetUnitByID(ctx, *unit.BaseUnitID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrUnitInvalidBase
+		}
+
+		return res, err
+	}
+
+	if !base.IsBase() || base.Dimension != unit.Dimension {
+		return res, domain.ErrUnitInvalidBase
+	}
+
+	return res, nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"fmt"
+	"log"
+)
+
+func New(attr InitAttribute) *UnitService {
+	if err := attr.validate(); err != nil {
+		log.Panic(err)
+	}
+
+	return &UnitService{
+		repo:   attr.Repo,
+		config: attr.Config,
+	}
+}
+
+func (attr InitAttribute) validate() error {
+	if !attr.Repo.validate() {
+		return fmt.Errorf("missing unit repo : %+v", attr.Repo.UnitRepo)
+	}
+
+	return nil
+}
+
+func (repo RepoAttribute) validate() bool {
+	return repo.UnitRepo != nil
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/gunawanpras/be-product-service/internal/core/unit/port"
+)
+
+type (
+	RepoAttribute struct {
+		UnitRepo port.Repository
+	}
+
+	ConfigAttribute struct {
+		Config *config.Config
+	}
+
+	UnitService struct {
+		repo   RepoAttribute
+		config ConfigAttribute
+	}
+
+	InitAttribute struct {
+		Repo   RepoAttribute
+		Config ConfigAttribute
+	}
+)
@@ -0,0 +1,10 @@
+package domain
+
+import (
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+var (
+	ErrWebhookNotFound = apperror.NotFound(constant.CodeWebhookNotFound, constant.WebhookNotFound)
+)
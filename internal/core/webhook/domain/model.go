@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+// Webhook subscribes a URL to the events of the given types. Its secret signs every
+// delivery sent to the URL.
+type Webhook struct {
+	ID         uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []string
+	CreatedAt  time.Time
+	CreatedBy  string
+}
+
+type Webhooks []Webhook
+
+// Subscribes reports whether the webhook receives the events of the given type.
+func (w Webhook) Subscribes(eventType string) bool {
+	return slices.Contains(w.EventTypes, eventType)
+}
+
+// Sign returns the signature of a delivery body sent at the given unix timestamp, the
+// hex encoded HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret of the webhook.
+// Signing the timestamp along with the body lets receivers reject replayed deliveries.
+func (w Webhook) Sign(timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return constant.WebhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Event is an event to be delivered to the webhooks subscribed to its type.
+type Event struct {
+	ID        uuid.UUID
+	Type      string
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// Delivery is an event queued for a webhook. A pending delivery is sent again at
+// NextAttemptAt until the webhook accepts it, when it becomes delivered, or it runs out
+// of attempts, when it becomes dead. Webhook is only set on the deliveries claimed for
+// dispatch.
+type Delivery struct {
+	ID             uuid.UUID
+	WebhookID      uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	Webhook        *Webhook
+}
+
+type Deliveries []Delivery
+
+// DeliveryAttempt is the outcome of sending a delivery once.
+type DeliveryAttempt struct {
+	DeliveryID    uuid.UUID
+	Status        string
+	AttemptedAt   time.Time
+	NextAttemptAt time.Time
+	StatusCode    *int
+	Error         *string
+	DeliveredAt   *time.Time
+}
+
+// DeliveryFilter selects a page of the deliveries of a webhook, newest first, optionally
+// of a single status. Page starts at 1.
+type DeliveryFilter struct {
+	Status string
+	Page   int
+	Limit  int
+}
+
+// Offset returns the number of deliveries preceding the page.
+func (f DeliveryFilter) Offset() int {
+	if f.Page < 1 {
+		return 0
+	}
+
+	return (f.Page - 1) * f.Limit
+}
+
+// DeliveryPage is a page of the deliveries of a webhook, along with the number of
+// deliveries matching the filter.
+type DeliveryPage struct {
+	Deliveries Deliveries
+	Total      int
+}
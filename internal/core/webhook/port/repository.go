@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/domain"
+)
+
+type Repository interface {
+	CreateWebhook(ctx context.Context, webhook domain.Webhook) (res uuid.UUID, err error)
+	GetListWebhook(ctx context.Context) (res domain.Webhooks, err error)
+	GetWebhookByID(ctx context.Context, webhookID uuid.UUID) (res domain.Webhook, err error)
+	DeleteWebhook(ctx context.Context, webhookID uuid.UUID) (err error)
+	GetListWebhookByEventType(ctx context.Context, eventType string) (res domain.Webhooks, err error)
+
+	// CreateDeliveries queues deliveries, skipping those of an event already queued for
+	// the same webhook.
+	CreateDeliveries(ctx context.Context, deliveries domain.Deliveries) (err error)
+
+	// ClaimDueDeliveries returns up to limit pending deliveries due at now, along with
+	// their webhook. Their next attempt is moved to leaseUntil, so that no other
+	// dispatcher claims them while they are being sent.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) (res domain.Deliveries, err error)
+	MarkDeliveryAttempt(ctx context.Context, attempt domain.DeliveryAttempt) (err error)
+	GetListDeliveryByWebhookID(ctx context.Context, webhookID uuid.UUID, filter domain.DeliveryFilter) (res domain.DeliveryPage, err error)
+}
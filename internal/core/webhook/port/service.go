@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/domain"
+)
+
+type Service interface {
+	CreateWebhook(ctx context.Context, webhook domain.Webhook) (res domain.Webhook, err error)
+	GetListWebhook(ctx context.Context) (res domain.Webhooks, err error)
+	GetWebhookByID(ctx context.Context, webhookID uuid.UUID) (res domain.Webhook, err error)
+	DeleteWebhook(ctx context.Context, webhookID uuid.UUID) (err error)
+	GetListDelivery(ctx context.Context, webhookID uuid.UUID, filter domain.DeliveryFilter) (res domain.DeliveryPage, err error)
+
+	EnqueueEvent(ctx context.Context, event domain.Event) (err error)
+	RunDispatcher(ctx context.Context)
+	DispatchDeliveries(ctx context.Context) (res int, err error)
+}
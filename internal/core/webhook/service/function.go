@@ -0,0 +1,415 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/domain"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/timeutil"
+)
+
+// deliveryBody is the JSON body sent to a webhook, data being the payload of the event.
+type deliveryBody struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// CreateWebhook subscribes a URL to the given event types. A secret is generated when the
+// webhook does not come with one, the secret is only ever returned by this call.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - webhook: domain.Webhook containing the URL, event types and optional secret.
+//
+// Returns:
+// - res: domain.Webhook representing the newly created webhook, including its secret.
+// - err: error if an error occurs during the creation process.
+func (service *WebhookService) CreateWebhook(ctx context.Context, webhook domain.Webhook) (res domain.Webhook, err error) {
+	secret := webhook.Secret
+	if secret == "" {
+		if secret, err = newSecret(); err != nil {
+			return res, err
+		}
+	}
+
+	newWebhook := domain.Webhook{
+		URL:        webhook.URL,
+		Secret:     secret,
+		EventTypes: webhook.EventTypes,
+		CreatedAt:  timeutil.TimeHelper.Now(),
+		CreatedBy:  constant.SYSTEM,
+	}
+
+	webhookID, err := service.repo.WebhookRepo.CreateWebhook(ctx, newWebhook)
+	if err != nil {
+		return res, err
+	}
+
+	newWebhook.ID = webhookID
+
+	return newWebhook, nil
+}
+
+// GetListWebhook retrieves every webhook, oldest first.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+//
+// Returns:
+// - res: domain.Webhooks representing the list of webhooks.
+// - err: error if an error occurs during the retrieval process.
+func (service *WebhookService) GetListWebhook(ctx context.Context) (res domain.Webhooks, err error) {
+	res, err = service.repo.WebhookRepo.GetListWebhook(ctx)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// GetWebhookByID retrieves a webhook by ID.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - webhookID: The ID of the webhook to retrieve.
+//
+// Returns:
+// - res: domain.Webhook representing the webhook with the provided ID.
+// - err: error if an error occurs during the retrieval process.
+func (service *WebhookService) GetWebhookByID(ctx context.Context, webhookID uuid.UUID) (res domain.Webhook, err error) {
+	res, err = service.repo.WebhookRepo.GetWebhookByID(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return res, domain.ErrWebhookNotFound
+		}
+
+		return res, err
+	}
+
+	return res, nil
+}
+
+// DeleteWebhook removes a webhook along with its deliveries, including those still
+// pending.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - webhookID: The ID of the webhook to delete.
+//
+// Returns:
+// - err: error if an error occurs during the deletion process.
+func (service *WebhookService) DeleteWebhook(ctx context.Context, webhookID uuid.UUID) (err error) {
+	err = service.repo.WebhookRepo.DeleteWebhook(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return domain.ErrWebhookNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// GetListDelivery retrieves a page of the deliveries of a webhook, newest first, along
+// with the outcome of their last attempt.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - webhookID: The ID of the webhook.
+// - filter: domain.DeliveryFilter selecting the status and page of the deliveries.
+//
+// Returns:
+// - res: domain.DeliveryPage representing the page of deliveries and their total.
+// - err: error if the webhook does not exist or an error occurs during the retrieval process.
+func (service *WebhookService) GetListDelivery(ctx context.Context, webhookID uuid.UUID, filter domain.DeliveryFilter) (res domain.DeliveryPage, err error) {
+	if _, err = service.GetWebhookByID(ctx, webhookID); err != nil {
+		return res, err
+	}
+
+	res, err = service.repo.WebhookRepo.GetListDeliveryByWebhookID(ctx, webhookID, filter)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// EnqueueEvent queues a delivery of the event for every webhook subscribed to its type.
+// An event queued again, as happens when it is published more than once, is only
+// delivered once to each webhook.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+// - event: domain.Event whose payload is sent as the data of the deliveries.
+//
+// Returns:
+// - err: error if an error occurs while queueing the deliveries.
+func (service *WebhookService) EnqueueEvent(ctx context.Context, event domain.Event) (err error) {
+	webhooks, err := service.repo.WebhookRepo.GetListWebhookByEventType(ctx, event.Type)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return err
+		}
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(deliveryBody{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt,
+		Data:      json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return err
+	}
+
+	now := timeutil.TimeHelper.Now()
+	deliveries := make(domain.Deliveries, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, domain.Delivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        constant.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+
+	return service.repo.WebhookRepo.CreateDeliveries(ctx, deliveries)
+}
+
+// RunDispatcher sends the due deliveries of the webhooks until ctx is done. It polls the
+// deliveries every poll interval, and right away again as long as full batches are due.
+//
+// Parameters:
+// - ctx: Context whose cancellation stops the dispatcher.
+func (service *WebhookService) RunDispatcher(ctx context.Context) {
+	ticker := time.NewTicker(service.pollInterval())
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		sent, err := service.DispatchDeliveries(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[RunDispatcher] error: %v", err)
+		}
+
+		if err == nil && sent == service.batchSize() {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDeliveries sends a batch of the due deliveries concurrently. A delivery is
+// delivered once its webhook responds with a 2xx status. Otherwise it is sent again after
+// a delay which doubles with each attempt, until it runs out of attempts and is dead.
+// Several dispatchers may run at once, each delivery being claimed by a single one.
+//
+// Parameters:
+// - ctx: Context for controlling the lifetime of the request.
+//
+// Returns:
+// - res: The number of deliveries sent, whether or not their webhook accepted them.
+// - err: error if the deliveries could not be read or updated.
+func (service *WebhookService) DispatchDeliveries(ctx context.Context) (res int, err error) {
+	now := timeutil.TimeHelper.Now()
+
+	// a delivery is sent within the timeout, it is claimed for twice as long so that
+	// its attempt is recorded before another dispatcher may claim it again
+	leaseUntil := now.Add(2 * service.timeout())
+
+	deliveries, err := service.repo.WebhookRepo.ClaimDueDeliveries(ctx, now, leaseUntil, service.batchSize())
+	if err != nil {
+		return res, err
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, delivery := range deliveries {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			attempt := service.deliver(ctx, delivery)
+			if err := service.repo.WebhookRepo.MarkDeliveryAttempt(ctx, attempt); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return len(deliveries), errors.Join(errs...)
+}
+
+// deliver sends a delivery to its webhook once and returns the outcome of the attempt.
+func (service *WebhookService) deliver(ctx context.Context, delivery domain.Delivery) domain.DeliveryAttempt {
+	attempt := domain.DeliveryAttempt{
+		DeliveryID:  delivery.ID,
+		AttemptedAt: timeutil.TimeHelper.Now(),
+	}
+
+	statusCode, err := service.send(ctx, delivery, attempt.AttemptedAt)
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+	}
+
+	now := timeutil.TimeHelper.Now()
+	attempt.NextAttemptAt = now
+
+	if err == nil {
+		attempt.Status = constant.WebhookDeliveryDelivered
+		attempt.DeliveredAt = &now
+
+		return attempt
+	}
+
+	lastError := err.Error()
+	attempt.Error = &lastError
+
+	if delivery.Attempts+1 >= service.maxAttempts() {
+		attempt.Status = constant.WebhookDeliveryDead
+
+		return attempt
+	}
+
+	attempt.Status = constant.WebhookDeliveryPending
+	attempt.NextAttemptAt = now.Add(service.retryDelay(delivery.Attempts))
+
+	return attempt
+}
+
+// send posts the payload of a delivery to its webhook, signed along with the time of the
+// attempt. It returns the status code of the response, if any, and an error unless the
+// webhook accepted the delivery.
+func (service *WebhookService) send(ctx context.Context, delivery domain.Delivery, attemptedAt time.Time) (statusCode int, err error) {
+	ctx, cancel := context.WithTimeout(ctx, service.timeout())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := attemptedAt.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.HeaderWebhookID, delivery.ID.String())
+	req.Header.Set(constant.HeaderWebhookEvent, delivery.EventType)
+	req.Header.Set(constant.HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(constant.HeaderWebhookSignature, delivery.Webhook.Sign(timestamp, delivery.Payload))
+
+	resp, err := service.client.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, constant.WebhookResponseLimit))
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return resp.StatusCode, nil
+}
+
+// retryDelay is the delay before the next attempt of a delivery which failed after the
+// given number of previous attempts, within the bounds of the webhook config.
+func (service *WebhookService) retryDelay(attempts int) time.Duration {
+	conf := service.config.Config.Webhook
+
+	base := time.Duration(conf.RetryBaseDelayInSecond) * time.Second
+	if base <= 0 {
+		base = constant.WebhookRetryBaseDelay * time.Second
+	}
+
+	maxDelay := time.Duration(conf.RetryMaxDelayInSecond) * time.Second
+	if maxDelay <= 0 {
+		maxDelay = constant.WebhookRetryMaxDelay * time.Second
+	}
+
+	return timeutil.Backoff(attempts, base, maxDelay)
+}
+
+func (service *WebhookService) pollInterval() time.Duration {
+	interval := service.config.Config.Webhook.PollIntervalInMillisecond
+	if interval <= 0 {
+		interval = constant.WebhookPollInterval
+	}
+
+	return time.Duration(interval) * time.Millisecond
+}
+
+func (service *WebhookService) batchSize() int {
+	size := service.config.Config.Webhook.BatchSize
+	if size <= 0 {
+		size = constant.WebhookBatchSize
+	}
+
+	return size
+}
+
+func (service *WebhookService) timeout() time.Duration {
+	timeout := service.config.Config.Webhook.TimeoutInSecond
+	if timeout <= 0 {
+		timeout = constant.WebhookTimeout
+	}
+
+	return time.Duration(timeout) * time.Second
+}
+
+func (service *WebhookService) maxAttempts() int {
+	attempts := service.config.Config.Webhook.MaxAttempts
+	if attempts <= 0 {
+		attempts = constant.WebhookMaxAttempts
+	}
+
+	return attempts
+}
+
+// newSecret generates a random secret of constant.WebhookSecretSize bytes, hex encoded.
+func newSecret() (string, error) {
+	secret := make([]byte, constant.WebhookSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,337 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/domain"
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/service"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/dbutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubRepository holds webhooks and their deliveries in memory, claiming the due
+// deliveries the way the postgres repository does.
+type stubRepository struct {
+	mu         sync.Mutex
+	webhooks   domain.Webhooks
+	deliveries domain.Deliveries
+}
+
+func (r *stubRepository) CreateWebhook(ctx context.Context, webhook domain.Webhook) (uuid.UUID, error) {
+	webhook.ID = uuid.New()
+	r.webhooks = append(r.webhooks, webhook)
+
+	return webhook.ID, nil
+}
+
+func (r *stubRepository) GetListWebhook(ctx context.Context) (domain.Webhooks, error) {
+	return r.webhooks, nil
+}
+
+func (r *stubRepository) GetWebhookByID(ctx context.Context, webhookID uuid.UUID) (domain.Webhook, error) {
+	for _, webhook := range r.webhooks {
+		if webhook.ID == webhookID {
+			return webhook, nil
+		}
+	}
+
+	return domain.Webhook{}, dbutil.ErrDataNotFound
+}
+
+func (r *stubRepository) DeleteWebhook(ctx context.Context, webhookID uuid.UUID) error {
+	return dbutil.ErrDataNotFound
+}
+
+func (r *stubRepository) GetListWebhookByEventType(ctx context.Context, eventType string) (res domain.Webhooks, err error) {
+	for _, webhook := range r.webhooks {
+		if webhook.Subscribes(eventType) {
+			res = append(res, webhook)
+		}
+	}
+
+	return res, nil
+}
+
+func (r *stubRepository) CreateDeliveries(ctx context.Context, deliveries domain.Deliveries) error {
+	for _, delivery := range deliveries {
+		delivery.ID = uuid.New()
+		r.deliveries = append(r.deliveries, delivery)
+	}
+
+	return nil
+}
+
+func (r *stubRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) (res domain.Deliveries, err error) {
+	for i, delivery := range r.deliveries {
+		if len(res) == limit || delivery.Status != constant.WebhookDeliveryPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+
+		webhook, err := r.GetWebhookByID(ctx, delivery.WebhookID)
+		if err != nil {
+			return nil, err
+		}
+
+		r.deliveries[i].NextAttemptAt = leaseUntil
+		delivery.Webhook = &webhook
+		res = append(res, delivery)
+	}
+
+	return res, nil
+}
+
+func (r *stubRepository) MarkDeliveryAttempt(ctx context.Context, attempt domain.DeliveryAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, delivery := range r.deliveries {
+		if delivery.ID == attempt.DeliveryID {
+			r.deliveries[i].Status = attempt.Status
+			r.deliveries[i].Attempts++
+			r.deliveries[i].NextAttemptAt = attempt.NextAttemptAt
+			r.deliveries[i].LastAttemptAt = &attempt.AttemptedAt
+			r.deliveries[i].LastStatusCode = attempt.StatusCode
+			r.deliveries[i].LastError = attempt.Error
+			r.deliveries[i].DeliveredAt = attempt.DeliveredAt
+		}
+	}
+
+	return nil
+}
+
+func (r *stubRepository) GetListDeliveryByWebhookID(ctx context.Context, webhookID uuid.UUID, filter domain.DeliveryFilter) (res domain.DeliveryPage, err error) {
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookID == webhookID {
+			res.Deliveries = append(res.Deliveries, delivery)
+		}
+	}
+
+	res.Total = len(res.Deliveries)
+
+	return res, nil
+}
+
+func newService(repo *stubRepository) *service.WebhookService {
+	conf := &config.Config{
+		Webhook: config.WebhookConfig{
+			TimeoutInSecond:        5,
+			MaxAttempts:            2,
+			RetryBaseDelayInSecond: 30,
+			RetryMaxDelayInSecond:  60,
+		},
+	}
+
+	return service.New(service.InitAttribute{
+		Repo:   service.RepoAttribute{WebhookRepo: repo},
+		Client: service.ClientAttribute{HTTPClient: http.DefaultClient},
+		Config: service.ConfigAttribute{Config: conf},
+	})
+}
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("generates a secret", func(t *testing.T) {
+		res, err := newService(&stubRepository{}).CreateWebhook(ctx, domain.Webhook{
+			URL:        "https://partner.example.com/hooks",
+			EventTypes: []string{constant.ProductEventCreated},
+		})
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, res.ID)
+		assert.Len(t, res.Secret, 2*constant.WebhookSecretSize)
+		assert.Equal(t, constant.SYSTEM, res.CreatedBy)
+	})
+
+	t.Run("keeps the given secret", func(t *testing.T) {
+		res, err := newService(&stubRepository{}).CreateWebhook(ctx, domain.Webhook{
+			URL:        "https://partner.example.com/hooks",
+			Secret:     "s3cr3t",
+			EventTypes: []string{constant.ProductEventCreated},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "s3cr3t", res.Secret)
+	})
+}
+
+func TestWebhookService_EnqueueEvent(t *testing.T) {
+	ctx := context.Background()
+
+	products := domain.Webhook{ID: uuid.New(), EventTypes: []string{constant.ProductEventCreated, constant.ProductEventUpdated}}
+	stock := domain.Webhook{ID: uuid.New(), EventTypes: []string{constant.StockEventChanged}}
+	repo := &stubRepository{webhooks: domain.Webhooks{products, stock}}
+
+	event := domain.Event{
+		ID:        uuid.New(),
+		Type:      constant.ProductEventCreated,
+		Payload:   []byte(`{"id":"a1b2c3d4-0000-0000-0000-000000000001","name":"Kangkung"}`),
+		CreatedAt: time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC),
+	}
+
+	assert.NoError(t, newService(repo).EnqueueEvent(ctx, event))
+
+	if assert.Len(t, repo.deliveries, 1) {
+		delivery := repo.deliveries[0]
+		assert.Equal(t, products.ID, delivery.WebhookID)
+		assert.Equal(t, event.ID, delivery.EventID)
+		assert.Equal(t, constant.WebhookDeliveryPending, delivery.Status)
+		assert.JSONEq(t, `{
+			"id": "`+event.ID.String()+`",
+			"type": "product.created",
+			"created_at": "2025-03-01T08:00:00Z",
+			"data": {"id": "a1b2c3d4-0000-0000-0000-000000000001", "name": "Kangkung"}
+		}`, string(delivery.Payload))
+	}
+
+	event.Type = constant.ProductEventPurged
+	assert.NoError(t, newService(repo).EnqueueEvent(ctx, event))
+	assert.Len(t, repo.deliveries, 1)
+}
+
+// receiver is a webhook endpoint which checks the signature of the deliveries it gets,
+// and responds with the given status.
+type receiver struct {
+	t        *testing.T
+	webhook  domain.Webhook
+	status   int
+	mu       sync.Mutex
+	received []string
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	assert.NoError(rc.t, err)
+
+	timestamp, err := strconv.ParseInt(r.Header.Get(constant.HeaderWebhookTimestamp), 10, 64)
+	assert.NoError(rc.t, err)
+	assert.Equal(rc.t, rc.webhook.Sign(timestamp, body), r.Header.Get(constant.HeaderWebhookSignature))
+	assert.Equal(rc.t, "application/json", r.Header.Get("Content-Type"))
+	assert.True(rc.t, json.Valid(body))
+
+	rc.mu.Lock()
+	rc.received = append(rc.received, r.Header.Get(constant.HeaderWebhookEvent))
+	rc.mu.Unlock()
+
+	w.WriteHeader(rc.status)
+	_, _ = w.Write([]byte("busy"))
+}
+
+func TestWebhookService_DispatchDeliveries(t *testing.T) {
+	ctx := context.Background()
+
+	newDelivery := func(webhook domain.Webhook, attempts int) domain.Delivery {
+		return domain.Delivery{
+			ID:            uuid.New(),
+			WebhookID:     webhook.ID,
+			EventID:       uuid.New(),
+			EventType:     constant.ProductEventCreated,
+			Payload:       []byte(`{"type":"product.created"}`),
+			Status:        constant.WebhookDeliveryPending,
+			Attempts:      attempts,
+			NextAttemptAt: time.Now().Add(-time.Second),
+		}
+	}
+
+	t.Run("delivers when the webhook accepts", func(t *testing.T) {
+		webhook := domain.Webhook{ID: uuid.New(), Secret: "s3cr3t"}
+		rc := &receiver{t: t, webhook: webhook, status: http.StatusNoContent}
+		server := httptest.NewServer(rc)
+		defer server.Close()
+
+		webhook.URL = server.URL
+		repo := &stubRepository{webhooks: domain.Webhooks{webhook}}
+		repo.deliveries = domain.Deliveries{newDelivery(webhook, 0), newDelivery(webhook, 0)}
+
+		res, err := newService(repo).DispatchDeliveries(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, res)
+		assert.Equal(t, []string{constant.ProductEventCreated, constant.ProductEventCreated}, rc.received)
+
+		for _, delivery := range repo.deliveries {
+			assert.Equal(t, constant.WebhookDeliveryDelivered, delivery.Status)
+			assert.Equal(t, 1, delivery.Attempts)
+			assert.Equal(t, http.StatusNoContent, *delivery.LastStatusCode)
+			assert.Nil(t, delivery.LastError)
+			assert.NotNil(t, delivery.DeliveredAt)
+		}
+
+		res, err = newService(repo).DispatchDeliveries(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, res)
+	})
+
+	t.Run("retries then dead-letters when the webhook fails", func(t *testing.T) {
+		webhook := domain.Webhook{ID: uuid.New(), Secret: "s3cr3t"}
+		rc := &receiver{t: t, webhook: webhook, status: http.StatusServiceUnavailable}
+		server := httptest.NewServer(rc)
+		defer server.Close()
+
+		webhook.URL = server.URL
+		repo := &stubRepository{webhooks: domain.Webhooks{webhook}}
+		repo.deliveries = domain.Deliveries{newDelivery(webhook, 0)}
+
+		res, err := newService(repo).DispatchDeliveries(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, res)
+
+		delivery := repo.deliveries[0]
+		assert.Equal(t, constant.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, http.StatusServiceUnavailable, *delivery.LastStatusCode)
+		assert.Equal(t, "webhook responded with status 503: busy", *delivery.LastError)
+		assert.WithinDuration(t, time.Now().Add(30*time.Second), delivery.NextAttemptAt, 5*time.Second)
+
+		// not due before its next attempt
+		res, err = newService(repo).DispatchDeliveries(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, res)
+
+		repo.deliveries[0].NextAttemptAt = time.Now().Add(-time.Second)
+		res, err = newService(repo).DispatchDeliveries(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, res)
+
+		delivery = repo.deliveries[0]
+		assert.Equal(t, constant.WebhookDeliveryDead, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Len(t, rc.received, 2)
+
+		res, err = newService(repo).DispatchDeliveries(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, res)
+	})
+
+	t.Run("retries when the webhook is unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		webhook := domain.Webhook{ID: uuid.New(), URL: server.URL, Secret: "s3cr3t"}
+		repo := &stubRepository{webhooks: domain.Webhooks{webhook}}
+		repo.deliveries = domain.Deliveries{newDelivery(webhook, 0)}
+
+		_, err := newService(repo).DispatchDeliveries(ctx)
+		assert.NoError(t, err)
+
+		delivery := repo.deliveries[0]
+		assert.Equal(t, constant.WebhookDeliveryPending, delivery.Status)
+		assert.Nil(t, delivery.LastStatusCode)
+		assert.NotNil(t, delivery.LastError)
+	})
+}
+
+func TestWebhook_Sign(t *testing.T) {
+	webhook := domain.Webhook{Secret: "s3cr3t"}
+
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac s3cr3t
+	assert.Equal(t, "sha256=dd8508e44d9a9f82f2690fb7dff1da8a6ae99700d98a23a4e7e1c307af3cb6cb", webhook.Sign(1700000000, []byte(`{}`)))
+}
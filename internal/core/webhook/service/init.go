@@ -0,0 +1,42 @@
+package service
+
+import (
+	"fmt"
+	"log"
+)
+
+func New(attr InitAttribute) *WebhookService {
+	if err := attr.validate(); err != nil {
+		log.Panic(err)
+	}
+
+	return &WebhookService{
+		repo:   attr.Repo,
+		client: attr.Client,
+		config: attr.Config,
+	}
+}
+
+func (attr InitAttribute) validate() error {
+	if !attr.Repo.validate() {
+		return fmt.Errorf("missing webhook repo : %+v", attr.Repo.WebhookRepo)
+	}
+
+	if !attr.Client.validate() {
+		return fmt.Errorf("missing http client : %+v", attr.Client.HTTPClient)
+	}
+
+	if attr.Config.Config == nil {
+		return fmt.Errorf("missing config : %+v", attr.Config.Config)
+	}
+
+	return nil
+}
+
+func (repo RepoAttribute) validate() bool {
+	return repo.WebhookRepo != nil
+}
+
+func (client ClientAttribute) validate() bool {
+	return client.HTTPClient != nil
+}
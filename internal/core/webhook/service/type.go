@@ -0,0 +1,34 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/gunawanpras/be-product-service/internal/core/webhook/port"
+)
+
+type (
+	RepoAttribute struct {
+		WebhookRepo port.Repository
+	}
+
+	ClientAttribute struct {
+		HTTPClient *http.Client
+	}
+
+	ConfigAttribute struct {
+		Config *config.Config
+	}
+
+	WebhookService struct {
+		repo   RepoAttribute
+		client ClientAttribute
+		config ConfigAttribute
+	}
+
+	InitAttribute struct {
+		Repo   RepoAttribute
+		Client ClientAttribute
+		Config ConfigAttribute
+	}
+)
@@ -2,6 +2,7 @@ package setup
 
 import (
 	"github.com/go-redis/cache/v8"
+	"github.com/go-redis/redis/v8"
 	"github.com/gunawanpras/be-product-service/config"
 	rd "github.com/gunawanpras/be-product-service/internal/adapter/cache/redis"
 	productRdCache "github.com/gunawanpras/be-product-service/internal/adapter/cache/redis/product"
@@ -9,24 +10,27 @@ import (
 )
 
 type Cache struct {
+	RedisClient  rd.Cache
 	ProductCache port.Cache
 }
 
-func NewCache(conf *config.Config, client *cache.Cache) Cache {
-	redisClient := rd.NewRedisCacheClient(rd.InitAttribute{
+func NewCache(conf *config.Config, client *cache.Cache, redisClient *redis.Client) Cache {
+	cacheClient := rd.NewRedisCacheClient(rd.InitAttribute{
 		Client: rd.Client{
 			Client: client,
+			Redis:  redisClient,
 		},
 	})
 
 	productCache := productRdCache.NewProductCache(productRdCache.InitAttribute{
 		RedisClient: productRdCache.RedisClient{
-			RedisClient: redisClient,
+			RedisClient: cacheClient,
 		},
 		Config: conf,
 	})
 
 	return Cache{
+		RedisClient:  cacheClient,
 		ProductCache: productCache,
 	}
 }
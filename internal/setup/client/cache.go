@@ -9,8 +9,8 @@ import (
 	"github.com/gunawanpras/be-product-service/config"
 )
 
-func InitRedis(conf *config.Config) (c *rCache.Cache) {
-	client := redis.NewClient(&redis.Options{
+func InitRedis(conf *config.Config) (client *redis.Client, c *rCache.Cache) {
+	client = redis.NewClient(&redis.Options{
 		Addr:         fmt.Sprintf("%s:%d", conf.Redis.Primary.Host, conf.Redis.Primary.Port),
 		DialTimeout:  time.Duration(conf.Redis.Primary.DialTimeout) * time.Second,
 		ReadTimeout:  time.Duration(conf.Redis.Primary.ReadTimeout) * time.Second,
@@ -22,5 +22,5 @@ func InitRedis(conf *config.Config) (c *rCache.Cache) {
 		LocalCache: rCache.NewTinyLFU(1000, time.Minute),
 	})
 
-	return c
+	return client, c
 }
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+// InitHTTPClient returns the client the webhooks are delivered with. Redirects are not
+// followed, a webhook must accept its deliveries at the URL it was created with.
+func InitHTTPClient(conf *config.Config) *http.Client {
+	timeout := conf.Webhook.TimeoutInSecond
+	if timeout <= 0 {
+		timeout = constant.WebhookTimeout
+	}
+
+	return &http.Client{
+		Timeout: time.Duration(timeout) * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"log"
+
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// InitNats connects to NATS and makes sure the JetStream stream of the product events
+// exists, capturing every subject under the configured subject.
+func InitNats(conf *config.Config) (*nats.Conn, jetstream.JetStream) {
+	nc, err := nats.Connect(conf.Nats.Url, nats.Name(conf.Server.Name), nats.MaxReconnects(-1))
+	if err != nil {
+		log.Panic("failed to connect to nats:", err)
+	}
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		log.Panic("failed to open nats jetstream:", err)
+	}
+
+	_, err = js.CreateOrUpdateStream(context.Background(), jetstream.StreamConfig{
+		Name:     conf.Nats.Stream,
+		Subjects: []string{conf.Nats.Subject + ".>"},
+	})
+	if err != nil {
+		log.Panic("failed to create nats stream:", err)
+	}
+
+	return nc, js
+}
@@ -1,16 +1,59 @@
 package setup
 
-import handler "github.com/gunawanpras/be-product-service/internal/adapter/http/handler/product"
+import (
+	"github.com/gunawanpras/be-product-service/config"
+	categoryHandler "github.com/gunawanpras/be-product-service/internal/adapter/http/handler/category"
+	handler "github.com/gunawanpras/be-product-service/internal/adapter/http/handler/product"
+	supplierHandler "github.com/gunawanpras/be-product-service/internal/adapter/http/handler/supplier"
+	unitHandler "github.com/gunawanpras/be-product-service/internal/adapter/http/handler/unit"
+	webhookHandler "github.com/gunawanpras/be-product-service/internal/adapter/http/handler/webhook"
+	"github.com/gunawanpras/be-product-service/internal/adapter/http/middleware"
+)
 
 type Handler struct {
-	ProductHandler handler.Handler
+	Middleware      middleware.Middleware
+	ProductHandler  handler.Handler
+	CategoryHandler categoryHandler.Handler
+	SupplierHandler supplierHandler.Handler
+	UnitHandler     unitHandler.Handler
+	WebhookHandler  webhookHandler.Handler
 }
 
-func NewHandler(service Service) *Handler {
+func NewHandler(conf *config.Config, service Service, importer Importer, cache Cache) *Handler {
 	return &Handler{
+		Middleware: middleware.New(middleware.InitAttribute{
+			Cache: middleware.CacheAttribute{
+				RedisClient: cache.RedisClient,
+			},
+			Config: conf,
+		}),
 		ProductHandler: handler.New(handler.InitAttribute{
 			Service: handler.ServiceAttribute{
 				ProductService: service.ProductService,
+				UnitService:    service.UnitService,
+			},
+			Importer: importer.ProductImporter,
+			Config:   conf,
+		}),
+		CategoryHandler: categoryHandler.New(categoryHandler.InitAttribute{
+			Service: categoryHandler.ServiceAttribute{
+				CategoryService: service.CategoryService,
+			},
+		}),
+		SupplierHandler: supplierHandler.New(supplierHandler.InitAttribute{
+			Service: supplierHandler.ServiceAttribute{
+				SupplierService: service.SupplierService,
+				ProductService:  service.ProductService,
+			},
+		}),
+		UnitHandler: unitHandler.New(unitHandler.InitAttribute{
+			Service: unitHandler.ServiceAttribute{
+				UnitService: service.UnitService,
+			},
+		}),
+		WebhookHandler: webhookHandler.New(webhookHandler.InitAttribute{
+			Service: webhookHandler.ServiceAttribute{
+				WebhookService: service.WebhookService,
 			},
 		}),
 	}
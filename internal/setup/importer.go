@@ -0,0 +1,24 @@
+package setup
+
+import (
+	"github.com/gunawanpras/be-product-service/config"
+	productImporter "github.com/gunawanpras/be-product-service/internal/adapter/importer/product"
+)
+
+type Importer struct {
+	ProductImporter productImporter.Importer
+}
+
+func NewImporter(conf *config.Config, service Service) Importer {
+	return Importer{
+		ProductImporter: productImporter.New(productImporter.InitAttribute{
+			Service: productImporter.ServiceAttribute{
+				ProductService:  service.ProductService,
+				CategoryService: service.CategoryService,
+				SupplierService: service.SupplierService,
+				UnitService:     service.UnitService,
+			},
+			Config: conf,
+		}),
+	}
+}
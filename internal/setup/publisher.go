@@ -0,0 +1,32 @@
+package setup
+
+import (
+	"github.com/gunawanpras/be-product-service/config"
+	"github.com/gunawanpras/be-product-service/internal/adapter/publisher/memory"
+	natsPublisher "github.com/gunawanpras/be-product-service/internal/adapter/publisher/nats"
+	"github.com/gunawanpras/be-product-service/internal/core/outbox/port"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+)
+
+type Publisher struct {
+	EventPublisher port.EventPublisher
+}
+
+// NewPublisher publishes the product events to NATS, or keeps them in memory when the
+// outbox config asks for the memory publisher.
+func NewPublisher(conf *config.Config, externalService *ExternalServices) Publisher {
+	if conf.Outbox.Publisher == constant.OutboxPublisherMemory {
+		return Publisher{
+			EventPublisher: memory.NewEventPublisher(),
+		}
+	}
+
+	return Publisher{
+		EventPublisher: natsPublisher.NewEventPublisher(natsPublisher.InitAttribute{
+			Client: natsPublisher.Client{
+				JetStream: externalService.JetStream,
+			},
+			Config: conf,
+		}),
+	}
+}
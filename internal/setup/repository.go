@@ -1,13 +1,28 @@
 package setup
 
 import (
+	categoryRepoPg "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/category"
+	outboxRepoPg "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/outbox"
 	productRepoPg "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/product"
+	supplierRepoPg "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/supplier"
+	unitRepoPg "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/unit"
+	webhookRepoPg "github.com/gunawanpras/be-product-service/internal/adapter/repository/postgres/webhook"
+	categoryRepo "github.com/gunawanpras/be-product-service/internal/core/category/port"
+	outboxRepo "github.com/gunawanpras/be-product-service/internal/core/outbox/port"
 	productRepo "github.com/gunawanpras/be-product-service/internal/core/product/port"
+	supplierRepo "github.com/gunawanpras/be-product-service/internal/core/supplier/port"
+	unitRepo "github.com/gunawanpras/be-product-service/internal/core/unit/port"
+	webhookRepo "github.com/gunawanpras/be-product-service/internal/core/webhook/port"
 	"github.com/jmoiron/sqlx"
 )
 
 type Repository struct {
-	ProductRepo productRepo.Repository
+	ProductRepo  productRepo.Repository
+	CategoryRepo categoryRepo.Repository
+	SupplierRepo supplierRepo.Repository
+	UnitRepo     unitRepo.Repository
+	OutboxRepo   outboxRepo.Repository
+	WebhookRepo  webhookRepo.Repository
 }
 
 func NewRepository(db *sqlx.DB) Repository {
@@ -17,7 +32,42 @@ func NewRepository(db *sqlx.DB) Repository {
 		},
 	})
 
+	categoryRepo := categoryRepoPg.New(categoryRepoPg.InitAttribute{
+		DB: categoryRepoPg.DB{
+			Db: db,
+		},
+	})
+
+	supplierRepo := supplierRepoPg.New(supplierRepoPg.InitAttribute{
+		DB: supplierRepoPg.DB{
+			Db: db,
+		},
+	})
+
+	unitRepo := unitRepoPg.New(unitRepoPg.InitAttribute{
+		DB: unitRepoPg.DB{
+			Db: db,
+		},
+	})
+
+	outboxRepo := outboxRepoPg.New(outboxRepoPg.InitAttribute{
+		DB: outboxRepoPg.DB{
+			Db: db,
+		},
+	})
+
+	webhookRepo := webhookRepoPg.New(webhookRepoPg.InitAttribute{
+		DB: webhookRepoPg.DB{
+			Db: db,
+		},
+	})
+
 	return Repository{
-		ProductRepo: productRepo,
+		ProductRepo:  productRepo,
+		CategoryRepo: categoryRepo,
+		SupplierRepo: supplierRepo,
+		UnitRepo:     unitRepo,
+		OutboxRepo:   outboxRepo,
+		WebhookRepo:  webhookRepo,
 	}
 }
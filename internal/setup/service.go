@@ -1,16 +1,57 @@
 package setup
 
 import (
+	"net/http"
+
 	"github.com/gunawanpras/be-product-service/config"
+	"github.com/gunawanpras/be-product-service/internal/adapter/publisher/multi"
+	webhookPublisher "github.com/gunawanpras/be-product-service/internal/adapter/publisher/webhook"
+	categoryPort "github.com/gunawanpras/be-product-service/internal/core/category/port"
+	categoryService "github.com/gunawanpras/be-product-service/internal/core/category/service"
+	outboxPort "github.com/gunawanpras/be-product-service/internal/core/outbox/port"
+	outboxService "github.com/gunawanpras/be-product-service/internal/core/outbox/service"
 	productPort "github.com/gunawanpras/be-product-service/internal/core/product/port"
 	productService "github.com/gunawanpras/be-product-service/internal/core/product/service"
+	supplierPort "github.com/gunawanpras/be-product-service/internal/core/supplier/port"
+	supplierService "github.com/gunawanpras/be-product-service/internal/core/supplier/service"
+	unitPort "github.com/gunawanpras/be-product-service/internal/core/unit/port"
+	unitService "github.com/gunawanpras/be-product-service/internal/core/unit/service"
+	webhookPort "github.com/gunawanpras/be-product-service/internal/core/webhook/port"
+	webhookService "github.com/gunawanpras/be-product-service/internal/core/webhook/service"
 )
 
 type Service struct {
-	ProductService productPort.Service
+	ProductService  productPort.Service
+	CategoryService categoryPort.Service
+	SupplierService supplierPort.Service
+	UnitService     unitPort.Service
+	OutboxService   outboxPort.Service
+	WebhookService  webhookPort.Service
 }
 
-func NewService(conf *config.Config, repo Repository, cache Cache) Service {
+func NewService(conf *config.Config, repo Repository, cache Cache, publisher Publisher, httpClient *http.Client) Service {
+	webhookSvc := webhookService.New(webhookService.InitAttribute{
+		Repo: webhookService.RepoAttribute{
+			WebhookRepo: repo.WebhookRepo,
+		},
+		Client: webhookService.ClientAttribute{
+			HTTPClient: httpClient,
+		},
+		Config: webhookService.ConfigAttribute{
+			Config: conf,
+		},
+	})
+
+	// the outbox publishes each event to the broker, then queues it for the webhooks
+	eventPublisher := multi.NewEventPublisher(
+		publisher.EventPublisher,
+		webhookPublisher.NewEventPublisher(webhookPublisher.InitAttribute{
+			Service: webhookPublisher.ServiceAttribute{
+				WebhookService: webhookSvc,
+			},
+		}),
+	)
+
 	return Service{
 		ProductService: productService.New(productService.InitAttribute{
 			Repo: productService.RepoAttribute{
@@ -23,5 +64,41 @@ func NewService(conf *config.Config, repo Repository, cache Cache) Service {
 				Config: conf,
 			},
 		}),
+		CategoryService: categoryService.New(categoryService.InitAttribute{
+			Repo: categoryService.RepoAttribute{
+				CategoryRepo: repo.CategoryRepo,
+			},
+			Config: categoryService.ConfigAttribute{
+				Config: conf,
+			},
+		}),
+		SupplierService: supplierService.New(supplierService.InitAttribute{
+			Repo: supplierService.RepoAttribute{
+				SupplierRepo: repo.SupplierRepo,
+			},
+			Config: supplierService.ConfigAttribute{
+				Config: conf,
+			},
+		}),
+		UnitService: unitService.New(unitService.InitAttribute{
+			Repo: unitService.RepoAttribute{
+				UnitRepo: repo.UnitRepo,
+			},
+			Config: unitService.ConfigAttribute{
+				Config: conf,
+			},
+		}),
+		OutboxService: outboxService.New(outboxService.InitAttribute{
+			Repo: outboxService.RepoAttribute{
+				OutboxRepo: repo.OutboxRepo,
+			},
+			Publisher: outboxService.PublisherAttribute{
+				EventPublisher: eventPublisher,
+			},
+			Config: outboxService.ConfigAttribute{
+				Config: conf,
+			},
+		}),
+		WebhookService: webhookSvc,
 	}
 }
@@ -1,38 +1,66 @@
 package setup
 
 import (
+	"net/http"
+
 	"github.com/go-redis/cache/v8"
+	"github.com/go-redis/redis/v8"
 	"github.com/gunawanpras/be-product-service/config"
+	outboxPort "github.com/gunawanpras/be-product-service/internal/core/outbox/port"
+	webhookPort "github.com/gunawanpras/be-product-service/internal/core/webhook/port"
 	setupClient "github.com/gunawanpras/be-product-service/internal/setup/client"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
 	"github.com/jmoiron/sqlx"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 )
 
 type ExternalServices struct {
-	Postgres *sqlx.DB
-	Redis    *cache.Cache
+	Postgres    *sqlx.DB
+	Redis       *cache.Cache
+	RedisClient *redis.Client
+	Nats        *nats.Conn
+	JetStream   jetstream.JetStream
+	HTTPClient  *http.Client
 }
 
 type CoreServices struct {
-	Handler Handler
+	Handler  Handler
+	Importer Importer
+	Outbox   outboxPort.Service
+	Webhook  webhookPort.Service
 }
 
 func InitExternalServices(conf *config.Config) *ExternalServices {
 	pg := setupClient.InitPostgres(conf)
-	rd := setupClient.InitRedis(conf)
+	redisClient, rd := setupClient.InitRedis(conf)
+
+	externalService := &ExternalServices{
+		Postgres:    pg,
+		Redis:       rd,
+		RedisClient: redisClient,
+		HTTPClient:  setupClient.InitHTTPClient(conf),
+	}
 
-	return &ExternalServices{
-		Postgres: pg,
-		Redis:    rd,
+	if conf.Outbox.Publisher != constant.OutboxPublisherMemory {
+		externalService.Nats, externalService.JetStream = setupClient.InitNats(conf)
 	}
+
+	return externalService
 }
 
 func InitCoreServices(conf *config.Config, externalService *ExternalServices) *CoreServices {
-	cache := NewCache(conf, externalService.Redis)
+	cache := NewCache(conf, externalService.Redis, externalService.RedisClient)
 	repo := NewRepository(externalService.Postgres)
-	service := NewService(conf, repo, cache)
-	handler := NewHandler(service)
+	publisher := NewPublisher(conf, externalService)
+	service := NewService(conf, repo, cache, publisher, externalService.HTTPClient)
+	importer := NewImporter(conf, service)
+	handler := NewHandler(conf, service, importer, cache)
 
 	return &CoreServices{
-		Handler: *handler,
+		Handler:  *handler,
+		Importer: importer,
+		Outbox:   service.OutboxService,
+		Webhook:  service.WebhookService,
 	}
 }
@@ -0,0 +1,164 @@
+package apperror
+
+import "errors"
+
+// Kind classifies an error by what went wrong rather than by its message, so callers
+// can branch on it and the delivery layer can pick a status code without parsing text.
+type Kind uint8
+
+const (
+	KindInternal Kind = iota
+	KindValidation
+	KindNotFound
+	KindConflict
+	KindPreconditionFailed
+	KindUnprocessable
+	KindForbidden
+)
+
+var kindCodes = map[Kind]string{
+	KindInternal:           "internal_error",
+	KindValidation:         "validation_failed",
+	KindNotFound:           "not_found",
+	KindConflict:           "conflict",
+	KindPreconditionFailed: "precondition_failed",
+	KindUnprocessable:      "unprocessable_entity",
+	KindForbidden:          "forbidden",
+}
+
+// String returns the generic error code of the kind, used when an error has no code of its own.
+func (k Kind) String() string {
+	if code, ok := kindCodes[k]; ok {
+		return code
+	}
+
+	return kindCodes[KindInternal]
+}
+
+// Sentinels matching any error of their kind, e.g. errors.Is(err, apperror.ErrNotFound).
+var (
+	ErrInternal           error = &Error{Kind: KindInternal}
+	ErrValidation         error = &Error{Kind: KindValidation}
+	ErrNotFound           error = &Error{Kind: KindNotFound}
+	ErrConflict           error = &Error{Kind: KindConflict}
+	ErrPreconditionFailed error = &Error{Kind: KindPreconditionFailed}
+	ErrUnprocessable      error = &Error{Kind: KindUnprocessable}
+	ErrForbidden          error = &Error{Kind: KindForbidden}
+)
+
+// Error is an error carrying a Kind, a stable machine-readable Code, a message and
+// optionally the error that caused it.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Message == "" && e.Err == nil:
+		return e.Kind.String()
+	case e.Message == "":
+		return e.Err.Error()
+	case e.Err == nil:
+		return e.Message
+	default:
+		return e.Message + ": " + e.Err.Error()
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the same error, or a message-less sentinel of the same kind.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+
+	if e == t {
+		return true
+	}
+
+	return t.Code == "" && t.Message == "" && t.Err == nil && t.Kind == e.Kind
+}
+
+// New creates an error of the given kind. code identifies the error for clients and must
+// not change once published.
+func New(kind Kind, code, message string) error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Wrap attaches a kind to err, keeping err in the chain for errors.Is and errors.As.
+// It returns nil when err is nil.
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{Kind: kind, Err: err}
+}
+
+// NotFound creates an error for a resource that does not exist.
+func NotFound(code, message string) error {
+	return New(KindNotFound, code, message)
+}
+
+// Conflict creates an error for a request clashing with the current state of a resource.
+func Conflict(code, message string) error {
+	return New(KindConflict, code, message)
+}
+
+// Validation creates an error for malformed or invalid input.
+func Validation(code, message string) error {
+	return New(KindValidation, code, message)
+}
+
+// Internal creates an error for a failure the client cannot do anything about.
+func Internal(code, message string) error {
+	return New(KindInternal, code, message)
+}
+
+// PreconditionFailed creates an error for a request whose precondition no longer holds.
+func PreconditionFailed(code, message string) error {
+	return New(KindPreconditionFailed, code, message)
+}
+
+// Unprocessable creates an error for well-formed input that breaks a business rule.
+func Unprocessable(code, message string) error {
+	return New(KindUnprocessable, code, message)
+}
+
+// Forbidden creates an error for a caller lacking the required permission.
+func Forbidden(code, message string) error {
+	return New(KindForbidden, code, message)
+}
+
+// KindOf returns the kind of the first *Error in the chain of err. Errors without a
+// kind are treated as internal.
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+
+	return KindInternal
+}
+
+// CodeOf returns the code of the first *Error in the chain of err, falling back to the
+// generic code of its kind.
+func CodeOf(err error) string {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		if appErr.Code != "" {
+			return appErr.Code
+		}
+
+		return appErr.Kind.String()
+	}
+
+	return KindInternal.String()
+}
@@ -0,0 +1,138 @@
+package apperror_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Is(t *testing.T) {
+	errProductNotFound := apperror.NotFound("product_not_found", "product not found")
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "same kind sentinel",
+			err:    errProductNotFound,
+			target: apperror.ErrNotFound,
+			want:   true,
+		},
+		{
+			name:   "wrapped with fmt.Errorf",
+			err:    fmt.Errorf("get product: %w", errProductNotFound),
+			target: apperror.ErrNotFound,
+			want:   true,
+		},
+		{
+			name:   "same value",
+			err:    fmt.Errorf("get product: %w", errProductNotFound),
+			target: errProductNotFound,
+			want:   true,
+		},
+		{
+			name:   "other kind sentinel",
+			err:    errProductNotFound,
+			target: apperror.ErrConflict,
+			want:   false,
+		},
+		{
+			name:   "other error of the same kind",
+			err:    apperror.NotFound("category_not_found", "category not found"),
+			target: errProductNotFound,
+			want:   false,
+		},
+		{
+			name:   "wrapped cause",
+			err:    apperror.Wrap(apperror.KindValidation, errProductNotFound),
+			target: errProductNotFound,
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.Is(tt.err, tt.target))
+		})
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want apperror.Kind
+	}{
+		{
+			name: "typed error",
+			err:  apperror.Conflict("already_exist", "already exist"),
+			want: apperror.KindConflict,
+		},
+		{
+			name: "wrapped typed error",
+			err:  fmt.Errorf("create product: %w", apperror.PreconditionFailed("version_mismatch", "version mismatch")),
+			want: apperror.KindPreconditionFailed,
+		},
+		{
+			name: "outermost kind wins",
+			err:  apperror.Wrap(apperror.KindValidation, apperror.Internal("boom", "boom")),
+			want: apperror.KindValidation,
+		},
+		{
+			name: "plain error",
+			err:  errors.New("boom"),
+			want: apperror.KindInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, apperror.KindOf(tt.err))
+		})
+	}
+}
+
+func TestError_Error(t *testing.T) {
+	cause := errors.New("connection refused")
+
+	assert.Equal(t, "data not found", apperror.NotFound("data_not_found", "data not found").Error())
+	assert.Equal(t, "connection refused", apperror.Wrap(apperror.KindInternal, cause).Error())
+	assert.Equal(t, "not_found", apperror.ErrNotFound.Error())
+	assert.Nil(t, apperror.Wrap(apperror.KindInternal, nil))
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "own code",
+			err:  fmt.Errorf("get product: %w", apperror.NotFound("product_not_found", "product not found")),
+			want: "product_not_found",
+		},
+		{
+			name: "kind code",
+			err:  apperror.Wrap(apperror.KindValidation, errors.New("bad input")),
+			want: "validation_failed",
+		},
+		{
+			name: "plain error",
+			err:  errors.New("boom"),
+			want: "internal_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, apperror.CodeOf(tt.err))
+		})
+	}
+}
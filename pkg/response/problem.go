@@ -0,0 +1,81 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/validator"
+)
+
+// Problem is an RFC 7807 problem details object. Code is a stable identifier of the
+// error, and Errors lists the request fields that failed validation.
+type Problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Code     string         `json:"code"`
+	Errors   []ProblemField `json:"errors,omitempty"`
+}
+
+// ProblemField describes a request field that failed validation. Field uses the name of
+// the field in the request, Code the failed rule and Param the argument of that rule.
+type ProblemField struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// wantsProblem tells whether the client prefers problem details over the default
+// response envelope.
+func wantsProblem(ctx *fiber.Ctx) bool {
+	return ctx.Accepts(fiber.MIMEApplicationJSON, constant.MIMEApplicationProblemJSON) == constant.MIMEApplicationProblemJSON
+}
+
+// newProblem creates a problem for the given status and code. The type is a URI
+// reference derived from the code so that it stays stable along with it.
+func newProblem(ctx *fiber.Ctx, status int, code, detail string) Problem {
+	return Problem{
+		Type:     constant.ProblemTypeBaseURI + code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: ctx.OriginalURL(),
+		Code:     code,
+	}
+}
+
+func writeProblem(ctx *fiber.Ctx, problem Problem) error {
+	return ctx.Status(problem.Status).JSON(problem, constant.MIMEApplicationProblemJSON)
+}
+
+func errorProblem(ctx *fiber.Ctx, message string, err error) error {
+	problem := newProblem(ctx, StatusCode(err), apperror.CodeOf(err), message+": "+err.Error())
+
+	return writeProblem(ctx, problem)
+}
+
+func validatorProblem(ctx *fiber.Ctx, errs []*validator.ErrorResponse) error {
+	problem := newProblem(ctx, http.StatusBadRequest, apperror.KindValidation.String(), constant.RequestValidationFailed)
+
+	for _, err := range errs {
+		rule := err.Tag
+		if err.Value != "" {
+			rule += "=" + err.Value
+		}
+
+		problem.Errors = append(problem.Errors, ProblemField{
+			Field:   err.Field,
+			Code:    err.Tag,
+			Param:   err.Value,
+			Message: fmt.Sprintf("%s failed on the %s rule", err.Field, rule),
+		})
+	}
+
+	return writeProblem(ctx, problem)
+}
@@ -1,18 +1,61 @@
 package response
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
 	"github.com/gunawanpras/be-product-service/pkg/util/constant"
 	"github.com/gunawanpras/be-product-service/pkg/validator"
 )
 
+var kindStatusMappings = map[apperror.Kind]int{
+	apperror.KindInternal:           http.StatusInternalServerError,
+	apperror.KindValidation:         http.StatusBadRequest,
+	apperror.KindNotFound:           http.StatusNotFound,
+	apperror.KindConflict:           http.StatusConflict,
+	apperror.KindPreconditionFailed: http.StatusPreconditionFailed,
+	apperror.KindUnprocessable:      http.StatusUnprocessableEntity,
+	apperror.KindForbidden:          http.StatusForbidden,
+}
+
 type Response struct {
 	Status  string `json:"status"`
 	Message string `json:"message"`
 	Data    any    `json:"data"`
+	Meta    any    `json:"meta,omitempty"`
+}
+
+// PageMeta describes a numbered page of a list.
+type PageMeta struct {
+	Total      int `json:"total"`
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	TotalPages int `json:"total_pages"`
+}
+
+// NewPageMeta describes the given page of a list holding total items.
+func NewPageMeta(total, page, perPage int) PageMeta {
+	var totalPages int
+	if perPage > 0 {
+		totalPages = (total + perPage - 1) / perPage
+	}
+
+	return PageMeta{
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: totalPages,
+	}
+}
+
+// CursorMeta tells a client how to continue a cursor-paginated list. NextCursor is null
+// on the last page.
+type CursorMeta struct {
+	NextCursor *string `json:"next_cursor"`
+	HasMore    bool    `json:"has_more"`
 }
 
 type ValidatorResponse struct {
@@ -21,6 +64,12 @@ type ValidatorResponse struct {
 }
 
 func OK(ctx *fiber.Ctx, message string, data any, statusMappings map[string]int) error {
+	return OKWithMeta(ctx, message, data, nil, statusMappings)
+}
+
+// OKWithMeta writes a successful response carrying metadata about data, such as the
+// pagination of a list, next to it.
+func OKWithMeta(ctx *fiber.Ctx, message string, data, meta any, statusMappings map[string]int) error {
 	var code int
 
 	if c, ok := statusMappings[message]; ok {
@@ -31,18 +80,18 @@ func OK(ctx *fiber.Ctx, message string, data any, statusMappings map[string]int)
 		Status:  constant.SUCCESS,
 		Message: message,
 		Data:    data,
+		Meta:    meta,
 	}
 
 	return ctx.Status(code).JSON(response)
 }
 
-func Error(ctx *fiber.Ctx, message string, err error, statusMappings map[string]int) error {
-	var code = http.StatusNotImplemented
-
-	if c, ok := statusMappings[err.Error()]; ok {
-		code = c
-	} else if c, ok := statusMappings[message]; ok {
-		code = c
+// Error writes an error response. The status code is derived from the kind of err, see
+// apperror.Kind, so wrapped errors keep their status. Fiber errors keep their own code.
+// Clients accepting application/problem+json get RFC 7807 problem details instead.
+func Error(ctx *fiber.Ctx, message string, err error) error {
+	if wantsProblem(ctx) {
+		return errorProblem(ctx, message, err)
 	}
 
 	response := Response{
@@ -50,10 +99,30 @@ func Error(ctx *fiber.Ctx, message string, err error, statusMappings map[string]
 		Message: fmt.Sprintf("%s. reason: %v", message, err.Error()),
 	}
 
-	return ctx.Status(code).JSON(response)
+	return ctx.Status(StatusCode(err)).JSON(response)
+}
+
+// StatusCode maps err to the HTTP status code matching its kind.
+func StatusCode(err error) int {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+
+	if code, ok := kindStatusMappings[apperror.KindOf(err)]; ok {
+		return code
+	}
+
+	return http.StatusInternalServerError
 }
 
+// ErrorValidator writes the failed validations of a request. Clients accepting
+// application/problem+json get them keyed by request field name.
 func ErrorValidator(ctx *fiber.Ctx, err []*validator.ErrorResponse) error {
+	if wantsProblem(ctx) {
+		return validatorProblem(ctx, err)
+	}
+
 	var code = http.StatusBadRequest
 
 	response := ValidatorResponse{
@@ -0,0 +1,147 @@
+package response_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/response"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type contactRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type sortRequest struct {
+	Sort string `query:"sort" validate:"omitempty,oneof=name"`
+}
+
+type createRequest struct {
+	ID      string         `json:"-" uri:"id" validate:"required"`
+	Name    string         `json:"name" validate:"required,min=3"`
+	Contact contactRequest `json:"contact_info"`
+	sortRequest
+}
+
+func newTestApp() *fiber.App {
+	app := fiber.New()
+
+	app.Get("/products/:id", func(c *fiber.Ctx) error {
+		err := fmt.Errorf("get product: %w", apperror.NotFound(constant.CodeProductNotFound, constant.ProductNotFound))
+		return response.Error(c, constant.ProductGetFailed, err)
+	})
+
+	app.Get("/internal", func(c *fiber.Ctx) error {
+		return response.Error(c, constant.ProductGetFailed, errors.New("connection refused"))
+	})
+
+	app.Post("/products", func(c *fiber.Ctx) error {
+		return response.ErrorValidator(c, validator.Validate(createRequest{Name: "ab", Contact: contactRequest{Email: "nope"}, sortRequest: sortRequest{Sort: "price"}}))
+	})
+
+	return app
+}
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		accept          string
+		wantStatus      int
+		wantContentType string
+		wantBody        map[string]any
+	}{
+		{
+			name:            "default envelope",
+			path:            "/products/1",
+			wantStatus:      http.StatusNotFound,
+			wantContentType: fiber.MIMEApplicationJSON,
+			wantBody: map[string]any{
+				"status":  constant.ERROR,
+				"message": "failed to fetch product. reason: get product: product not found",
+				"data":    nil,
+			},
+		},
+		{
+			name:            "problem details",
+			path:            "/products/1",
+			accept:          constant.MIMEApplicationProblemJSON,
+			wantStatus:      http.StatusNotFound,
+			wantContentType: constant.MIMEApplicationProblemJSON,
+			wantBody: map[string]any{
+				"type":     "/problems/product_not_found",
+				"title":    "Not Found",
+				"status":   float64(http.StatusNotFound),
+				"detail":   "failed to fetch product: get product: product not found",
+				"instance": "/products/1",
+				"code":     constant.CodeProductNotFound,
+			},
+		},
+		{
+			name:            "untyped error is internal",
+			path:            "/internal",
+			accept:          constant.MIMEApplicationProblemJSON + ", application/json;q=0.5",
+			wantStatus:      http.StatusInternalServerError,
+			wantContentType: constant.MIMEApplicationProblemJSON,
+			wantBody: map[string]any{
+				"type":     "/problems/internal_error",
+				"title":    "Internal Server Error",
+				"status":   float64(http.StatusInternalServerError),
+				"detail":   "failed to fetch product: connection refused",
+				"instance": "/internal",
+				"code":     "internal_error",
+			},
+		},
+	}
+
+	app := newTestApp()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set(fiber.HeaderAccept, tt.accept)
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Contains(t, resp.Header.Get(fiber.HeaderContentType), tt.wantContentType)
+
+			body, _ := io.ReadAll(resp.Body)
+			var got map[string]any
+			assert.NoError(t, json.Unmarshal(body, &got))
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestErrorValidator_Problem(t *testing.T) {
+	app := newTestApp()
+
+	req := httptest.NewRequest(http.MethodPost, "/products", nil)
+	req.Header.Set(fiber.HeaderAccept, constant.MIMEApplicationProblemJSON)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var got response.Problem
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	assert.Equal(t, "validation_failed", got.Code)
+	assert.Equal(t, []response.ProblemField{
+		{Field: "id", Code: "required", Message: "id failed on the required rule"},
+		{Field: "name", Code: "min", Param: "3", Message: "name failed on the min=3 rule"},
+		{Field: "contact_info.email", Code: "email", Message: "contact_info.email failed on the email rule"},
+		{Field: "sort", Code: "oneof", Param: "name", Message: "sort failed on the oneof=name rule"},
+	}, got.Errors)
+}
@@ -13,31 +13,321 @@ const (
 	SYSTEM = "SYSTEM"
 )
 
+const (
+	ProductInitialVersion = 1
+
+	// pagination of the product list, the max limit applies when the server config
+	// does not set a max page size
+	ProductListDefaultLimit = 20
+	ProductListMaxLimit     = 100
+
+	// pagination of the stock history of a product, the per page of the product list
+	// applies when the server config does not set a max page size
+	StockHistoryDefaultLimit = 20
+
+	// number of product name suggestions when the request does not set a limit
+	ProductSuggestDefaultLimit = 10
+
+	// number of products a batch get may ask for when the server config does not set
+	// a max batch size
+	ProductBatchMaxSize = 100
+
+	// number of products a bulk creation may hold when the server config does not set
+	// a max bulk size
+	ProductBulkMaxSize = 1000
+
+	// number of rows of an import file created at once when the server config does not
+	// set an import chunk size
+	ProductImportChunkSize = 500
+
+	// number of products held in memory by a parquet export before they are written out
+	ProductExportRowGroupSize = 10000
+
+	// relay of the outbox when the outbox config does not set its own, the delays are
+	// in milliseconds
+	OutboxPollInterval   = 1000
+	OutboxBatchSize      = 100
+	OutboxRetryBaseDelay = 1000
+	OutboxRetryMaxDelay  = 5 * 60 * 1000
+
+	// key of the postgres advisory lock held by the instance relaying the outbox
+	OutboxRelayLockKey = 72001
+
+	// seconds a nats publisher waits for the stream to acknowledge an event when the
+	// nats config does not set a publish timeout
+	NatsPublishTimeout = 5
+
+	// dispatch of the webhook deliveries when the webhook config does not set its own,
+	// the poll interval is in milliseconds, the timeout and delays in seconds
+	WebhookPollInterval   = 1000
+	WebhookBatchSize      = 50
+	WebhookTimeout        = 10
+	WebhookMaxAttempts    = 8
+	WebhookRetryBaseDelay = 30
+	WebhookRetryMaxDelay  = 60 * 60
+
+	// number of deliveries of a webhook listed when the request does not set a limit
+	WebhookDeliveryDefaultLimit = 20
+
+	// bytes of a generated webhook secret, and of the response body of a failed delivery
+	// kept as its last error
+	WebhookSecretSize    = 32
+	WebhookResponseLimit = 4 << 10
+)
+
 const (
 	// sort
 	ProductSortCreatedAt   = "created_at"
 	ProductSortBasePrice   = "base_price"
 	ProductSortProductName = "name"
+	ProductSortStock       = "stock"
+	ProductSortUpdatedAt   = "updated_at"
+	ProductSortRelevance   = "relevance"
 
 	// sort and direction
 	SortDirectionAsc        = "asc"
 	SortDirectionDesc       = "desc"
 	ErrInvalidSortDirection = "invalid sort direction argument"
 	ErrInvalidSort          = "invalid sort argument"
+
+	// relations embedded in a product response
+	ProductIncludeCategory = "category"
+	ProductIncludeSupplier = "supplier"
+	ProductIncludeUnit     = "unit"
+	ProductIncludeDiscount = "discount"
+
+	// status of a product of a bulk creation
+	ProductBulkCreated   = "created"
+	ProductBulkInvalid   = "invalid"
+	ProductBulkDuplicate = "duplicate"
+	ProductBulkSkipped   = "skipped"
+	ProductBulkValid     = "valid"
+	ProductBulkFailed    = "failed"
+
+	// types of the events of a product change
+	ProductEventCreated  = "product.created"
+	ProductEventUpdated  = "product.updated"
+	ProductEventDeleted  = "product.deleted"
+	ProductEventRestored = "product.restored"
+	ProductEventPurged   = "product.purged"
+	StockEventChanged    = "stock.changed"
+
+	// types of a stock movement, receipts and returns add to the stock, sales take from
+	// it, adjustments and transfers go either way
+	StockMovementReceipt    = "receipt"
+	StockMovementSale       = "sale"
+	StockMovementAdjustment = "adjustment"
+	StockMovementReturn     = "return"
+	StockMovementTransfer   = "transfer"
+
+	// reasons of the stock movements recorded on behalf of a product write
+	StockMovementReasonInitialStock  = "initial stock"
+	StockMovementReasonProductUpdate = "stock set by product update"
+
+	// status of a webhook delivery, a dead delivery ran out of attempts
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+
+	// publishers of the outbox events
+	OutboxPublisherNats   = "nats"
+	OutboxPublisherMemory = "memory"
+
+	// formats of a product export
+	ProductExportFormatCSV     = "csv"
+	ProductExportFormatNDJSON  = "ndjson"
+	ProductExportFormatParquet = "parquet"
+
+	// columns of a product import file
+	ProductImportColumnCategory    = "category"
+	ProductImportColumnSupplier    = "supplier"
+	ProductImportColumnUnit        = "unit"
+	ProductImportColumnName        = "name"
+	ProductImportColumnDescription = "description"
+	ProductImportColumnBasePrice   = "base_price"
+	ProductImportColumnStock       = "stock"
+
+	// nulls placement
+	SortNullsFirst      = "first"
+	SortNullsLast       = "last"
+	ErrInvalidSortNulls = "invalid sort nulls argument"
 )
 
 const (
 	BindingParameterFailed = "failed to bind parameter"
 	InvalidUUID            = "invalid uuid"
+	AdminAccessRequired    = "admin access required"
+	InvalidAdminToken      = "missing or invalid admin token"
+	InvalidIfMatchHeader   = "invalid If-Match header"
+	RequestBodyTooLarge    = "request body too large"
+)
+
+const (
+	MIMEApplicationProblemJSON = "application/problem+json"
+	ProblemTypeBaseURI         = "/problems/"
+	RequestValidationFailed    = "request validation failed"
+
+	MIMETextCSV                 = "text/csv"
+	MIMEApplicationNDJSON       = "application/x-ndjson"
+	MIMEApplicationParquet      = "application/vnd.apache.parquet"
+	ProductImportReportFilename = "product-import-report.csv"
+	ProductExportFilename       = "products"
+)
+
+const (
+	HeaderAdminToken         = "X-Admin-Token"
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	// headers of a published product event
+	HeaderEventType     = "Event-Type"
+	HeaderEventSequence = "Event-Sequence"
+	HeaderProductID     = "Product-Id"
+
+	// headers of a webhook delivery
+	HeaderWebhookID        = "X-Webhook-Id"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+
+	// prefix of the signature of a webhook delivery, naming its algorithm
+	WebhookSignaturePrefix = "sha256="
+)
+
+const (
+	IdempotencyCheckFailed       = "failed to check idempotency key"
+	IdempotencyKeyTooLong        = "idempotency key must not exceed 255 characters"
+	IdempotencyKeyReused         = "idempotency key was already used with a different payload"
+	IdempotencyRequestInProgress = "a request with the same idempotency key is still in progress"
+)
+
+const (
+	ProductCreateSuccess  = "product created successfully"
+	ProductCreateFailed   = "failed to create product"
+	ProductGetSuccess     = "product fetched successfully"
+	ProductGetFailed      = "failed to fetch product"
+	ProductNotFound       = "product not found"
+	ProductAlreadyExist   = "product already exist"
+	ProductUpdateSuccess  = "product updated successfully"
+	ProductUpdateFailed   = "failed to update product"
+	ProductDeleteSuccess  = "product deleted successfully"
+	ProductDeleteFailed   = "failed to delete product"
+	ProductRestoreSuccess = "product restored successfully"
+	ProductRestoreFailed  = "failed to restore product"
+	ProductPurgeSuccess   = "product purged successfully"
+	ProductPurgeFailed    = "failed to purge product"
+	ProductSuggestSuccess = "product suggestions fetched successfully"
+	ProductSuggestFailed  = "failed to fetch product suggestions"
+	ProductFacetsSuccess  = "product facets fetched successfully"
+	ProductFacetsFailed   = "failed to fetch product facets"
+
+	ProductBatchGetSuccess = "product batch fetched successfully"
+	ProductBatchGetFailed  = "failed to fetch product batch"
+
+	ProductBulkCreateSuccess  = "products created successfully"
+	ProductBulkCreatePartial  = "some products could not be created"
+	ProductBulkCreateRejected = "no product created, some products could not be created"
+	ProductBulkCreateFailed   = "failed to create products"
+
+	ProductImportSuccess   = "products imported successfully"
+	ProductImportValidated = "products validated successfully"
+	ProductImportPartial   = "some rows could not be imported"
+	ProductImportFailed    = "failed to import products"
+
+	ProductExportFailed = "failed to export products"
+
+	ProductPreconditionFailed = "product has been modified by another request"
+	ProductInvalidCursor      = "cursor is malformed or does not match the requested sort"
+	ProductPageSizeTooLarge   = "page size exceeds the maximum page size"
+	ProductInvalidFilterRange = "filter range lower bound must not exceed its upper bound"
+	ProductSortRequiresQuery  = "sorting by relevance requires a search query"
+	ProductInvalidFields      = "fields lists an unknown product field"
+	ProductInvalidInclude     = "include lists an unknown product relation"
+	ProductBatchTooLarge      = "batch exceeds the maximum number of products"
+	ProductBulkTooLarge       = "bulk exceeds the maximum number of products"
+	ProductBulkItemInvalid    = "product failed validation"
+	ProductBulkAborted        = "product skipped, another product of the atomic bulk could not be created"
+	ProductReferenceNotFound  = "category, supplier or unit of the product does not exist"
+
+	ProductImportUnsupportedFormat = "import file must be a .csv or .xlsx file"
+	ProductImportInvalidHeader     = "import file must start with a header row naming the category, supplier, unit, name, base_price and stock columns"
+	ProductImportMalformed         = "import file could not be read"
+	ProductImportChunkFailed       = "product not created, the chunk of the file holding it could not be created"
+
+	ProductDiscountCreateSuccess = "product discount created successfully"
+	ProductDiscountCreateFailed  = "failed to create product discount"
+	ProductDiscountUpdateSuccess = "product discount updated successfully"
+	ProductDiscountUpdateFailed  = "failed to update product discount"
+	ProductDiscountDeleteSuccess = "product discount deleted successfully"
+	ProductDiscountDeleteFailed  = "failed to delete product discount"
+	ProductDiscountNotFound      = "product discount not found"
+	ProductDiscountAlreadyExist  = "product discount already exist"
+	ProductDiscountInvalidPeriod = "discount end date must not be before its start date"
+
+	StockMovementCreateSuccess   = "stock movement recorded successfully"
+	StockMovementCreateFailed    = "failed to record stock movement"
+	StockMovementAlreadyExist    = "stock movement with the same type and reference already exist"
+	StockMovementInvalidQuantity = "quantity of a receipt, sale or return must be positive, and of another movement must not be zero"
+	StockHistoryGetSuccess       = "stock history fetched successfully"
+	StockHistoryGetFailed        = "failed to fetch stock history"
+	ProductInsufficientStock     = "product stock is insufficient for the movement"
 )
 
 const (
-	ProductCreateSuccess = "product created successfully"
-	ProductCreateFailed  = "failed to create product"
-	ProductGetSuccess    = "product fetched successfully"
-	ProductGetFailed     = "failed to fetch product"
-	ProductNotFound      = "product not found"
-	ProductAlreadyExist  = "product already exist"
+	CategoryCreateSuccess = "category created successfully"
+	CategoryCreateFailed  = "failed to create category"
+	CategoryGetSuccess    = "category fetched successfully"
+	CategoryGetFailed     = "failed to fetch category"
+	CategoryUpdateSuccess = "category updated successfully"
+	CategoryUpdateFailed  = "failed to update category"
+	CategoryDeleteSuccess = "category deleted successfully"
+	CategoryDeleteFailed  = "failed to delete category"
+	CategoryNotFound      = "category not found"
+	CategoryAlreadyExist  = "category already exist"
+	CategoryInUse         = "category is still used by products"
+)
+
+const (
+	SupplierCreateSuccess = "supplier created successfully"
+	SupplierCreateFailed  = "failed to create supplier"
+	SupplierGetSuccess    = "supplier fetched successfully"
+	SupplierGetFailed     = "failed to fetch supplier"
+	SupplierUpdateSuccess = "supplier updated successfully"
+	SupplierUpdateFailed  = "failed to update supplier"
+	SupplierDeleteSuccess = "supplier deleted successfully"
+	SupplierDeleteFailed  = "failed to delete supplier"
+	SupplierNotFound      = "supplier not found"
+	SupplierAlreadyExist  = "supplier already exist"
+	SupplierInUse         = "supplier is still used by products"
+)
+
+const (
+	UnitCreateSuccess  = "unit created successfully"
+	UnitCreateFailed   = "failed to create unit"
+	UnitGetSuccess     = "unit fetched successfully"
+	UnitGetFailed      = "failed to fetch unit"
+	UnitUpdateSuccess  = "unit updated successfully"
+	UnitUpdateFailed   = "failed to update unit"
+	UnitDeleteSuccess  = "unit deleted successfully"
+	UnitDeleteFailed   = "failed to delete unit"
+	UnitNotFound       = "unit not found"
+	UnitAlreadyExist   = "unit already exist"
+	UnitInUse          = "unit is still used by products or units"
+	UnitInvalidBase    = "base unit must be an existing base unit of the same dimension"
+	UnitUnknown        = "unknown unit"
+	UnitNotConvertible = "units are not convertible"
+)
+
+const (
+	WebhookCreateSuccess      = "webhook created successfully"
+	WebhookCreateFailed       = "failed to create webhook"
+	WebhookGetSuccess         = "webhook fetched successfully"
+	WebhookGetFailed          = "failed to fetch webhook"
+	WebhookDeleteSuccess      = "webhook deleted successfully"
+	WebhookDeleteFailed       = "failed to delete webhook"
+	WebhookNotFound           = "webhook not found"
+	WebhookDeliveryGetSuccess = "webhook deliveries fetched successfully"
+	WebhookDeliveryGetFailed  = "failed to fetch webhook deliveries"
 )
 
 const (
@@ -45,31 +335,145 @@ const (
 	DbRollbackTransactionFailed = "failed to rollback transaction: %v"
 	DbCommitTransactionFailed   = "failed to commit transaction: %v"
 	DataNotFound                = "data not found"
+	DataStillReferenced         = "data is still referenced"
+	DataReferenceNotFound       = "data references missing data"
+	DataAlreadyExist            = "data already exist"
+	DataOutOfRange              = "data is out of the allowed range"
 	DbReturnedMalformedData     = "database returned malformed data"
 )
 
+// error codes, stable identifiers of the errors for API clients
+const (
+	CodeProductNotFound              = "product_not_found"
+	CodeProductAlreadyExist          = "product_already_exist"
+	CodeProductPreconditionFailed    = "product_precondition_failed"
+	CodeProductDiscountNotFound      = "product_discount_not_found"
+	CodeProductDiscountAlreadyExist  = "product_discount_already_exist"
+	CodeProductDiscountInvalidPeriod = "product_discount_invalid_period"
+	CodeProductInvalidCursor         = "product_invalid_cursor"
+	CodeProductPageSizeTooLarge      = "product_page_size_too_large"
+	CodeProductInvalidFilterRange    = "product_invalid_filter_range"
+	CodeProductSortRequiresQuery     = "product_sort_requires_query"
+	CodeProductInvalidFields         = "product_invalid_fields"
+	CodeProductInvalidInclude        = "product_invalid_include"
+	CodeProductBatchTooLarge         = "product_batch_too_large"
+	CodeProductBulkTooLarge          = "product_bulk_too_large"
+	CodeProductBulkItemInvalid       = "product_bulk_item_invalid"
+	CodeProductBulkAborted           = "product_bulk_aborted"
+	CodeProductReferenceNotFound     = "product_reference_not_found"
+	CodeProductInsufficientStock     = "product_insufficient_stock"
+
+	CodeStockMovementAlreadyExist    = "stock_movement_already_exist"
+	CodeStockMovementInvalidQuantity = "stock_movement_invalid_quantity"
+
+	CodeProductImportUnsupportedFormat = "product_import_unsupported_format"
+	CodeProductImportInvalidHeader     = "product_import_invalid_header"
+	CodeProductImportMalformed         = "product_import_malformed"
+	CodeProductImportChunkFailed       = "product_import_chunk_failed"
+
+	CodeCategoryNotFound     = "category_not_found"
+	CodeCategoryAlreadyExist = "category_already_exist"
+	CodeCategoryInUse        = "category_in_use"
+	CodeSupplierNotFound     = "supplier_not_found"
+	CodeSupplierAlreadyExist = "supplier_already_exist"
+	CodeSupplierInUse        = "supplier_in_use"
+
+	CodeUnitNotFound       = "unit_not_found"
+	CodeUnitAlreadyExist   = "unit_already_exist"
+	CodeUnitInUse          = "unit_in_use"
+	CodeUnitInvalidBase    = "unit_invalid_base"
+	CodeUnitUnknown        = "unit_unknown"
+	CodeUnitNotConvertible = "unit_not_convertible"
+
+	CodeWebhookNotFound = "webhook_not_found"
+
+	CodeDataNotFound            = "data_not_found"
+	CodeDataStillReferenced     = "data_still_referenced"
+	CodeDataReferenceNotFound   = "data_reference_not_found"
+	CodeDataAlreadyExist        = "data_already_exist"
+	CodeDataOutOfRange          = "data_out_of_range"
+	CodeDbReturnedMalformedData = "db_returned_malformed_data"
+
+	CodeInvalidSort                  = "invalid_sort"
+	CodeInvalidSortDirection         = "invalid_sort_direction"
+	CodeInvalidSortNulls             = "invalid_sort_nulls"
+	CodeInvalidIfMatchHeader         = "invalid_if_match_header"
+	CodeInvalidAdminToken            = "invalid_admin_token"
+	CodeIdempotencyKeyTooLong        = "idempotency_key_too_long"
+	CodeIdempotencyKeyReused         = "idempotency_key_reused"
+	CodeIdempotencyRequestInProgress = "idempotency_request_in_progress"
+)
+
 var (
-	GenericHttpStatusMappings = map[string]int{
-		BindingParameterFailed: http.StatusBadRequest,
-		InvalidUUID:            http.StatusBadRequest,
+	ProductHttpStatusMappings = map[string]int{
+		ProductCreateSuccess:         http.StatusCreated,
+		ProductGetSuccess:            http.StatusOK,
+		ProductUpdateSuccess:         http.StatusOK,
+		ProductDeleteSuccess:         http.StatusOK,
+		ProductRestoreSuccess:        http.StatusOK,
+		ProductPurgeSuccess:          http.StatusOK,
+		ProductSuggestSuccess:        http.StatusOK,
+		ProductFacetsSuccess:         http.StatusOK,
+		ProductBatchGetSuccess:       http.StatusOK,
+		ProductBulkCreateSuccess:     http.StatusCreated,
+		ProductBulkCreatePartial:     http.StatusMultiStatus,
+		ProductBulkCreateRejected:    http.StatusUnprocessableEntity,
+		ProductImportSuccess:         http.StatusCreated,
+		ProductImportValidated:       http.StatusOK,
+		ProductImportPartial:         http.StatusMultiStatus,
+		ProductDiscountCreateSuccess: http.StatusCreated,
+		ProductDiscountUpdateSuccess: http.StatusOK,
+		ProductDiscountDeleteSuccess: http.StatusOK,
+		StockMovementCreateSuccess:   http.StatusCreated,
+		StockHistoryGetSuccess:       http.StatusOK,
 	}
 
-	ProductHttpStatusMappings = map[string]int{
-		ProductCreateSuccess:        http.StatusCreated,
-		ProductCreateFailed:         http.StatusInternalServerError,
-		ProductGetSuccess:           http.StatusOK,
-		ProductGetFailed:            http.StatusInternalServerError,
-		ProductAlreadyExist:         http.StatusConflict,
-		ProductNotFound:             http.StatusNotFound,
-		DataNotFound:                http.StatusNotFound,
-		DbBeginTransactionFailed:    http.StatusInternalServerError,
-		DbRollbackTransactionFailed: http.StatusInternalServerError,
-		DbCommitTransactionFailed:   http.StatusInternalServerError,
-		DbReturnedMalformedData:     http.StatusInternalServerError,
+	ProductExportContentTypes = map[string]string{
+		ProductExportFormatCSV:     MIMETextCSV,
+		ProductExportFormatNDJSON:  MIMEApplicationNDJSON,
+		ProductExportFormatParquet: MIMEApplicationParquet,
+	}
+
+	CategoryHttpStatusMappings = map[string]int{
+		CategoryCreateSuccess: http.StatusCreated,
+		CategoryGetSuccess:    http.StatusOK,
+		CategoryUpdateSuccess: http.StatusOK,
+		CategoryDeleteSuccess: http.StatusOK,
+	}
+
+	UnitHttpStatusMappings = map[string]int{
+		UnitCreateSuccess: http.StatusCreated,
+		UnitGetSuccess:    http.StatusOK,
+		UnitUpdateSuccess: http.StatusOK,
+		UnitDeleteSuccess: http.StatusOK,
+	}
+
+	SupplierHttpStatusMappings = map[string]int{
+		SupplierCreateSuccess: http.StatusCreated,
+		SupplierGetSuccess:    http.StatusOK,
+		SupplierUpdateSuccess: http.StatusOK,
+		SupplierDeleteSuccess: http.StatusOK,
+	}
+
+	WebhookHttpStatusMappings = map[string]int{
+		WebhookCreateSuccess:      http.StatusCreated,
+		WebhookGetSuccess:         http.StatusOK,
+		WebhookDeleteSuccess:      http.StatusOK,
+		WebhookDeliveryGetSuccess: http.StatusOK,
 	}
 )
 
 var (
-	ValidProductSort   = []string{ProductSortCreatedAt, ProductSortBasePrice, ProductSortProductName}
+	ValidProductSort   = []string{ProductSortCreatedAt, ProductSortBasePrice, ProductSortProductName, ProductSortStock, ProductSortUpdatedAt, ProductSortRelevance}
 	ValidSortDirection = []string{SortDirectionAsc, SortDirectionDesc}
+	ValidSortNulls     = []string{SortNullsFirst, SortNullsLast}
+
+	ValidProductInclude = []string{ProductIncludeCategory, ProductIncludeSupplier, ProductIncludeUnit, ProductIncludeDiscount}
+
+	// ProductFacetPriceBuckets are the lower bounds of the price buckets of the product
+	// facets, used when the server config does not set them.
+	ProductFacetPriceBuckets = []float64{0, 5000, 10000, 25000, 50000, 100000}
+
+	// NullableProductSort lists the sort fields a product may have no value for.
+	NullableProductSort = []string{ProductSortUpdatedAt}
 )
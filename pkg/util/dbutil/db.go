@@ -0,0 +1,65 @@
+package dbutil
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/lib/pq"
+)
+
+const (
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+	pgCheckViolation      = "23514"
+)
+
+// Errors returned by the repositories. Services branch on their kind, e.g.
+// errors.Is(err, apperror.ErrNotFound), rather than on these exact values.
+var (
+	ErrDataNotFound          = apperror.NotFound(constant.CodeDataNotFound, constant.DataNotFound)
+	ErrDataStillReferenced   = apperror.Conflict(constant.CodeDataStillReferenced, constant.DataStillReferenced)
+	ErrDataAlreadyExist      = apperror.Conflict(constant.CodeDataAlreadyExist, constant.DataAlreadyExist)
+	ErrDataReferenceNotFound = apperror.Unprocessable(constant.CodeDataReferenceNotFound, constant.DataReferenceNotFound)
+	ErrDataOutOfRange        = apperror.Unprocessable(constant.CodeDataOutOfRange, constant.DataOutOfRange)
+	ErrMalformedData         = apperror.Internal(constant.CodeDbReturnedMalformedData, constant.DbReturnedMalformedData)
+)
+
+// CheckRowsAffected reports ErrDataNotFound when a write statement did not touch any row.
+func CheckRowsAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrDataNotFound
+	}
+
+	return nil
+}
+
+// IsForeignKeyViolation tells whether err was raised by a foreign key constraint.
+func IsForeignKeyViolation(err error) bool {
+	return hasErrorCode(err, pgForeignKeyViolation)
+}
+
+// IsUniqueViolation tells whether err was raised by a unique constraint.
+func IsUniqueViolation(err error) bool {
+	return hasErrorCode(err, pgUniqueViolation)
+}
+
+// IsCheckViolation tells whether err was raised by a check constraint.
+func IsCheckViolation(err error) bool {
+	return hasErrorCode(err, pgCheckViolation)
+}
+
+func hasErrorCode(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == code
+	}
+
+	return false
+}
@@ -0,0 +1,26 @@
+package pageutil
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// EncodeCursor turns a cursor value into an opaque, URL safe string.
+func EncodeCursor(cursor any) (string, error) {
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeCursor reads a string produced by EncodeCursor back into cursor.
+func DecodeCursor(encoded string, cursor any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, cursor)
+}
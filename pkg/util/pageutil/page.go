@@ -1,20 +1,89 @@
 package pageutil
 
 import (
-	"errors"
 	"reflect"
 	"strings"
 
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
 	"github.com/gunawanpras/be-product-service/pkg/util/constant"
 )
 
-func ValidateSortDirection(availSort []string, reqSort, reqDirection string) error {
-	if !ItemExists(availSort, strings.ToLower(reqSort)) {
-		return errors.New(constant.ErrInvalidSort)
+// SortKey orders a list by Field in Direction. Nulls places NULL values first or last,
+// leaving the database default (last when ascending, first when descending) when empty.
+type SortKey struct {
+	Field     string
+	Direction string
+	Nulls     string
+}
+
+// NullsLast reports whether NULL values of the key come after every other value.
+func (k SortKey) NullsLast() bool {
+	if k.Nulls == "" {
+		return k.Direction != constant.SortDirectionDesc
 	}
 
-	if !IsValidDirection(reqDirection) {
-		return errors.New(constant.ErrInvalidSortDirection)
+	return k.Nulls == constant.SortNullsLast
+}
+
+// ParseSort reads a comma separated list of sort fields such as "-base_price,name". A
+// field prefixed with "-" is sorted descending and one prefixed with "+" ascending, while
+// the others use direction, ascending when empty. Every field gets the given nulls
+// placement, and defaultSort is used when sort is empty. The keys still have to go
+// through ValidateSort.
+func ParseSort(sort, direction, nulls, defaultSort string) (keys []SortKey) {
+	direction = strings.ToLower(strings.TrimSpace(direction))
+	if direction == "" {
+		direction = constant.SortDirectionAsc
+	}
+
+	nulls = strings.ToLower(strings.TrimSpace(nulls))
+
+	if strings.TrimSpace(sort) == "" {
+		sort = defaultSort
+	}
+
+	for _, field := range strings.Split(sort, ",") {
+		key := SortKey{
+			Field:     strings.ToLower(strings.TrimSpace(field)),
+			Direction: direction,
+			Nulls:     nulls,
+		}
+
+		switch {
+		case strings.HasPrefix(key.Field, "-"):
+			key.Field, key.Direction = key.Field[1:], constant.SortDirectionDesc
+		case strings.HasPrefix(key.Field, "+"):
+			key.Field, key.Direction = key.Field[1:], constant.SortDirectionAsc
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// ValidateSort makes sure every key sorts by a field of availSort, at most once, with a
+// valid direction and nulls placement. Only keys passing it may be written into a query.
+func ValidateSort(availSort []string, keys []SortKey) error {
+	if len(keys) == 0 {
+		return apperror.Validation(constant.CodeInvalidSort, constant.ErrInvalidSort)
+	}
+
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if !ItemExists(availSort, key.Field) || seen[key.Field] {
+			return apperror.Validation(constant.CodeInvalidSort, constant.ErrInvalidSort)
+		}
+
+		seen[key.Field] = true
+
+		if !IsValidDirection(key.Direction) {
+			return apperror.Validation(constant.CodeInvalidSortDirection, constant.ErrInvalidSortDirection)
+		}
+
+		if key.Nulls != "" && !ItemExists(constant.ValidSortNulls, key.Nulls) {
+			return apperror.Validation(constant.CodeInvalidSortNulls, constant.ErrInvalidSortNulls)
+		}
 	}
 
 	return nil
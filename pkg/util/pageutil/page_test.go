@@ -0,0 +1,106 @@
+package pageutil_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gunawanpras/be-product-service/pkg/apperror"
+	"github.com/gunawanpras/be-product-service/pkg/util/constant"
+	"github.com/gunawanpras/be-product-service/pkg/util/pageutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name      string
+		sort      string
+		direction string
+		nulls     string
+		want      []pageutil.SortKey
+	}{
+		{
+			name: "default sort",
+			want: []pageutil.SortKey{{Field: "created_at", Direction: "asc"}},
+		},
+		{
+			name:      "single field with a direction",
+			sort:      "Name",
+			direction: "DESC",
+			want:      []pageutil.SortKey{{Field: "name", Direction: "desc"}},
+		},
+		{
+			name:      "prefixed fields override the direction",
+			sort:      "-base_price, name,+stock",
+			direction: "desc",
+			nulls:     "last",
+			want: []pageutil.SortKey{
+				{Field: "base_price", Direction: "desc", Nulls: "last"},
+				{Field: "name", Direction: "desc", Nulls: "last"},
+				{Field: "stock", Direction: "asc", Nulls: "last"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pageutil.ParseSort(tt.sort, tt.direction, tt.nulls, constant.ProductSortCreatedAt))
+		})
+	}
+}
+
+func TestValidateSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []pageutil.SortKey
+		wantCode string
+	}{
+		{
+			name: "valid keys",
+			keys: []pageutil.SortKey{{Field: "base_price", Direction: "desc"}, {Field: "updated_at", Direction: "asc", Nulls: "first"}},
+		},
+		{
+			name:     "no keys",
+			wantCode: constant.CodeInvalidSort,
+		},
+		{
+			name:     "field outside the whitelist",
+			keys:     []pageutil.SortKey{{Field: "name; DROP TABLE products", Direction: "asc"}},
+			wantCode: constant.CodeInvalidSort,
+		},
+		{
+			name:     "repeated field",
+			keys:     []pageutil.SortKey{{Field: "name", Direction: "asc"}, {Field: "name", Direction: "desc"}},
+			wantCode: constant.CodeInvalidSort,
+		},
+		{
+			name:     "invalid direction",
+			keys:     []pageutil.SortKey{{Field: "name", Direction: "up"}},
+			wantCode: constant.CodeInvalidSortDirection,
+		},
+		{
+			name:     "invalid nulls",
+			keys:     []pageutil.SortKey{{Field: "updated_at", Direction: "asc", Nulls: "middle"}},
+			wantCode: constant.CodeInvalidSortNulls,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := pageutil.ValidateSort(constant.ValidProductSort, tt.keys)
+			if tt.wantCode == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, apperror.ErrValidation))
+			assert.Equal(t, tt.wantCode, apperror.CodeOf(err))
+		})
+	}
+}
+
+func TestSortKey_NullsLast(t *testing.T) {
+	assert.True(t, pageutil.SortKey{Direction: "asc"}.NullsLast())
+	assert.False(t, pageutil.SortKey{Direction: "desc"}.NullsLast())
+	assert.True(t, pageutil.SortKey{Direction: "desc", Nulls: "last"}.NullsLast())
+	assert.False(t, pageutil.SortKey{Direction: "asc", Nulls: "first"}.NullsLast())
+}
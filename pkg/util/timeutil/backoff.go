@@ -0,0 +1,14 @@
+package timeutil
+
+import "time"
+
+// Backoff is the delay before retrying an operation which failed after the given number
+// of previous attempts, doubling from base with each attempt up to maxDelay.
+func Backoff(attempts int, base, maxDelay time.Duration) time.Duration {
+	delay := base
+	for i := 0; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
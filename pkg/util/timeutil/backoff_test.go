@@ -0,0 +1,39 @@
+package timeutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gunawanpras/be-product-service/pkg/util/timeutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{
+			name:     "first retry waits the base delay",
+			attempts: 0,
+			want:     time.Second,
+		},
+		{
+			name:     "delay doubles with each attempt",
+			attempts: 3,
+			want:     8 * time.Second,
+		},
+		{
+			name:     "delay is capped by the max delay",
+			attempts: 10,
+			want:     time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, timeutil.Backoff(tt.attempts, time.Second, time.Minute))
+		})
+	}
+}
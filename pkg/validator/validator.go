@@ -1,6 +1,10 @@
 package validator
 
 import (
+	"reflect"
+	"regexp"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -8,9 +12,45 @@ type ErrorResponse struct {
 	FailedField string
 	Tag         string
 	Value       string
+
+	// Field is the path of the failed field as the client sent it, using the json, query
+	// or uri tag names, e.g. "contact_info.email".
+	Field string `json:"-"`
+}
+
+var (
+	validate = validator.New()
+
+	// phoneRegex accepts local and international phone numbers such as
+	// "+62 812-3456-7890" or "0812 3456 7890".
+	phoneRegex = regexp.MustCompile(`^\+?[0-9][0-9 -]{5,19}$`)
+
+	// fieldNameTags are looked up in order to name a field after its request binding.
+	fieldNameTags = []string{"json", "query", "uri"}
+)
+
+func init() {
+	validate.RegisterTagNameFunc(fieldName)
+	validate.RegisterValidation("phone", validatePhone)
+}
+
+// fieldName names a struct field after the first binding tag it has, so validation errors
+// can be attached to the matching request field. Fields without one keep their Go name.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range fieldNameTags {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
 }
 
-var validate = validator.New()
+// validatePhone implements the "phone" tag.
+func validatePhone(fl validator.FieldLevel) bool {
+	return phoneRegex.MatchString(fl.Field().String())
+}
 
 func Validate(input any) (errResponse []*ErrorResponse) {
 	err := validate.Struct(input)
@@ -26,14 +66,65 @@ func Validate(input any) (errResponse []*ErrorResponse) {
 			}
 		}
 
+		embedded := embeddedStructs(reflect.TypeOf(input), map[reflect.Type]bool{})
+
 		for _, err := range err.(validator.ValidationErrors) {
 			var element ErrorResponse
 			//element.FailedField = err.StructNamespace()
 			element.FailedField = err.StructField() + "(" + err.Tag() + ": " + err.Param() + ")"
 			element.Tag = err.Tag()
 			element.Value = err.Param()
+			element.Field = fieldPath(err.Namespace(), embedded)
 			errResponse = append(errResponse, &element)
 		}
 	}
 	return errResponse
 }
+
+// fieldPath drops the name of the validated struct from a namespace such as
+// "CreateSupplierRequest.contact_info.email", along with the names of the embedded
+// structs, whose fields the client sends at the level of the embedding struct.
+func fieldPath(namespace string, embedded map[string]bool) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return namespace
+	}
+
+	var fields []string
+	for _, field := range strings.Split(path, ".") {
+		if !embedded[field] {
+			fields = append(fields, field)
+		}
+	}
+
+	return strings.Join(fields, ".")
+}
+
+// embeddedStructs collects the names of the structs embedded in t or in any struct it
+// holds, as they appear in validation namespaces.
+func embeddedStructs(t reflect.Type, seen map[reflect.Type]bool) map[string]bool {
+	names := map[string]bool{}
+
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || seen[t] {
+		return names
+	}
+
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && fieldName(field) == field.Name {
+			names[field.Name] = true
+		}
+
+		for name := range embeddedStructs(field.Type, seen) {
+			names[name] = true
+		}
+	}
+
+	return names
+}